@@ -13,7 +13,8 @@ import (
 //
 // It resolves the url and message flags from positional arguments (if provided)
 // or from the SHOUTRRR_URL environment variable. When the URL is sourced from
-// the environment, the message defaults to stdin ("-") unless already set.
+// the environment, the message defaults to stdin ("-") unless already set. When
+// a config file is given with the config flag, a positional argument is the message.
 //
 // Parameters:
 //   - cmd: the cobra.Command whose flags will be populated.
@@ -24,6 +25,18 @@ import (
 func LoadFlagsFromAltSources(cmd *cobra.Command, args []string) error {
 	flags := cmd.Flags()
 
+	// With a config file, the targets come from the file, so a positional
+	// argument is the message.
+	if configFlag := flags.Lookup("config"); configFlag != nil && configFlag.Value.String() != "" {
+		if len(args) > 0 {
+			if err := flags.Set("message", args[0]); err != nil {
+				return fmt.Errorf("setting message flag from positional arg: %w", err)
+			}
+		}
+
+		return nil
+	}
+
 	if len(args) > 0 {
 		if err := flags.Set("url", args[0]); err != nil {
 			return fmt.Errorf("setting url flag from positional arg: %w", err)
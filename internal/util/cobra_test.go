@@ -190,6 +190,34 @@ func TestLoadFlagsFromAltSources_EnvVarOverridesEmptyFlag(t *testing.T) {
 	assert.Equal(t, "https://env.example.com", urls[0])
 }
 
+func TestLoadFlagsFromAltSources_ConfigFlagTakesMessage(t *testing.T) {
+	t.Parallel()
+
+	// With a config file, the positional arg is the message and the env var is ignored
+	cmd := setupTestCommand()
+	cmd.Flags().String("config", "", "The config file")
+
+	require.NoError(t, cmd.Flags().Set("config", "shoutrrr.yaml"))
+
+	// Serialize viper access
+	viperMu.Lock()
+	viper.Reset()
+	viper.Set("SHOUTRRR_URL", "https://env.example.com")
+
+	err := LoadFlagsFromAltSources(cmd, []string{"config message"})
+	viperMu.Unlock()
+
+	require.NoError(t, err)
+
+	urls, err := cmd.Flags().GetStringArray("url")
+	require.NoError(t, err)
+	assert.Empty(t, urls)
+
+	message, err := cmd.Flags().GetString("message")
+	require.NoError(t, err)
+	assert.Equal(t, "config message", message)
+}
+
 func TestLoadFlagsFromAltSources_ErrorCases(t *testing.T) {
 	t.Parallel()
 
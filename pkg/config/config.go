@@ -0,0 +1,386 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/pelletier/go-toml/v2"
+	"go.yaml.in/yaml/v3"
+
+	"github.com/nicholas-fedor/shoutrrr/pkg/router"
+	"github.com/nicholas-fedor/shoutrrr/pkg/types"
+)
+
+// Format is the syntax of a config file.
+type Format string
+
+const (
+	// YAML is the YAML config file format, used for .yaml and .yml files.
+	YAML Format = "yaml"
+	// TOML is the TOML config file format, used for .toml files.
+	TOML Format = "toml"
+)
+
+var (
+	ErrUnsupportedFormat = errors.New("unsupported config file format")
+	ErrNoTargets         = errors.New("config defines no targets")
+	ErrInvalidName       = errors.New("invalid name")
+	ErrMissingURL        = errors.New("target has no URL")
+	ErrUnknownTarget     = errors.New("unknown target")
+	ErrEmptyRoute        = errors.New("route has no targets")
+	ErrInvalidLevel      = errors.New("invalid message level")
+	ErrInvalidTemplate   = errors.New("invalid template")
+)
+
+// Config is a declarative description of the targets and routes of a router.
+type Config struct {
+	// Templates maps template IDs to template bodies shared by all targets.
+	Templates map[string]string `toml:"templates" yaml:"templates"`
+	// Targets maps target names to targets.
+	Targets map[string]Target `toml:"targets" yaml:"targets"`
+	// Routes maps route names to routes.
+	Routes map[string]Route `toml:"routes" yaml:"routes"`
+}
+
+// Target is a named service URL with its default parameters.
+type Target struct {
+	// URL is the service URL. It may contain secret references.
+	URL string `toml:"url" yaml:"url"`
+	// Params are the default parameters of the target's deliveries.
+	Params map[string]string `toml:"params" yaml:"params"`
+	// MinLevel is the lowest message level delivered to the target.
+	MinLevel string `toml:"min_level" yaml:"min_level"`
+	// Levels, if not empty, restricts delivery to the listed message levels.
+	Levels []string `toml:"levels" yaml:"levels"`
+}
+
+// Route is a named list of targets that messages can be sent to together.
+type Route struct {
+	// Targets are the names of the targets of the route, in failover order.
+	Targets []string `toml:"targets" yaml:"targets"`
+	// Mode is "all" (the default), "failover", "first-success" or "quorum".
+	Mode string `toml:"mode" yaml:"mode"`
+	// Quorum is the number of targets that must deliver in quorum mode.
+	// Zero requires all targets to deliver.
+	Quorum int `toml:"quorum" yaml:"quorum"`
+	// MinLevel is the lowest message level delivered to the route.
+	MinLevel string `toml:"min_level" yaml:"min_level"`
+	// Levels, if not empty, restricts delivery to the listed message levels.
+	Levels []string `toml:"levels" yaml:"levels"`
+}
+
+// routeModeAll is the default route mode, delivering to every target.
+const routeModeAll = "all"
+
+// Load reads the config file at path. The format is determined by the file
+// extension. The config is validated when a router is created from it.
+//
+// Parameters:
+//   - path: the path of a .yaml, .yml or .toml file.
+//
+// Returns:
+//   - *Config: the loaded config.
+//   - error: an error if the file cannot be read or decoded.
+func Load(path string) (*Config, error) {
+	var format Format
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		format = YAML
+	case ".toml":
+		format = TOML
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedFormat, filepath.Ext(path))
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading config file: %w", err)
+	}
+
+	config, err := Parse(data, format)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return config, nil
+}
+
+// Parse decodes a config. Unknown keys are rejected.
+//
+// Parameters:
+//   - data: the config contents.
+//   - format: the syntax of data.
+//
+// Returns:
+//   - *Config: the parsed config.
+//   - error: an error if data cannot be decoded.
+func Parse(data []byte, format Format) (*Config, error) {
+	config := &Config{}
+
+	switch format {
+	case YAML:
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+
+		if err := decoder.Decode(config); err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("decoding YAML: %w", err)
+		}
+	case TOML:
+		decoder := toml.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+
+		if err := decoder.Decode(config); err != nil {
+			return nil, fmt.Errorf("decoding TOML: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedFormat, format)
+	}
+
+	return config, nil
+}
+
+// Validate checks the templates, targets and routes of the config. Every target
+// URL is checked with router.ValidateURL, which parses it without initializing
+// its service or resolving its secret references. All problems are reported,
+// not just the first.
+//
+// Returns:
+//   - error: the joined validation errors, or nil if the config is valid.
+func (c *Config) Validate() error {
+	var errs []error
+
+	if len(c.Targets) == 0 {
+		errs = append(errs, ErrNoTargets)
+	}
+
+	for _, id := range sortedKeys(c.Templates) {
+		if _, err := template.New(id).Parse(c.Templates[id]); err != nil {
+			errs = append(errs, fmt.Errorf("template %q: %w: %w", id, ErrInvalidTemplate, err))
+		}
+	}
+
+	// Check secret references without resolving them, as NewRouter may expand them.
+	verifier := &router.ServiceRouter{}
+	verifier.SetExpandSecrets(true)
+
+	for _, name := range c.TargetNames() {
+		if err := c.Targets[name].validate(name, verifier); err != nil {
+			errs = append(errs, fmt.Errorf("target %q: %w", name, err))
+		}
+	}
+
+	for _, name := range c.RouteNames() {
+		if err := c.Routes[name].validate(name, c.Targets); err != nil {
+			errs = append(errs, fmt.Errorf("route %q: %w", name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// TargetNames returns the sorted names of the targets. Routers created by
+// NewRouter hold the targets in this order.
+//
+// Returns:
+//   - []string: the target names.
+func (c *Config) TargetNames() []string {
+	return sortedKeys(c.Targets)
+}
+
+// RouteNames returns the sorted names of the routes.
+//
+// Returns:
+//   - []string: the route names.
+func (c *Config) RouteNames() []string {
+	return sortedKeys(c.Routes)
+}
+
+// NewRouter validates the config and creates a router holding every target of the
+// config, in TargetNames order, and a routing group for every route, named after
+// it. Send delivers to all targets; SendGroup delivers to a route. Routes share
+// the services of their targets, which are initialized once, and apply the
+// target level filters as well as their own.
+//
+// Parameters:
+//   - logger: the logger to use for service output.
+//   - opts: the sender options. The config templates are added to opts.Templates.
+//
+// Returns:
+//   - *router.ServiceRouter: the router.
+//   - error: an error if the config is invalid or a service fails to initialize.
+func (c *Config) NewRouter(logger types.StdLogger, opts types.SenderOptions) (*router.ServiceRouter, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	templates := make(map[string]string, len(opts.Templates)+len(c.Templates))
+	maps.Copy(templates, opts.Templates)
+	maps.Copy(templates, c.Templates)
+	opts.Templates = templates
+
+	serviceRouter, err := router.NewWithOptions(logger, opts)
+	if err != nil {
+		return nil, fmt.Errorf("creating router: %w", err)
+	}
+
+	names := c.TargetNames()
+	indices := make(map[string]int, len(names))
+
+	for i, name := range names {
+		target := c.Targets[name]
+		indices[name] = i
+
+		filter, _ := parseFilter(target.MinLevel, target.Levels)
+
+		err := serviceRouter.AddServiceWithOptions(target.URL, router.TargetOptions{
+			Filter: filter,
+			Params: types.Params(target.Params),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("target %q: %w", name, err)
+		}
+	}
+
+	for _, name := range c.RouteNames() {
+		route := c.Routes[name]
+
+		groupOpts, _ := route.groupOptions()
+		targets := make([]int, len(route.Targets))
+
+		for i, targetName := range route.Targets {
+			targets[i] = indices[targetName]
+		}
+
+		if err := serviceRouter.AddTargetGroup(name, groupOpts, targets...); err != nil {
+			return nil, fmt.Errorf("route %q: %w", name, err)
+		}
+	}
+
+	return serviceRouter, nil
+}
+
+// validate checks the target, parsing its URL with verifier.
+func (t Target) validate(name string, verifier *router.ServiceRouter) error {
+	if err := validateName(name); err != nil {
+		return err
+	}
+
+	if t.URL == "" {
+		return ErrMissingURL
+	}
+
+	if _, err := parseFilter(t.MinLevel, t.Levels); err != nil {
+		return err
+	}
+
+	if err := verifier.ValidateURL(t.URL); err != nil {
+		return fmt.Errorf("verifying URL: %w", err)
+	}
+
+	return nil
+}
+
+// validate checks the route against the defined targets.
+func (r Route) validate(name string, targets map[string]Target) error {
+	if err := validateName(name); err != nil {
+		return err
+	}
+
+	if len(r.Targets) == 0 {
+		return ErrEmptyRoute
+	}
+
+	var errs []error
+
+	for _, target := range r.Targets {
+		if _, ok := targets[target]; !ok {
+			errs = append(errs, fmt.Errorf("%w: %q", ErrUnknownTarget, target))
+		}
+	}
+
+	opts, err := r.groupOptions()
+	if err != nil {
+		errs = append(errs, err)
+	} else if opts.Quorum < 0 || opts.Quorum > len(r.Targets) {
+		errs = append(errs, fmt.Errorf("%w: %d of %d targets", router.ErrInvalidQuorum, opts.Quorum, len(r.Targets)))
+	}
+
+	return errors.Join(errs...)
+}
+
+// groupOptions returns the routing group options of the route, without member params.
+func (r Route) groupOptions() (router.GroupOptions, error) {
+	filter, err := parseFilter(r.MinLevel, r.Levels)
+	if err != nil {
+		return router.GroupOptions{}, err
+	}
+
+	opts := router.GroupOptions{Mode: router.GroupQuorum, Quorum: r.Quorum, Filter: filter}
+
+	if r.Mode != "" && !strings.EqualFold(r.Mode, routeModeAll) {
+		if opts.Mode, err = router.ParseGroupMode(r.Mode); err != nil {
+			return router.GroupOptions{}, err
+		}
+	} else if r.Quorum != 0 {
+		return router.GroupOptions{}, fmt.Errorf("%w: quorum requires quorum mode", router.ErrInvalidQuorum)
+	}
+
+	return opts, nil
+}
+
+// parseFilter returns the level filter for a minimum level and a list of levels.
+func parseFilter(minLevel string, levels []string) (router.LevelFilter, error) {
+	var filter router.LevelFilter
+
+	if minLevel != "" {
+		level, ok := types.ParseMessageLevel(minLevel)
+		if !ok {
+			return filter, fmt.Errorf("%w: %q", ErrInvalidLevel, minLevel)
+		}
+
+		filter.MinLevel = level
+	}
+
+	for _, name := range levels {
+		level, ok := types.ParseMessageLevel(name)
+		if !ok {
+			return filter, fmt.Errorf("%w: %q", ErrInvalidLevel, name)
+		}
+
+		filter.Levels = append(filter.Levels, level)
+	}
+
+	return filter, nil
+}
+
+// validateName checks that a target or route name is usable on the command line.
+func validateName(name string) error {
+	if name == "" || strings.ContainsFunc(name, func(r rune) bool {
+		return r <= ' ' || r == ','
+	}) {
+		return fmt.Errorf("%w: %q", ErrInvalidName, name)
+	}
+
+	return nil
+}
+
+// sortedKeys returns the sorted keys of m.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,230 @@
+package config_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nicholas-fedor/shoutrrr/pkg/config"
+	"github.com/nicholas-fedor/shoutrrr/pkg/router"
+	"github.com/nicholas-fedor/shoutrrr/pkg/secrets"
+	"github.com/nicholas-fedor/shoutrrr/pkg/types"
+)
+
+// webhook records the bodies of the requests it receives.
+type webhook struct {
+	mu     sync.Mutex
+	bodies []string
+}
+
+func (w *webhook) ServeHTTP(res http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+
+	w.mu.Lock()
+	w.bodies = append(w.bodies, string(body))
+	w.mu.Unlock()
+
+	res.WriteHeader(http.StatusNoContent)
+}
+
+func (w *webhook) received() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	bodies := w.bodies
+	w.bodies = nil
+
+	return bodies
+}
+
+func TestNewRouter(t *testing.T) {
+	t.Parallel()
+
+	hook := &webhook{}
+	server := httptest.NewServer(hook)
+	t.Cleanup(server.Close)
+
+	host := strings.TrimPrefix(server.URL, "http://")
+	cfg, err := config.Parse([]byte(`
+templates:
+  alert: "{{.title}}: {{.message}}"
+targets:
+  ops:
+    url: generic+http://`+host+`/ops?template=alert
+    params:
+      title: Ops
+  errors:
+    url: generic+http://`+host+`/errors
+    min_level: error
+routes:
+  escalate:
+    mode: failover
+    targets: [errors, ops]
+    min_level: warning
+`), config.YAML)
+	require.NoError(t, err)
+
+	sender, err := cfg.NewRouter(nil, types.SenderOptions{})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"errors", "ops"}, cfg.TargetNames())
+	assert.Equal(t, []string{"escalate"}, sender.Groups())
+
+	for _, err := range sender.Send("deployed", nil) {
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, []string{"Ops: deployed"}, hook.received(), "errors target should filter info messages")
+
+	result := sender.SendGroup("escalate", "disk almost full", nil)
+	require.NoError(t, result.Err)
+	assert.True(t, result.Skipped, "route should filter info messages")
+	assert.Empty(t, hook.received())
+
+	result = sender.SendGroup("escalate", "disk full", &types.Params{"level": "error", "title": "Disk"})
+	require.NoError(t, result.Err)
+	assert.Equal(t, []int{0}, result.DeliveredBy)
+	assert.Equal(t, []string{"disk full"}, hook.received())
+
+	result = sender.SendGroup("escalate", "disk at 95%", &types.Params{"level": "warning"})
+	require.NoError(t, result.Err)
+	assert.Equal(t, []int{1}, result.DeliveredBy, "route should apply the min_level of the errors target")
+	assert.Equal(t, []string{"Ops: disk at 95%"}, hook.received(), "route should apply the params of the ops target")
+}
+
+func TestLoadTOML(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "shoutrrr.toml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+[targets.log]
+url = "logger://"
+params = { title = "Deploys" }
+
+[routes.all]
+targets = ["log"]
+levels = ["info", "error"]
+`), 0o600))
+
+	cfg, err := config.Load(path)
+	require.NoError(t, err)
+
+	assert.Equal(t, config.Target{URL: "logger://", Params: map[string]string{"title": "Deploys"}}, cfg.Targets["log"])
+	assert.Equal(t, config.Route{Targets: []string{"log"}, Levels: []string{"info", "error"}}, cfg.Routes["all"])
+	require.NoError(t, cfg.Validate())
+}
+
+func TestLoadRejectsUnsupportedFormats(t *testing.T) {
+	t.Parallel()
+
+	_, err := config.Load("shoutrrr.json")
+	require.ErrorIs(t, err, config.ErrUnsupportedFormat)
+}
+
+func TestParseRejectsUnknownKeys(t *testing.T) {
+	t.Parallel()
+
+	_, err := config.Parse([]byte("targets:\n  log:\n    uri: logger://\n"), config.YAML)
+	require.Error(t, err)
+
+	_, err = config.Parse([]byte("[targets.log]\nuri = \"logger://\"\n"), config.TOML)
+	require.Error(t, err)
+}
+
+func TestValidateReportsEveryProblem(t *testing.T) {
+	t.Parallel()
+
+	cfg := &config.Config{
+		Templates: map[string]string{"broken": "{{.title"},
+		Targets: map[string]config.Target{
+			"log":      {URL: "logger://"},
+			"unknown":  {URL: "nosuchservice://token@host"},
+			"empty":    {},
+			"chatty":   {URL: "logger://", MinLevel: "loud"},
+			"bad name": {URL: "logger://"},
+		},
+		Routes: map[string]config.Route{
+			"oncall": {Targets: []string{"log", "missing"}, Mode: "round-robin"},
+			"none":   {},
+			"most":   {Targets: []string{"log"}, Quorum: 1},
+		},
+	}
+
+	err := cfg.Validate()
+
+	require.ErrorIs(t, err, config.ErrInvalidTemplate)
+	require.ErrorIs(t, err, router.ErrUnknownService)
+	require.ErrorIs(t, err, config.ErrMissingURL)
+	require.ErrorIs(t, err, config.ErrInvalidLevel)
+	require.ErrorIs(t, err, config.ErrInvalidName)
+	require.ErrorIs(t, err, config.ErrUnknownTarget)
+	require.ErrorIs(t, err, router.ErrInvalidGroupMode)
+	require.ErrorIs(t, err, config.ErrEmptyRoute)
+	require.ErrorIs(t, err, router.ErrInvalidQuorum)
+	assert.NotContains(t, err.Error(), "token", "errors should not contain URLs")
+
+	_, err = cfg.NewRouter(nil, types.SenderOptions{})
+	require.Error(t, err)
+
+	_, err = (&config.Config{}).NewRouter(nil, types.SenderOptions{})
+	require.ErrorIs(t, err, config.ErrNoTargets)
+}
+
+//nolint:paralleltest // Modifies the shared provider registry.
+func TestValidateDoesNotResolveSecrets(t *testing.T) {
+	resolved := 0
+
+	require.NoError(t, secrets.Register("counting", secrets.ResolverFunc(func(context.Context, string) (string, error) {
+		resolved++
+
+		return "hook", nil
+	})))
+	defer secrets.Unregister("counting")
+
+	cfg := &config.Config{
+		Targets: map[string]config.Target{"hook": {URL: "generic://example.com/${counting:path}"}},
+	}
+
+	require.NoError(t, cfg.Validate())
+	assert.Zero(t, resolved, "Validate should not resolve secret references")
+
+	_, err := cfg.NewRouter(nil, types.SenderOptions{ExpandSecrets: true})
+	require.NoError(t, err)
+	assert.Equal(t, 1, resolved, "NewRouter should resolve secret references once")
+}
+
+//nolint:paralleltest // Modifies the shared provider registry.
+func TestNewRouterInitializesTargetsOnce(t *testing.T) {
+	resolved := 0
+
+	require.NoError(t, secrets.Register("counting", secrets.ResolverFunc(func(context.Context, string) (string, error) {
+		resolved++
+
+		return "hook", nil
+	})))
+	defer secrets.Unregister("counting")
+
+	cfg := &config.Config{
+		Targets: map[string]config.Target{
+			"hook": {URL: "generic://example.com/${counting:path}"},
+			"log":  {URL: "logger://"},
+		},
+		Routes: map[string]config.Route{
+			"primary": {Targets: []string{"hook", "log"}, Mode: "failover"},
+			"backup":  {Targets: []string{"log", "hook"}, Mode: "failover"},
+		},
+	}
+
+	_, err := cfg.NewRouter(nil, types.SenderOptions{ExpandSecrets: true})
+	require.NoError(t, err)
+	assert.Equal(t, 1, resolved, "a target shared by routes should be initialized once")
+}
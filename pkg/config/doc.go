@@ -0,0 +1,60 @@
+// Package config loads routers from declarative config files, so that many
+// notification targets can be maintained in one place instead of being passed
+// as URLs on the command line.
+//
+// # File Format
+//
+// Config files are written in YAML (.yaml, .yml) or TOML (.toml):
+//
+//	templates:
+//	  alert: "[{{.title}}] {{.message}}"
+//
+//	targets:
+//	  ops-slack:
+//	    url: slack://hook:${env:SLACK_WEBHOOK}@webhook
+//	    params:
+//	      title: Ops
+//	  pager:
+//	    url: pagerduty://events.pagerduty.com/${env:PD_KEY}
+//	    min_level: error
+//	  mail:
+//	    url: smtp://user:${file:/run/secrets/smtp}@mail.example.com:587/?from=ops@example.com&to=oncall@example.com
+//
+//	routes:
+//	  ops:
+//	    targets: [ops-slack, mail]
+//	    min_level: warning
+//	  oncall:
+//	    mode: failover
+//	    targets: [pager, ops-slack]
+//
+// Targets are service URLs with default parameters, which parameters passed with
+// a message override, and an optional level filter (min_level and levels).
+// Routes name a list of targets and deliver to them the way a routing group does:
+// mode is "all" (the default, every target must deliver), "failover",
+// "first-success" or "quorum". A route's level filter applies to all of its
+// targets. Templates are registered on every target's service by ID.
+//
+// # Validation
+//
+// Load and Parse reject unknown keys. Validate, which NewRouter calls before
+// creating any service, checks the whole config up front: every template is
+// parsed, every target URL is parsed with router.ValidateURL, without initializing
+// its service or resolving its secret references, and every route must refer to
+// defined targets. All problems are reported at once.
+//
+// # Usage
+//
+//	cfg, err := config.Load("/etc/shoutrrr.yaml")
+//	if err != nil {
+//	    // handle error
+//	}
+//
+//	sender, err := cfg.NewRouter(logger, types.SenderOptions{ExpandSecrets: true})
+//	if err != nil {
+//	    // handle error
+//	}
+//
+//	sender.Send("Deployed", nil)           // every target
+//	sender.SendGroup("oncall", "Down", nil) // one route
+package config
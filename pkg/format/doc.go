@@ -43,6 +43,8 @@
 //
 // Key functions:
 //   - ConvertFormat: Convert between any supported formats
+//   - RenderMarkup: Render a body to the markup of a service, such as Slack mrkdwn
+//   - RenderBody: Render a body declaring its format with the format param
 //
 // # Property Serialization
 //
@@ -66,6 +68,16 @@
 //   - ConsoleTreeRenderer: Render configuration as console-friendly tree
 //   - MarkdownTreeRenderer: Render configuration as markdown
 //
+// # Redaction
+//
+// Config fields tagged `sensitive:"true"` hold credentials. The package redacts them
+// so that service URLs can be logged and reported without leaking secrets:
+//
+// Key functions:
+//   - RedactConfig: Copy a config with its sensitive fields redacted
+//   - RedactURL: Get the URL of a config with its sensitive fields redacted
+//   - RedactServiceURL: Get the redacted URL of an initialized service
+//
 // # Key Concepts
 //
 // KeyPrefix ("__"): Prefix used to escape custom URL query keys that would otherwise
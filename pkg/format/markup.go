@@ -0,0 +1,348 @@
+package format
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/nicholas-fedor/shoutrrr/pkg/types"
+)
+
+// Body formats. MarkupText, MarkupMarkdown and MarkupHTML are the formats a
+// sender can declare with the format param. Services render them to their own
+// markup, which may also be MarkupSlack or MarkupTelegramHTML.
+const (
+	// MarkupText is plain text.
+	MarkupText = "text"
+	// MarkupMarkdown is markdown, as rendered by Discord, Teams and Mattermost.
+	MarkupMarkdown = "markdown"
+	// MarkupHTML is HTML, as rendered by email clients and Matrix.
+	MarkupHTML = "html"
+	// MarkupSlack is Slack's mrkdwn.
+	MarkupSlack = "mrkdwn"
+	// MarkupTelegramHTML is the HTML subset of the Telegram Bot API.
+	MarkupTelegramHTML = "telegram-html"
+)
+
+// ErrUnknownMarkup is returned for a body format that is not text, markdown or html.
+var ErrUnknownMarkup = errors.New("unknown body format")
+
+// markupFence opens and closes a fenced code block.
+const markupFence = "```"
+
+var (
+	markupCodeRegex       = regexp.MustCompile("`([^`]+)`")
+	markupLinkRegex       = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+	markupHeadingRegex    = regexp.MustCompile(`^#{1,6}\s+(.+)$`)
+	markupStrikeRegex     = regexp.MustCompile(`~~(.+?)~~`)
+	markupPlaceholder     = regexp.MustCompile("\x00([0-9]+)\x00")
+	mrkdwnBoldRegex       = regexp.MustCompile(`\*\*(.+?)\*\*|__(.+?)__`)
+	mrkdwnItalicStarRegex = regexp.MustCompile(`\*([^*\s][^*]*?)\*`)
+)
+
+// ParseMarkup validates a body format declared by a sender. The match is
+// case-insensitive.
+//
+// Parameters:
+//   - name: the format name.
+//
+// Returns:
+//   - string: MarkupText, MarkupMarkdown or MarkupHTML.
+//   - error: an error wrapping ErrUnknownMarkup for any other name.
+func ParseMarkup(name string) (string, error) {
+	switch markup := strings.ToLower(strings.TrimSpace(name)); markup {
+	case MarkupText, MarkupMarkdown, MarkupHTML:
+		return markup, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnknownMarkup, name)
+	}
+}
+
+// RenderMarkup renders a body written in one of the sender formats to the markup
+// of a service, escaping it so that it displays as intended.
+//
+// Parameters:
+//   - body: the message body.
+//   - from: the format of body, "text", "markdown" or "html".
+//   - to: the markup to render, one of the Markup constants.
+//
+// Returns:
+//   - string: the rendered body.
+//   - error: an error wrapping ErrUnknownMarkup if either format is unknown.
+func RenderMarkup(body, from, to string) (string, error) {
+	from, err := ParseMarkup(from)
+	if err != nil {
+		return "", err
+	}
+
+	switch to {
+	case MarkupText:
+		if from == MarkupMarkdown {
+			// Keep the link targets, which ConvertFormat drops.
+			return strings.TrimSpace(renderMarkdown(body, textSpan, textLine, textBlock)), nil
+		}
+
+		return ConvertFormat(body, from, to)
+	case MarkupMarkdown:
+		return ConvertFormat(body, from, to)
+	case MarkupHTML:
+		switch from {
+		case MarkupHTML:
+			return body, nil
+		case MarkupText:
+			return htmlLineBreaks(html.EscapeString(body)), nil
+		default:
+			return htmlLineBreaks(renderMarkdown(body, htmlSpan, htmlLine, htmlBlock)), nil
+		}
+	case MarkupSlack:
+		if from == MarkupText {
+			return escapeMrkdwn(body), nil
+		}
+
+		return renderMarkdown(toMarkdown(body, from), mrkdwnSpan, mrkdwnLine, mrkdwnBlock), nil
+	case MarkupTelegramHTML:
+		if from == MarkupText {
+			return html.EscapeString(body), nil
+		}
+
+		return renderMarkdown(toMarkdown(body, from), htmlSpan, htmlLine, htmlBlock), nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnknownMarkup, to)
+	}
+}
+
+// RenderBody renders a message body to the markup of a service if the sender
+// declared its format with the format param.
+//
+// Parameters:
+//   - body: the message body.
+//   - params: the params of the message, may be nil.
+//   - to: the markup to render, one of the Markup constants.
+//
+// Returns:
+//   - string: the rendered body, or body if params declares no format.
+//   - bool: true if params declares a format.
+//   - error: an error wrapping ErrUnknownMarkup if the declared format is unknown.
+func RenderBody(body string, params *types.Params, to string) (string, bool, error) {
+	if params == nil {
+		return body, false, nil
+	}
+
+	from, found := (*params)[types.FormatKey]
+	if !found {
+		return body, false, nil
+	}
+
+	rendered, err := RenderMarkup(body, from, to)
+	if err != nil {
+		return "", true, err
+	}
+
+	return rendered, true, nil
+}
+
+// toMarkdown converts a markdown or HTML body to markdown.
+func toMarkdown(body, from string) string {
+	if from == MarkupHTML {
+		return htmlToMarkdown(body)
+	}
+
+	return body
+}
+
+// renderMarkdown renders markdown line by line. Code spans and links are rendered
+// by span before line renders the emphasis of the rest of the line, so that their
+// contents are left as is. Fenced code blocks are rendered as a whole by block,
+// which is passed the info string of the opening fence and the lines of code; a
+// block left open runs to the end of the body.
+func renderMarkdown(
+	body string,
+	span func(code, text, url string) string,
+	line func(string) string,
+	block func(info string, code []string) string,
+) string {
+	var (
+		rendered []string
+		code     []string
+		info     string
+		fenced   bool
+	)
+
+	for text := range strings.SplitSeq(body, "\n") {
+		trimmed := strings.TrimSpace(text)
+
+		switch {
+		case fenced && strings.HasPrefix(trimmed, markupFence) && strings.Trim(trimmed, "`") == "":
+			rendered = append(rendered, block(info, code))
+			code, fenced = nil, false
+		case fenced:
+			code = append(code, text)
+		case strings.HasPrefix(trimmed, markupFence):
+			info, fenced = strings.TrimSpace(strings.TrimLeft(trimmed, "`")), true
+		default:
+			rendered = append(rendered, renderMarkdownLine(text, span, line))
+		}
+	}
+
+	if fenced {
+		rendered = append(rendered, block(info, code))
+	}
+
+	return strings.Join(rendered, "\n")
+}
+
+// renderMarkdownLine renders a markdown line outside of code blocks.
+func renderMarkdownLine(text string, span func(code, text, url string) string, line func(string) string) string {
+	var spans []string
+
+	protect := func(rendered string) string {
+		spans = append(spans, rendered)
+
+		return "\x00" + strconv.Itoa(len(spans)-1) + "\x00"
+	}
+
+	text = markupCodeRegex.ReplaceAllStringFunc(text, func(match string) string {
+		return protect(span(markupCodeRegex.FindStringSubmatch(match)[1], "", ""))
+	})
+	text = markupLinkRegex.ReplaceAllStringFunc(text, func(match string) string {
+		groups := markupLinkRegex.FindStringSubmatch(match)
+
+		return protect(span("", groups[1], groups[2]))
+	})
+	text = line(text)
+
+	return markupPlaceholder.ReplaceAllStringFunc(text, func(match string) string {
+		index, _ := strconv.Atoi(markupPlaceholder.FindStringSubmatch(match)[1])
+
+		return spans[index]
+	})
+}
+
+// textBlock renders a fenced code block as plain text, without its fences.
+func textBlock(_ string, code []string) string {
+	return strings.Join(code, "\n")
+}
+
+// textSpan renders a code span or a link as plain text.
+func textSpan(code, text, url string) string {
+	if url == "" {
+		return code
+	}
+
+	return text + " (" + url + ")"
+}
+
+// textLine strips the markdown formatting of a line.
+func textLine(line string) string {
+	return markupStrikeRegex.ReplaceAllString(markdownToText(line), "$1")
+}
+
+// escapeMrkdwn escapes the characters Slack treats as control characters.
+func escapeMrkdwn(text string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(text)
+}
+
+// mrkdwnSpan renders a code span or a link as mrkdwn.
+func mrkdwnSpan(code, text, url string) string {
+	if url == "" {
+		return "`" + escapeMrkdwn(code) + "`"
+	}
+
+	return "<" + escapeMrkdwn(url) + "|" + escapeMrkdwn(text) + ">"
+}
+
+// mrkdwnBlock renders a fenced code block as mrkdwn, which has no info string.
+func mrkdwnBlock(_ string, code []string) string {
+	return markupFence + "\n" + escapeMrkdwn(strings.Join(code, "\n")) + "\n" + markupFence
+}
+
+// mrkdwnLine renders the headings and emphasis of a markdown line as mrkdwn.
+func mrkdwnLine(line string) string {
+	const (
+		boldPlaceholder = "\x00B\x00"
+		astPlaceholder  = "\x00AST\x00"
+		undPlaceholder  = "\x00UND\x00"
+	)
+
+	line = escapeMrkdwn(line)
+	line = strings.ReplaceAll(line, `\*`, astPlaceholder)
+	line = strings.ReplaceAll(line, `\_`, undPlaceholder)
+
+	if groups := markupHeadingRegex.FindStringSubmatch(line); groups != nil {
+		line = boldPlaceholder + groups[1] + boldPlaceholder
+	}
+
+	line = mrkdwnBoldRegex.ReplaceAllString(line, boldPlaceholder+"$1$2"+boldPlaceholder)
+	line = mrkdwnItalicStarRegex.ReplaceAllString(line, "_${1}_")
+	line = markupStrikeRegex.ReplaceAllString(line, "~$1~")
+	line = strings.ReplaceAll(line, boldPlaceholder, "*")
+	line = strings.ReplaceAll(line, astPlaceholder, "*")
+	line = strings.ReplaceAll(line, undPlaceholder, "_")
+
+	return line
+}
+
+// htmlLineBreaks appends a <br> tag to every line but the last, except within
+// the <pre> blocks rendered by htmlBlock.
+func htmlLineBreaks(text string) string {
+	lines := strings.Split(text, "\n")
+	pre := false
+
+	for i := range len(lines) - 1 {
+		if strings.HasPrefix(lines[i], "<pre>") {
+			pre = true
+		}
+
+		if pre && strings.HasSuffix(lines[i], "</pre>") {
+			pre = false
+		}
+
+		if !pre {
+			lines[i] += "<br>"
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// htmlBlock renders a fenced code block as a <pre> block, which the Telegram Bot
+// API supports as well, naming its language with the info string.
+func htmlBlock(info string, code []string) string {
+	escaped := html.EscapeString(strings.Join(code, "\n"))
+
+	if language, _, _ := strings.Cut(info, " "); language != "" {
+		return `<pre><code class="language-` + html.EscapeString(language) + `">` + escaped + "</code></pre>"
+	}
+
+	return "<pre>" + escaped + "</pre>"
+}
+
+// htmlSpan renders a code span or a link as HTML.
+func htmlSpan(code, text, url string) string {
+	if url == "" {
+		return "<code>" + html.EscapeString(code) + "</code>"
+	}
+
+	return `<a href="` + html.EscapeString(url) + `">` + html.EscapeString(text) + "</a>"
+}
+
+// htmlLine renders the headings and emphasis of a markdown line as HTML. Only the
+// tags supported by the Telegram Bot API are used, headings being rendered bold.
+func htmlLine(line string) string {
+	heading := false
+	if groups := markupHeadingRegex.FindStringSubmatch(line); groups != nil {
+		line, heading = groups[1], true
+	}
+
+	line = applyInlineFormatting(line)
+	line = markupStrikeRegex.ReplaceAllString(line, "<s>$1</s>")
+
+	if heading {
+		line = "<b>" + line + "</b>"
+	}
+
+	return line
+}
@@ -0,0 +1,174 @@
+package format
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nicholas-fedor/shoutrrr/pkg/types"
+)
+
+func TestParseMarkup(t *testing.T) {
+	t.Parallel()
+
+	for name, expected := range map[string]string{
+		"text":       MarkupText,
+		"Markdown":   MarkupMarkdown,
+		" HTML ":     MarkupHTML,
+		"markdownv2": "",
+		"mrkdwn":     "",
+		"":           "",
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParseMarkup(name)
+			if expected == "" {
+				require.ErrorIs(t, err, ErrUnknownMarkup)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, expected, got)
+		})
+	}
+}
+
+func TestRenderMarkup(t *testing.T) {
+	t.Parallel()
+
+	const alert = "# Alert\n**Disk** at `90%` see [docs](https://x.io/a_b) & <now>"
+
+	tests := []struct {
+		name     string
+		input    string
+		from     string
+		to       string
+		expected string
+	}{
+		{
+			"markdown to text keeps links", alert, MarkupMarkdown, MarkupText,
+			"Alert\nDisk at 90% see docs (https://x.io/a_b) & <now>",
+		},
+		{"html to text", "<p><b>bold</b> &amp; x</p>", MarkupHTML, MarkupText, "bold & x"},
+		{"text to markdown", "a < b & *c*", MarkupText, MarkupMarkdown, "a < b & \\*c\\*"},
+		{
+			"markdown to html", alert, MarkupMarkdown, MarkupHTML,
+			"<b>Alert</b><br>\n<strong>Disk</strong> at <code>90%</code> see " +
+				"<a href=\"https://x.io/a_b\">docs</a> &amp; &lt;now&gt;",
+		},
+		{"text to html", "a < b\nc", MarkupText, MarkupHTML, "a &lt; b<br>\nc"},
+		{"html to html", "<p>x</p>", MarkupHTML, MarkupHTML, "<p>x</p>"},
+		{
+			"markdown to mrkdwn", alert, MarkupMarkdown, MarkupSlack,
+			"*Alert*\n*Disk* at `90%` see <https://x.io/a_b|docs> &amp; &lt;now&gt;",
+		},
+		{"text to mrkdwn", "a < b & *c*", MarkupText, MarkupSlack, "a &lt; b &amp; *c*"},
+		{"html to mrkdwn", "<p><b>bold</b> &amp; x</p>", MarkupHTML, MarkupSlack, "*bold* &amp; x"},
+		{
+			"markdown to telegram html", alert, MarkupMarkdown, MarkupTelegramHTML,
+			"<b>Alert</b>\n<strong>Disk</strong> at <code>90%</code> see " +
+				"<a href=\"https://x.io/a_b\">docs</a> &amp; &lt;now&gt;",
+		},
+		{"text to telegram html", "a < b & *c*", MarkupText, MarkupTelegramHTML, "a &lt; b &amp; *c*"},
+		{
+			"html to telegram html", "<p><b>bold</b> &amp; x</p>", MarkupHTML, MarkupTelegramHTML,
+			"<strong>bold</strong> &amp; x",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := RenderMarkup(tt.input, tt.from, tt.to)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestRenderMarkupFencedCode(t *testing.T) {
+	t.Parallel()
+
+	const body = "Build **failed**:\n```go\nfunc __init__(p *int) { *p = 1 }\nif a < b {}\n```\ndone"
+
+	tests := []struct {
+		name     string
+		input    string
+		to       string
+		expected string
+	}{
+		{
+			"text", body, MarkupText,
+			"Build failed:\nfunc __init__(p *int) { *p = 1 }\nif a < b {}\ndone",
+		},
+		{
+			"html", body, MarkupHTML,
+			"Build <strong>failed</strong>:<br>\n<pre><code class=\"language-go\">func __init__(p *int) { *p = 1 }\n" +
+				"if a &lt; b {}</code></pre><br>\ndone",
+		},
+		{
+			"mrkdwn", body, MarkupSlack,
+			"Build *failed*:\n```\nfunc __init__(p *int) { *p = 1 }\nif a &lt; b {}\n```\ndone",
+		},
+		{
+			"telegram html", body, MarkupTelegramHTML,
+			"Build <strong>failed</strong>:\n<pre><code class=\"language-go\">func __init__(p *int) { *p = 1 }\n" +
+				"if a &lt; b {}</code></pre>\ndone",
+		},
+		{
+			"telegram html without info string", "```\nfunc __init__(p *int) { *p = 1 }\n```", MarkupTelegramHTML,
+			"<pre>func __init__(p *int) { *p = 1 }</pre>",
+		},
+		{
+			"unterminated block", "```\n**not bold**", MarkupTelegramHTML,
+			"<pre>**not bold**</pre>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := RenderMarkup(tt.input, MarkupMarkdown, tt.to)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestRenderMarkupUnknownFormat(t *testing.T) {
+	t.Parallel()
+
+	_, err := RenderMarkup("x", "rtf", MarkupHTML)
+	require.ErrorIs(t, err, ErrUnknownMarkup)
+
+	_, err = RenderMarkup("x", MarkupText, "rtf")
+	require.ErrorIs(t, err, ErrUnknownMarkup)
+}
+
+func TestRenderBody(t *testing.T) {
+	t.Parallel()
+
+	got, declared, err := RenderBody("**x**", nil, MarkupHTML)
+	require.NoError(t, err)
+	assert.False(t, declared)
+	assert.Equal(t, "**x**", got)
+
+	got, declared, err = RenderBody("**x**", &types.Params{"title": "t"}, MarkupHTML)
+	require.NoError(t, err)
+	assert.False(t, declared)
+	assert.Equal(t, "**x**", got)
+
+	got, declared, err = RenderBody("**x**", &types.Params{types.FormatKey: "markdown"}, MarkupHTML)
+	require.NoError(t, err)
+	assert.True(t, declared)
+	assert.Equal(t, "<strong>x</strong>", got)
+
+	_, declared, err = RenderBody("**x**", &types.Params{types.FormatKey: "rtf"}, MarkupHTML)
+	require.ErrorIs(t, err, ErrUnknownMarkup)
+	assert.True(t, declared)
+}
@@ -129,6 +129,8 @@ func (pkr *PropKeyResolver) SetDefaultProps(config types.ServiceConfig) error {
 
 // UpdateConfigFromParams mutates the provided config, updating the values from its corresponding params.
 // If the provided config is nil, the internal config will be updated instead.
+// The level and format params describe the message rather than the config, and
+// are skipped unless the config has a prop with their key.
 // The error returned is the first error that occurred; subsequent errors are discarded.
 func (pkr *PropKeyResolver) UpdateConfigFromParams(
 	config types.ServiceConfig,
@@ -140,6 +142,10 @@ func (pkr *PropKeyResolver) UpdateConfigFromParams(
 
 	if params != nil {
 		for key, val := range *params {
+			if _, found := pkr.keyFields[strings.ToLower(key)]; !found && isMessageParam(key) {
+				continue
+			}
+
 			if err := pkr.set(confValue, key, val); err != nil && firstError == nil {
 				firstError = err
 			}
@@ -149,6 +155,12 @@ func (pkr *PropKeyResolver) UpdateConfigFromParams(
 	return firstError
 }
 
+// isMessageParam reports whether key is a param describing the message, which
+// services without a matching config prop ignore.
+func isMessageParam(key string) bool {
+	return strings.EqualFold(key, types.LevelKey) || strings.EqualFold(key, types.FormatKey)
+}
+
 // configValueOrInternal returns the reflect.Value of the config, or the internal config if config is nil.
 func (pkr *PropKeyResolver) configValueOrInternal(config types.ServiceConfig) reflect.Value {
 	if config != nil {
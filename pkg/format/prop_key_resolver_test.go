@@ -41,6 +41,13 @@ var _ = ginkgo.Describe("Prop Key Resolver", func() {
 				gomega.Expect(ts.Str).To(gomega.Equal("val"))
 			})
 		})
+		ginkgo.When("a level or format param does not match a prop key", func() {
+			ginkgo.It("should be skipped", func() {
+				err := pkr.UpdateConfigFromParams(nil, &types.Params{"level": "error", "format": "html", "str": "val"})
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Expect(ts.Str).To(gomega.Equal("val"))
+			})
+		})
 	})
 	ginkgo.Describe("Setting default props", func() {
 		ginkgo.When("a default tag are set for a field", func() {
@@ -0,0 +1,141 @@
+package format
+
+import (
+	"reflect"
+
+	"github.com/nicholas-fedor/shoutrrr/pkg/types"
+	"github.com/nicholas-fedor/shoutrrr/pkg/util"
+)
+
+// Redactor is implemented by sensitive config field types that are not strings,
+// to replace their value with a redacted placeholder in place.
+type Redactor interface {
+	Redact()
+}
+
+// RedactConfig returns a copy of config with the values of the fields tagged
+// `sensitive:"true"` replaced by util.RedactedPlaceholder. Sensitive string slices
+// have every item replaced, types implementing Redactor redact themselves, and
+// other sensitive fields are reset to their zero value. Empty values are kept.
+//
+// Parameters:
+//   - config: the service config to redact.
+//
+// Returns:
+//   - types.ServiceConfig: the redacted copy, or config itself if it has no sensitive fields.
+func RedactConfig(config types.ServiceConfig) types.ServiceConfig {
+	value := reflect.ValueOf(config)
+	if value.Kind() != reflect.Pointer || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return config
+	}
+
+	sensitive := sensitiveFields(value.Elem().Type())
+	if len(sensitive) == 0 {
+		return config
+	}
+
+	redacted := reflect.New(value.Elem().Type())
+	redacted.Elem().Set(value.Elem())
+
+	for _, index := range sensitive {
+		redactField(redacted.Elem().Field(index))
+	}
+
+	if redactedConfig, ok := redacted.Interface().(types.ServiceConfig); ok {
+		return redactedConfig
+	}
+
+	return config
+}
+
+// RedactURL returns the URL of config with its credentials redacted. The values of
+// fields tagged `sensitive:"true"` are replaced by util.RedactedPlaceholder; configs
+// that declare no sensitive fields are redacted heuristically with util.RedactURL.
+//
+// Parameters:
+//   - config: the service config whose URL to redact.
+//
+// Returns:
+//   - string: the redacted URL.
+func RedactURL(config types.ServiceConfig) string {
+	redacted := RedactConfig(config)
+	if redacted == config {
+		return util.RedactURL(config.GetURL().String())
+	}
+
+	return redacted.GetURL().String()
+}
+
+// RedactServiceURL returns the URL of the service's config with its credentials
+// redacted, as RedactURL does. Services without an initialized Config field are
+// identified by their ID instead.
+//
+// Parameters:
+//   - service: the service whose URL to redact.
+//
+// Returns:
+//   - string: the redacted URL, or the service ID.
+func RedactServiceURL(service types.Service) string {
+	value := reflect.Indirect(reflect.ValueOf(service))
+	if value.Kind() != reflect.Struct {
+		return service.GetID()
+	}
+
+	configField, ok := value.Type().FieldByName("Config")
+	if !ok || !configField.IsExported() {
+		return service.GetID()
+	}
+
+	configValue, err := value.FieldByIndexErr(configField.Index)
+	if err != nil || (configValue.Kind() == reflect.Pointer && configValue.IsNil()) {
+		return service.GetID()
+	}
+
+	config, ok := configValue.Interface().(types.ServiceConfig)
+	if !ok {
+		return service.GetID()
+	}
+
+	return RedactURL(config)
+}
+
+// sensitiveFields returns the indices of the fields of structType tagged `sensitive:"true"`.
+func sensitiveFields(structType reflect.Type) []int {
+	var indices []int
+
+	for i := range structType.NumField() {
+		field := structType.Field(i)
+		if field.IsExported() && field.Tag.Get("sensitive") == "true" {
+			indices = append(indices, i)
+		}
+	}
+
+	return indices
+}
+
+// redactField replaces the value of a sensitive field.
+func redactField(field reflect.Value) {
+	if field.IsZero() {
+		return
+	}
+
+	if redactor, ok := field.Addr().Interface().(Redactor); ok {
+		redactor.Redact()
+
+		return
+	}
+
+	switch {
+	case field.Kind() == reflect.String:
+		field.SetString(util.RedactedPlaceholder)
+	case field.Kind() == reflect.Slice && field.Type().Elem().Kind() == reflect.String:
+		items := reflect.MakeSlice(field.Type(), field.Len(), field.Len())
+		for i := range field.Len() {
+			items.Index(i).SetString(util.RedactedPlaceholder)
+		}
+
+		field.Set(items)
+	default:
+		field.SetZero()
+	}
+}
@@ -0,0 +1,45 @@
+// Package httprecord records and replays the HTTP exchanges of services, so that
+// notification payloads can be snapshot-tested without a network.
+//
+// A Client implements types.HTTPClient. Passed as SenderOptions.HTTPClient, it is
+// injected into every service that implements types.HTTPClientSetter.
+//
+// # Golden Files
+//
+// In Record mode, every request is sent upstream and the exchange is written to a
+// golden file: the method, the URL, the headers and the body of the request, and
+// the status, headers and body of the response. Secrets are redacted before
+// anything is written:
+//
+//   - the values listed in Options.Secrets, wherever they appear;
+//   - userinfo, and query parameters and form fields whose name looks sensitive;
+//   - headers whose name looks sensitive, such as Authorization, and cookies;
+//   - every URL path segment, if no secrets are listed.
+//
+// In Replay mode, the golden file is loaded and each request is served the
+// recorded response of the first unused interaction with the same method,
+// redacted URL and redacted body. A request whose body differs from the
+// recording fails with ErrBodyMismatch, showing both bodies.
+//
+// # Usage
+//
+//	var update = flag.Bool("update", false, "record golden files")
+//
+//	func TestAlertPayload(t *testing.T) {
+//	    mode := httprecord.Replay
+//	    if *update {
+//	        mode = httprecord.Record
+//	    }
+//
+//	    client, err := httprecord.New("testdata/slack.json", httprecord.Options{
+//	        Mode:    mode,
+//	        Secrets: []string{os.Getenv("SLACK_TOKEN")},
+//	    })
+//	    // ...
+//	    sender, err := shoutrrr.CreateSenderWithOptions(types.SenderOptions{HTTPClient: client}, slackURL)
+//	    // ...
+//	    if unused := client.Unused(); len(unused) > 0 {
+//	        t.Errorf("%d recorded requests were not made", len(unused))
+//	    }
+//	}
+package httprecord
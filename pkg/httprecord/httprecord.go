@@ -0,0 +1,456 @@
+package httprecord
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/nicholas-fedor/shoutrrr/pkg/types"
+	"github.com/nicholas-fedor/shoutrrr/pkg/util"
+)
+
+// Mode selects whether a Client records or replays HTTP exchanges.
+type Mode int
+
+const (
+	// Replay serves the responses recorded in the golden file, without a network.
+	Replay Mode = iota
+	// Record sends requests upstream and records the exchanges to the golden file.
+	Record
+)
+
+const (
+	dirPerm  = 0o755
+	filePerm = 0o644
+	// base64Prefix marks base64-encoded bodies in golden files.
+	base64Prefix = "base64:"
+)
+
+var (
+	ErrNoInteraction = errors.New("no recorded interaction matches the request")
+	ErrBodyMismatch  = errors.New("request body differs from the recording")
+	ErrInvalidMode   = errors.New("invalid record mode")
+)
+
+// Options configures a Client.
+type Options struct {
+	// Mode selects recording or replaying. The zero value replays.
+	Mode Mode
+
+	// Client performs the requests in Record mode. Defaults to http.DefaultClient.
+	Client types.HTTPClient
+
+	// Secrets are values replaced by util.RedactedPlaceholder wherever they appear
+	// in recorded URLs, headers and bodies, e.g. the tokens of the service URLs.
+	// If empty, every path segment of recorded URLs is redacted instead, as
+	// util.RedactURL does.
+	Secrets []string
+}
+
+// Cassette is the contents of a golden file.
+type Cassette struct {
+	// Interactions are the recorded exchanges, in the order they were made.
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a recorded request and the response it received.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is a recorded request with its secrets redacted.
+type Request struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   Body        `json:"body,omitzero"`
+}
+
+// Response is a recorded response.
+type Response struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       Body        `json:"body,omitzero"`
+}
+
+// Body is a recorded message body. It is stored as text if it is valid UTF-8,
+// and base64-encoded otherwise.
+type Body []byte
+
+// Client is a types.HTTPClient that records HTTP exchanges to a golden file, or
+// replays them from it. It is safe for concurrent use.
+type Client struct {
+	path     string
+	opts     Options
+	mu       sync.Mutex
+	cassette Cassette
+	used     []bool
+}
+
+// New creates a client for the golden file at path. In Replay mode the file is
+// loaded; in Record mode it is replaced once the first exchange is recorded.
+//
+// Parameters:
+//   - path: the path of the golden file.
+//   - opts: the client options.
+//
+// Returns:
+//   - *Client: the client.
+//   - error: an error if the mode is invalid or the golden file cannot be loaded.
+func New(path string, opts Options) (*Client, error) {
+	client := &Client{path: path, opts: opts}
+
+	switch opts.Mode {
+	case Record:
+		if client.opts.Client == nil {
+			client.opts.Client = http.DefaultClient
+		}
+	case Replay:
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading golden file: %w", err)
+		}
+
+		if err := json.Unmarshal(data, &client.cassette); err != nil {
+			return nil, fmt.Errorf("decoding golden file %s: %w", path, err)
+		}
+
+		client.used = make([]bool, len(client.cassette.Interactions))
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrInvalidMode, opts.Mode)
+	}
+
+	return client, nil
+}
+
+// Do records or replays the exchange of req.
+//
+// In Record mode, req is sent upstream and the exchange is appended to the golden
+// file. In Replay mode, the first unused interaction with the same method,
+// redacted URL and redacted body is served, regardless of the order in which
+// concurrent requests arrive. Headers are recorded for review but not matched.
+//
+// Parameters:
+//   - req: the request to send.
+//
+// Returns:
+//   - *http.Response: the upstream or recorded response.
+//   - error: an error if the request fails or matches no recorded interaction.
+func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	body, err := readBody(&req.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading request body: %w", err)
+	}
+
+	if req.GetBody == nil && body != nil {
+		req.GetBody = func() (io.ReadCloser, error) { return io.NopCloser(bytes.NewReader(body)), nil }
+	}
+
+	recorded := Request{
+		Method: req.Method,
+		URL:    c.redactURL(req.URL),
+		Header: c.redactHeader(req.Header),
+		Body:   c.redactBody(body, req.Header.Get("Content-Type")),
+	}
+
+	if c.opts.Mode == Replay {
+		return c.replay(req, recorded)
+	}
+
+	return c.record(req, recorded)
+}
+
+// Interactions returns the interactions recorded or loaded so far.
+//
+// Returns:
+//   - []Interaction: the interactions, in recording order.
+func (c *Client) Interactions() []Interaction {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return slices.Clone(c.cassette.Interactions)
+}
+
+// Unused returns the loaded interactions that have not been replayed, so tests can
+// assert that every recorded request was made again.
+//
+// Returns:
+//   - []Interaction: the unused interactions, in recording order.
+func (c *Client) Unused() []Interaction {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var unused []Interaction
+
+	for i, interaction := range c.cassette.Interactions {
+		if i < len(c.used) && !c.used[i] {
+			unused = append(unused, interaction)
+		}
+	}
+
+	return unused
+}
+
+// record sends req upstream and appends the exchange to the golden file.
+func (c *Client) record(req *http.Request, recorded Request) (*http.Response, error) {
+	res, err := c.opts.Client.Do(req)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // Upstream errors are passed through unchanged.
+	}
+
+	body, err := readBody(&res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading response body: %w", err)
+	}
+
+	interaction := Interaction{
+		Request: recorded,
+		Response: Response{
+			StatusCode: res.StatusCode,
+			Header:     c.redactHeader(res.Header),
+			Body:       c.redactBody(body, res.Header.Get("Content-Type")),
+		},
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.cassette.Interactions = append(c.cassette.Interactions, interaction)
+
+	if err := c.save(); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// replay serves the response of the first unused interaction matching recorded.
+// Interactions with the same method and URL but another body are reported as a
+// body mismatch against the first of them.
+func (c *Client) replay(req *http.Request, recorded Request) (*http.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var candidate *Interaction
+
+	for i, interaction := range c.cassette.Interactions {
+		if c.used[i] || interaction.Request.Method != recorded.Method || interaction.Request.URL != recorded.URL {
+			continue
+		}
+
+		if !bytes.Equal(interaction.Request.Body, recorded.Body) {
+			if candidate == nil {
+				candidate = &c.cassette.Interactions[i]
+			}
+
+			continue
+		}
+
+		c.used[i] = true
+		response := interaction.Response
+
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", response.StatusCode, http.StatusText(response.StatusCode)),
+			StatusCode:    response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        response.Header.Clone(),
+			Body:          io.NopCloser(bytes.NewReader(response.Body)),
+			ContentLength: int64(len(response.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	if candidate != nil {
+		return nil, fmt.Errorf("%w: %s %s\nrecorded: %s\n     got: %s",
+			ErrBodyMismatch, recorded.Method, recorded.URL, candidate.Request.Body, recorded.Body)
+	}
+
+	return nil, fmt.Errorf("%w: %s %s", ErrNoInteraction, recorded.Method, recorded.URL)
+}
+
+// save writes the cassette to the golden file. The caller must hold c.mu.
+func (c *Client) save() error {
+	data, err := json.MarshalIndent(c.cassette, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding golden file: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.path), dirPerm); err != nil {
+		return fmt.Errorf("creating golden file directory: %w", err)
+	}
+
+	if err := os.WriteFile(c.path, append(data, '\n'), filePerm); err != nil {
+		return fmt.Errorf("writing golden file: %w", err)
+	}
+
+	return nil
+}
+
+// redactURL returns the URL with its secrets redacted.
+func (c *Client) redactURL(target *url.URL) string {
+	if len(c.opts.Secrets) == 0 {
+		return util.RedactURL(target.String())
+	}
+
+	redacted := *target
+
+	if redacted.User != nil {
+		redacted.User = url.User(util.RedactedPlaceholder)
+	}
+
+	if redacted.RawQuery != "" {
+		redacted.RawQuery = redactValues(redacted.Query()).Encode()
+	}
+
+	return c.redactSecrets(redacted.String())
+}
+
+// redactHeader returns a copy of header with the values of sensitive headers and
+// the secrets redacted.
+func (c *Client) redactHeader(header http.Header) http.Header {
+	if len(header) == 0 {
+		return nil
+	}
+
+	redacted := make(http.Header, len(header))
+
+	for name, values := range header {
+		redacted[name] = make([]string, len(values))
+
+		for i, value := range values {
+			if util.IsSensitiveKey(name) || strings.EqualFold(name, "Cookie") || strings.EqualFold(name, "Set-Cookie") {
+				value = util.RedactedPlaceholder
+			}
+
+			redacted[name][i] = c.redactSecrets(value)
+		}
+	}
+
+	return redacted
+}
+
+// redactBody returns body with the secrets redacted. The values of sensitive
+// fields of form-encoded bodies are redacted as well.
+func (c *Client) redactBody(body []byte, contentType string) Body {
+	if body == nil {
+		return nil
+	}
+
+	text := string(body)
+
+	if strings.HasPrefix(contentType, "application/x-www-form-urlencoded") {
+		if values, err := url.ParseQuery(text); err == nil {
+			text = redactValues(values).Encode()
+		}
+	}
+
+	return Body(c.redactSecrets(text))
+}
+
+// redactSecrets replaces the configured secrets in s, including their
+// URL-encoded forms.
+func (c *Client) redactSecrets(s string) string {
+	for _, secret := range c.opts.Secrets {
+		if secret == "" {
+			continue
+		}
+
+		s = strings.ReplaceAll(s, secret, util.RedactedPlaceholder)
+		s = strings.ReplaceAll(s, url.QueryEscape(secret), util.RedactedPlaceholder)
+		s = strings.ReplaceAll(s, url.PathEscape(secret), util.RedactedPlaceholder)
+	}
+
+	return s
+}
+
+// redactValues redacts the values of sensitive keys in place.
+func redactValues(values url.Values) url.Values {
+	for name := range values {
+		if util.IsSensitiveKey(name) {
+			values.Set(name, util.RedactedPlaceholder)
+		}
+	}
+
+	return values
+}
+
+// readBody reads and replaces body, so it can be read again.
+func readBody(body *io.ReadCloser) ([]byte, error) {
+	if *body == nil || *body == http.NoBody {
+		return nil, nil
+	}
+
+	data, err := io.ReadAll(*body)
+	_ = (*body).Close()
+
+	*body = io.NopCloser(bytes.NewReader(data))
+
+	if err != nil {
+		return nil, fmt.Errorf("reading body: %w", err)
+	}
+
+	return data, nil
+}
+
+// MarshalJSON encodes the body as a JSON string, base64-encoded with a "base64:"
+// prefix if it is not valid UTF-8 or would be mistaken for an encoded body.
+func (b Body) MarshalJSON() ([]byte, error) {
+	text := string(b)
+	if !utf8.Valid(b) || strings.HasPrefix(text, base64Prefix) {
+		text = base64Prefix + base64.StdEncoding.EncodeToString(b)
+	}
+
+	data, err := json.Marshal(text)
+	if err != nil {
+		return nil, fmt.Errorf("encoding body: %w", err)
+	}
+
+	return data, nil
+}
+
+// UnmarshalJSON decodes a body encoded by MarshalJSON.
+func (b *Body) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return fmt.Errorf("decoding body: %w", err)
+	}
+
+	if encoded, ok := strings.CutPrefix(text, base64Prefix); ok {
+		decoded, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return fmt.Errorf("decoding body: %w", err)
+		}
+
+		*b = decoded
+
+		return nil
+	}
+
+	*b = Body(text)
+
+	return nil
+}
+
+// String returns the body as text, quoted if it is not valid UTF-8.
+func (b Body) String() string {
+	if utf8.Valid(b) {
+		return string(b)
+	}
+
+	return strconv.Quote(string(b))
+}
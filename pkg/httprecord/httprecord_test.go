@@ -0,0 +1,119 @@
+package httprecord_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nicholas-fedor/shoutrrr/pkg/httprecord"
+	"github.com/nicholas-fedor/shoutrrr/pkg/router"
+	"github.com/nicholas-fedor/shoutrrr/pkg/types"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+
+	golden := filepath.Join(t.TempDir(), "testdata", "generic.json")
+	serviceURL := "generic+http://" + server.Listener.Addr().String() + "/hooks/s3cr3t?template=json"
+
+	recorder, err := httprecord.New(golden, httprecord.Options{Mode: httprecord.Record, Secrets: []string{"s3cr3t"}})
+	require.NoError(t, err)
+
+	send := func(client types.HTTPClient, message string) error {
+		sender, err := router.NewWithOptions(nil, types.SenderOptions{HTTPClient: client}, serviceURL)
+		require.NoError(t, err)
+
+		return sender.Send(message, nil)[0]
+	}
+
+	require.NoError(t, send(recorder, "deployed"))
+	server.Close()
+
+	data, err := os.ReadFile(golden)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "s3cr3t")
+	assert.Contains(t, string(data), "/hooks/REDACTED")
+
+	interactions := recorder.Interactions()
+	require.Len(t, interactions, 1)
+	assert.Equal(t, http.MethodPost, interactions[0].Request.Method)
+	assert.JSONEq(t, `{"message":"deployed"}`, string(interactions[0].Request.Body))
+	assert.Equal(t, http.StatusOK, interactions[0].Response.StatusCode)
+
+	replayer, err := httprecord.New(golden, httprecord.Options{Secrets: []string{"s3cr3t"}})
+	require.NoError(t, err)
+	require.NoError(t, send(replayer, "deployed"))
+	assert.Empty(t, replayer.Unused())
+
+	err = send(replayer, "deployed")
+	assert.ErrorContains(t, err, httprecord.ErrNoInteraction.Error())
+
+	replayer, err = httprecord.New(golden, httprecord.Options{Secrets: []string{"s3cr3t"}})
+	require.NoError(t, err)
+
+	err = send(replayer, "rolled back")
+	assert.ErrorContains(t, err, httprecord.ErrBodyMismatch.Error())
+	assert.Len(t, replayer.Unused(), 1)
+}
+
+func TestRecordRedactsWithoutSecrets(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	recorder, err := httprecord.New(filepath.Join(t.TempDir(), "push.json"), httprecord.Options{Mode: httprecord.Record})
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/1/messages.json?user=u1",
+		strings.NewReader("token=app-token&message=hi"))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Authorization", "Bearer abc")
+
+	res, err := recorder.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, res.Body.Close())
+
+	recorded := recorder.Interactions()[0].Request
+	assert.Equal(t, "http://"+server.Listener.Addr().String()+"/REDACTED/REDACTED?user=u1", recorded.URL)
+	assert.Equal(t, "message=hi&token=REDACTED", string(recorded.Body))
+	assert.Equal(t, "REDACTED", recorded.Header.Get("Authorization"))
+}
+
+func TestBodyEncodesBinaryAsBase64(t *testing.T) {
+	t.Parallel()
+
+	for _, body := range []httprecord.Body{{0xff, 0x00}, httprecord.Body("base64:text"), httprecord.Body(`{"a":1}`)} {
+		data, err := json.Marshal(body)
+		require.NoError(t, err)
+
+		var decoded httprecord.Body
+		require.NoError(t, json.Unmarshal(data, &decoded))
+		assert.Equal(t, body, decoded)
+	}
+}
+
+func TestNewRequiresGoldenFileToReplay(t *testing.T) {
+	t.Parallel()
+
+	_, err := httprecord.New(filepath.Join(t.TempDir(), "missing.json"), httprecord.Options{})
+	require.Error(t, err)
+
+	_, err = httprecord.New("unused.json", httprecord.Options{Mode: 7})
+	require.ErrorIs(t, err, httprecord.ErrInvalidMode)
+}
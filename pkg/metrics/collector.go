@@ -0,0 +1,152 @@
+package metrics
+
+import (
+	"maps"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/nicholas-fedor/shoutrrr/pkg/types"
+)
+
+// DefaultBuckets are the upper bounds, in seconds, of the latency histogram
+// buckets used when NewCollector is given none.
+var DefaultBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+
+// Collector aggregates delivery observations per service scheme in memory.
+//
+// It implements types.Metrics, so it can be set on a router, and exposes the
+// aggregates in the Prometheus text exposition format. Collector is safe for
+// concurrent use.
+type Collector struct {
+	buckets []float64
+	mu      sync.Mutex
+	schemes map[string]*SchemeStats
+}
+
+// SchemeStats holds the aggregated deliveries to the targets of one service scheme.
+type SchemeStats struct {
+	// Scheme is the service scheme, e.g. "slack".
+	Scheme string
+	// Sends is the number of deliveries, successful or not.
+	Sends uint64
+	// Failures is the number of failed deliveries by error class.
+	Failures map[types.ErrorClass]uint64
+	// Retries is the number of send attempts made after the first one.
+	Retries uint64
+	// Bytes is the total size of the delivered message texts.
+	Bytes uint64
+	// Latency is the distribution of the delivery durations, in seconds.
+	Latency Histogram
+}
+
+// Histogram is a distribution of observed values over fixed buckets.
+type Histogram struct {
+	// Bounds are the sorted upper bounds of the buckets.
+	Bounds []float64
+	// Counts are the numbers of values less than or equal to each bound. Values
+	// above the last bound are only included in Count.
+	Counts []uint64
+	// Count is the number of observed values.
+	Count uint64
+	// Sum is the sum of the observed values.
+	Sum float64
+}
+
+// NewCollector creates an empty collector.
+//
+// Parameters:
+//   - buckets: the upper bounds, in seconds, of the latency histogram buckets.
+//     DefaultBuckets are used if none are given.
+//
+// Returns:
+//   - *Collector: the collector.
+func NewCollector(buckets ...float64) *Collector {
+	if len(buckets) == 0 {
+		buckets = DefaultBuckets
+	}
+
+	bounds := slices.Clone(buckets)
+	sort.Float64s(bounds)
+
+	return &Collector{
+		buckets: slices.Compact(bounds),
+		schemes: make(map[string]*SchemeStats),
+	}
+}
+
+// ObserveDelivery adds a delivery to the aggregates of its scheme.
+//
+// Parameters:
+//   - observation: the delivery to record.
+func (c *Collector) ObserveDelivery(observation types.DeliveryObservation) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats, ok := c.schemes[observation.Scheme]
+	if !ok {
+		stats = &SchemeStats{
+			Scheme:   observation.Scheme,
+			Failures: make(map[types.ErrorClass]uint64),
+			Latency: Histogram{
+				Bounds: c.buckets,
+				Counts: make([]uint64, len(c.buckets)),
+			},
+		}
+		c.schemes[observation.Scheme] = stats
+	}
+
+	stats.Sends++
+	stats.Retries += uint64(observation.Retries())
+	stats.Bytes += uint64(max(observation.Bytes, 0))
+
+	if observation.Err != nil {
+		class := observation.Class
+		if class == "" {
+			class = types.ClassifyError(observation.Err)
+		}
+
+		stats.Failures[class]++
+	}
+
+	stats.Latency.observe(observation.Duration.Seconds())
+}
+
+// Snapshot returns a copy of the aggregates of every observed scheme.
+//
+// Returns:
+//   - []SchemeStats: the aggregates, sorted by scheme.
+func (c *Collector) Snapshot() []SchemeStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	snapshot := make([]SchemeStats, 0, len(c.schemes))
+	for _, scheme := range slices.Sorted(maps.Keys(c.schemes)) {
+		stats := *c.schemes[scheme]
+		stats.Failures = maps.Clone(stats.Failures)
+		stats.Latency.Counts = slices.Clone(stats.Latency.Counts)
+		snapshot = append(snapshot, stats)
+	}
+
+	return snapshot
+}
+
+// Reset discards all aggregates.
+func (c *Collector) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	clear(c.schemes)
+}
+
+// observe adds a value to the histogram.
+func (h *Histogram) observe(value float64) {
+	for i, bound := range h.Bounds {
+		if value <= bound {
+			h.Counts[i]++
+		}
+	}
+
+	h.Count++
+	h.Sum += value
+}
@@ -0,0 +1,102 @@
+package metrics_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nicholas-fedor/shoutrrr/pkg/metrics"
+	"github.com/nicholas-fedor/shoutrrr/pkg/types"
+)
+
+var errRejected = errors.New("rejected")
+
+func TestCollectorAggregatesPerScheme(t *testing.T) {
+	t.Parallel()
+
+	collector := metrics.NewCollector(1, 0.1)
+
+	collector.ObserveDelivery(types.DeliveryObservation{Scheme: "slack", Duration: 50 * time.Millisecond, Attempts: 1, Bytes: 5})
+	collector.ObserveDelivery(types.DeliveryObservation{
+		Scheme: "slack", Duration: 2 * time.Second, Attempts: 3, Bytes: 7,
+		Err: errRejected, Class: types.ErrorClassServer,
+	})
+	collector.ObserveDelivery(types.DeliveryObservation{Scheme: "discord", Err: context.Canceled})
+
+	snapshot := collector.Snapshot()
+	require.Len(t, snapshot, 2)
+
+	discord, slack := snapshot[0], snapshot[1]
+
+	assert.Equal(t, "discord", discord.Scheme)
+	assert.Equal(t, map[types.ErrorClass]uint64{types.ErrorClassCanceled: 1}, discord.Failures)
+
+	assert.Equal(t, uint64(2), slack.Sends)
+	assert.Equal(t, uint64(2), slack.Retries)
+	assert.Equal(t, uint64(12), slack.Bytes)
+	assert.Equal(t, map[types.ErrorClass]uint64{types.ErrorClassServer: 1}, slack.Failures)
+	assert.Equal(t, []float64{0.1, 1}, slack.Latency.Bounds)
+	assert.Equal(t, []uint64{1, 1}, slack.Latency.Counts)
+	assert.Equal(t, uint64(2), slack.Latency.Count)
+	assert.InDelta(t, 2.05, slack.Latency.Sum, 1e-9)
+
+	collector.Reset()
+	assert.Empty(t, collector.Snapshot())
+}
+
+func TestCollectorServesPrometheusText(t *testing.T) {
+	t.Parallel()
+
+	collector := metrics.NewCollector(0.5)
+	collector.ObserveDelivery(types.DeliveryObservation{
+		Scheme: `we"ird`, Duration: time.Second, Attempts: 2, Bytes: 3,
+		Err: errRejected, Class: types.ErrorClassRateLimited,
+	})
+
+	recorder := httptest.NewRecorder()
+	collector.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, metrics.ContentType, recorder.Header().Get("Content-Type"))
+
+	body := recorder.Body.String()
+	for _, line := range []string{
+		"# TYPE shoutrrr_sends_total counter",
+		`shoutrrr_sends_total{scheme="we\"ird"} 1`,
+		`shoutrrr_send_failures_total{scheme="we\"ird",class="rate_limited"} 1`,
+		`shoutrrr_send_retries_total{scheme="we\"ird"} 1`,
+		`shoutrrr_send_bytes_total{scheme="we\"ird"} 3`,
+		"# TYPE shoutrrr_send_duration_seconds histogram",
+		`shoutrrr_send_duration_seconds_bucket{scheme="we\"ird",le="0.5"} 0`,
+		`shoutrrr_send_duration_seconds_bucket{scheme="we\"ird",le="+Inf"} 1`,
+		`shoutrrr_send_duration_seconds_sum{scheme="we\"ird"} 1`,
+		`shoutrrr_send_duration_seconds_count{scheme="we\"ird"} 1`,
+	} {
+		assert.Contains(t, strings.Split(body, "\n"), line)
+	}
+
+	recorder = httptest.NewRecorder()
+	collector.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/metrics", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+}
+
+func TestMultiForwardsToEveryHook(t *testing.T) {
+	t.Parallel()
+
+	first, second := metrics.NewCollector(), metrics.NewCollector()
+	calls := 0
+
+	hook := metrics.Multi(first, nil, second, types.MetricsFunc(func(types.DeliveryObservation) { calls++ }))
+	hook.ObserveDelivery(types.DeliveryObservation{Scheme: "ntfy"})
+
+	assert.Len(t, first.Snapshot(), 1)
+	assert.Len(t, second.Snapshot(), 1)
+	assert.Equal(t, 1, calls)
+}
@@ -0,0 +1,32 @@
+// Package metrics aggregates the outcome of notification deliveries and exposes
+// it to monitoring systems.
+//
+// Routers report every delivery to a types.Metrics hook, set with
+// SenderOptions.Metrics or ServiceRouter.SetMetrics. Each observation carries the
+// service scheme, the duration, the number of attempts, the message size and,
+// for failures, the error and its types.ErrorClass.
+//
+// # Prometheus
+//
+// Collector aggregates observations per scheme and serves them in the Prometheus
+// text exposition format, without depending on the Prometheus client library:
+//
+//	collector := metrics.NewCollector()
+//	sender, err := shoutrrr.CreateSenderWithOptions(types.SenderOptions{Metrics: collector}, urls...)
+//	http.Handle("/metrics", collector)
+//
+// The exposed families, all labelled by scheme, are:
+//
+//   - shoutrrr_sends_total: deliveries, successful or not.
+//   - shoutrrr_send_failures_total: failed deliveries, also labelled by class.
+//   - shoutrrr_send_retries_total: attempts made after the first one.
+//   - shoutrrr_send_bytes_total: size of the delivered message texts.
+//   - shoutrrr_send_duration_seconds: histogram of the delivery durations.
+//
+// # Other Systems
+//
+// Other metrics systems are bridged by implementing types.Metrics, or by
+// wrapping a function with types.MetricsFunc. Multi forwards observations to
+// several hooks, e.g. to a Collector and an OpenTelemetry adapter. Collector.Snapshot
+// returns the aggregates for systems that poll instead.
+package metrics
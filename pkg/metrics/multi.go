@@ -0,0 +1,34 @@
+package metrics
+
+import "github.com/nicholas-fedor/shoutrrr/pkg/types"
+
+// multi forwards observations to several metrics hooks.
+type multi []types.Metrics
+
+// Multi returns a metrics hook forwarding every observation to each of the given
+// hooks in order, e.g. to a Collector and an adapter to another metrics system.
+// Nil hooks are ignored.
+//
+// Parameters:
+//   - hooks: the metrics hooks to forward to.
+//
+// Returns:
+//   - types.Metrics: the combined hook.
+func Multi(hooks ...types.Metrics) types.Metrics {
+	combined := make(multi, 0, len(hooks))
+
+	for _, hook := range hooks {
+		if hook != nil {
+			combined = append(combined, hook)
+		}
+	}
+
+	return combined
+}
+
+// ObserveDelivery forwards the observation to every hook.
+func (m multi) ObserveDelivery(observation types.DeliveryObservation) {
+	for _, hook := range m {
+		hook.ObserveDelivery(observation)
+	}
+}
@@ -0,0 +1,141 @@
+package metrics
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/nicholas-fedor/shoutrrr/pkg/types"
+)
+
+// ContentType is the media type of the Prometheus text exposition format.
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// Metric names of the Prometheus exposition.
+const (
+	SendsMetric    = "shoutrrr_sends_total"
+	FailuresMetric = "shoutrrr_send_failures_total"
+	RetriesMetric  = "shoutrrr_send_retries_total"
+	BytesMetric    = "shoutrrr_send_bytes_total"
+	LatencyMetric  = "shoutrrr_send_duration_seconds"
+)
+
+// labelEscaper escapes label values as required by the exposition format.
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// WriteTo writes the aggregates in the Prometheus text exposition format.
+//
+// Parameters:
+//   - w: the writer to write to.
+//
+// Returns:
+//   - int64: the number of bytes written.
+//   - error: an error if writing fails.
+func (c *Collector) WriteTo(w io.Writer) (int64, error) {
+	snapshot := c.Snapshot()
+
+	var buf bytes.Buffer
+
+	writeHeader(&buf, SendsMetric, "counter", "Notification deliveries, successful or not.")
+
+	for _, stats := range snapshot {
+		writeSample(&buf, SendsMetric, schemeLabels(stats.Scheme), float64(stats.Sends))
+	}
+
+	writeHeader(&buf, FailuresMetric, "counter", "Failed notification deliveries by error class.")
+
+	for _, stats := range snapshot {
+		classes := make([]types.ErrorClass, 0, len(stats.Failures))
+		for class := range stats.Failures {
+			classes = append(classes, class)
+		}
+
+		slices.Sort(classes)
+
+		for _, class := range classes {
+			labels := schemeLabels(stats.Scheme) + `,class="` + labelEscaper.Replace(string(class)) + `"`
+			writeSample(&buf, FailuresMetric, labels, float64(stats.Failures[class]))
+		}
+	}
+
+	writeHeader(&buf, RetriesMetric, "counter", "Send attempts made after the first attempt of a delivery.")
+
+	for _, stats := range snapshot {
+		writeSample(&buf, RetriesMetric, schemeLabels(stats.Scheme), float64(stats.Retries))
+	}
+
+	writeHeader(&buf, BytesMetric, "counter", "Size of the delivered message texts in bytes.")
+
+	for _, stats := range snapshot {
+		writeSample(&buf, BytesMetric, schemeLabels(stats.Scheme), float64(stats.Bytes))
+	}
+
+	writeHeader(&buf, LatencyMetric, "histogram", "Duration of notification deliveries, including retries.")
+
+	for _, stats := range snapshot {
+		labels := schemeLabels(stats.Scheme)
+
+		for i, bound := range stats.Latency.Bounds {
+			bucketLabels := labels + `,le="` + formatFloat(bound) + `"`
+			writeSample(&buf, LatencyMetric+"_bucket", bucketLabels, float64(stats.Latency.Counts[i]))
+		}
+
+		writeSample(&buf, LatencyMetric+"_bucket", labels+`,le="+Inf"`, float64(stats.Latency.Count))
+		writeSample(&buf, LatencyMetric+"_sum", labels, stats.Latency.Sum)
+		writeSample(&buf, LatencyMetric+"_count", labels, float64(stats.Latency.Count))
+	}
+
+	n, err := buf.WriteTo(w)
+	if err != nil {
+		return n, fmt.Errorf("writing metrics: %w", err)
+	}
+
+	return n, nil
+}
+
+// ServeHTTP serves the aggregates in the Prometheus text exposition format, so
+// that the collector can be mounted as a scrape endpoint, e.g. at /metrics.
+//
+// Parameters:
+//   - w: the response writer.
+//   - req: the scrape request.
+func (c *Collector) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", ContentType)
+
+	if req.Method == http.MethodHead {
+		return
+	}
+
+	_, _ = c.WriteTo(w)
+}
+
+// writeHeader writes the HELP and TYPE lines of a metric family.
+func writeHeader(buf *bytes.Buffer, name, metricType, help string) {
+	fmt.Fprintf(buf, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, metricType)
+}
+
+// writeSample writes a single sample line.
+func writeSample(buf *bytes.Buffer, name, labels string, value float64) {
+	fmt.Fprintf(buf, "%s{%s} %s\n", name, labels, formatFloat(value))
+}
+
+// schemeLabels returns the label pairs identifying a scheme.
+func schemeLabels(scheme string) string {
+	return `scheme="` + labelEscaper.Replace(scheme) + `"`
+}
+
+// formatFloat formats a sample value or bucket bound.
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
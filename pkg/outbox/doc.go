@@ -0,0 +1,31 @@
+// Package outbox provides a durable, on-disk outbox for notification deliveries.
+//
+// An Outbox records every pending delivery in an append-only journal inside a
+// configured directory. Each entry tracks a single message for a single target,
+// so a delivery that failed (or was interrupted by a crash) can be retried on the
+// next process start until it succeeds or expires.
+//
+// # Journal
+//
+// The journal is a file of JSON records, one per line, describing additions,
+// delivery attempts, completions and purges. It is replayed by Open, which also
+// drops expired entries and compacts the file so it only contains pending entries.
+// Targets are identified by a hash of their service URL (see TargetKey), so
+// credentials embedded in URLs are never written to disk. Message bodies and
+// params are stored as-is.
+//
+// # Usage
+//
+// The outbox is typically attached to a router, which journals each delivery
+// made by Flush and replays the pending entries when the outbox is attached:
+//
+//	box, err := outbox.Open("/var/lib/myapp/outbox", outbox.Options{MaxAge: 24 * time.Hour})
+//	if err != nil {
+//	    // handle error
+//	}
+//	defer box.Close()
+//
+//	errs := sender.SetOutbox(box) // retries deliveries left over from the last run
+//
+// Pending entries can be inspected with Pending and discarded with Purge or PurgeAll.
+package outbox
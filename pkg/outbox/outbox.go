@@ -0,0 +1,474 @@
+package outbox
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/nicholas-fedor/shoutrrr/pkg/types"
+)
+
+// DefaultMaxAge is the default time an entry is kept before it expires.
+const DefaultMaxAge = 24 * time.Hour
+
+const (
+	journalName = "outbox.journal"
+	dirPerm     = 0o700
+	filePerm    = 0o600
+	idBytes     = 16
+	targetBytes = 16
+)
+
+// Journal record operations.
+const (
+	opAdd     = "add"
+	opAttempt = "attempt"
+	opDone    = "done"
+	opPurge   = "purge"
+)
+
+var (
+	ErrClosed         = errors.New("outbox is closed")
+	ErrUnknownEntry   = errors.New("unknown outbox entry")
+	ErrCorruptJournal = errors.New("corrupt outbox journal")
+)
+
+// Options configures an Outbox.
+type Options struct {
+	// MaxAge is how long an entry is retried before it expires.
+	// Defaults to DefaultMaxAge when <= 0.
+	MaxAge time.Duration
+
+	// MaxAttempts, if > 0, expires an entry after that many failed attempts.
+	MaxAttempts int
+}
+
+// Entry is a single pending delivery of a message to a target.
+type Entry struct {
+	// ID uniquely identifies the entry.
+	ID string `json:"id"`
+	// Target is the TargetKey of the service URL the message is destined for.
+	Target string `json:"target"`
+	// ServiceID is the service identifier, e.g. "slack", for display purposes.
+	ServiceID string `json:"service"`
+	// Message is the message body to deliver.
+	Message string `json:"message"`
+	// Params are the parameters to deliver the message with.
+	Params types.Params `json:"params,omitempty"`
+	// Created is the time the entry was added.
+	Created time.Time `json:"created"`
+	// Attempts is the number of failed delivery attempts so far.
+	Attempts int `json:"attempts,omitempty"`
+	// LastAttempt is the time of the last failed delivery attempt.
+	LastAttempt time.Time `json:"lastAttempt,omitzero"`
+	// LastError is the error of the last failed delivery attempt.
+	LastError string `json:"lastError,omitempty"`
+}
+
+// record is a single line of the journal.
+type record struct {
+	Op    string    `json:"op"`
+	Entry *Entry    `json:"entry,omitempty"`
+	ID    string    `json:"id,omitempty"`
+	Time  time.Time `json:"time,omitzero"`
+	Error string    `json:"error,omitempty"`
+}
+
+// Outbox is a durable store of pending deliveries backed by an append-only journal.
+// It is safe for concurrent use.
+type Outbox struct {
+	mu      sync.Mutex
+	opts    Options
+	path    string
+	file    *os.File
+	entries map[string]*Entry
+	order   []string
+	now     func() time.Time
+}
+
+// Open opens the outbox stored in dir, creating the directory if needed.
+// The journal is replayed, expired entries are dropped and the journal is
+// compacted to only contain the remaining pending entries.
+//
+// Parameters:
+//   - dir: the directory holding the journal.
+//   - opts: the outbox options.
+//
+// Returns:
+//   - *Outbox: the opened outbox.
+//   - error: an error if the directory or journal cannot be read or written.
+func Open(dir string, opts Options) (*Outbox, error) {
+	if opts.MaxAge <= 0 {
+		opts.MaxAge = DefaultMaxAge
+	}
+
+	if err := os.MkdirAll(dir, dirPerm); err != nil {
+		return nil, fmt.Errorf("creating outbox directory: %w", err)
+	}
+
+	box := &Outbox{
+		opts:    opts,
+		path:    filepath.Join(dir, journalName),
+		entries: map[string]*Entry{},
+		now:     time.Now,
+	}
+
+	if err := box.load(); err != nil {
+		return nil, err
+	}
+
+	box.dropExpired()
+
+	if err := box.compact(); err != nil {
+		return nil, err
+	}
+
+	return box, nil
+}
+
+// TargetKey returns the key identifying the target with the given service URL.
+// The key is a hash of the URL, so it can be persisted without exposing credentials.
+//
+// Parameters:
+//   - rawURL: the service URL.
+//
+// Returns:
+//   - string: the target key.
+func TargetKey(rawURL string) string {
+	sum := sha256.Sum256([]byte(rawURL))
+
+	return hex.EncodeToString(sum[:targetBytes])
+}
+
+// Add records a new pending delivery.
+//
+// Parameters:
+//   - target: the TargetKey of the destination service URL.
+//   - serviceID: the service identifier.
+//   - message: the message to deliver.
+//   - params: the parameters to deliver the message with.
+//
+// Returns:
+//   - Entry: the recorded entry.
+//   - error: an error if the entry could not be journaled.
+func (o *Outbox) Add(target, serviceID, message string, params types.Params) (Entry, error) {
+	id, err := newID()
+	if err != nil {
+		return Entry{}, err
+	}
+
+	entry := &Entry{
+		ID:        id,
+		Target:    target,
+		ServiceID: serviceID,
+		Message:   message,
+		Params:    params,
+		Created:   o.now().UTC(),
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if err := o.append(record{Op: opAdd, Entry: entry}); err != nil {
+		return Entry{}, err
+	}
+
+	o.entries[id] = entry
+	o.order = append(o.order, id)
+
+	return *entry, nil
+}
+
+// RecordAttempt records the outcome of a delivery attempt for an entry.
+// A nil sendErr completes the entry and removes it from the outbox; otherwise the
+// failure is recorded and the entry stays pending until it succeeds or expires.
+//
+// Parameters:
+//   - id: the entry ID.
+//   - sendErr: the delivery error, or nil on success.
+//
+// Returns:
+//   - error: ErrUnknownEntry if the entry is not pending, or a journal write error.
+func (o *Outbox) RecordAttempt(id string, sendErr error) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	entry, found := o.entries[id]
+	if !found {
+		return fmt.Errorf("%w: %q", ErrUnknownEntry, id)
+	}
+
+	if sendErr == nil {
+		if err := o.append(record{Op: opDone, ID: id}); err != nil {
+			return err
+		}
+
+		o.remove(id)
+
+		return nil
+	}
+
+	rec := record{Op: opAttempt, ID: id, Time: o.now().UTC(), Error: sendErr.Error()}
+	if err := o.append(rec); err != nil {
+		return err
+	}
+
+	entry.apply(rec)
+
+	return nil
+}
+
+// Pending returns a copy of all entries that have neither been delivered nor expired,
+// in the order they were added.
+//
+// Returns:
+//   - []Entry: the pending entries.
+func (o *Outbox) Pending() []Entry {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	now := o.now()
+	pending := make([]Entry, 0, len(o.order))
+
+	for _, id := range o.order {
+		if entry := o.entries[id]; !o.expired(entry, now) {
+			pending = append(pending, *entry)
+		}
+	}
+
+	return pending
+}
+
+// Purge removes the given entries from the outbox without delivering them.
+// IDs that are not pending are ignored.
+//
+// Parameters:
+//   - ids: the IDs of the entries to remove.
+//
+// Returns:
+//   - error: an error if the removal could not be journaled.
+func (o *Outbox) Purge(ids ...string) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	for _, id := range ids {
+		if _, found := o.entries[id]; !found {
+			continue
+		}
+
+		if err := o.append(record{Op: opPurge, ID: id}); err != nil {
+			return err
+		}
+
+		o.remove(id)
+	}
+
+	return nil
+}
+
+// PurgeAll removes every entry from the outbox, including expired ones.
+//
+// Returns:
+//   - error: an error if the journal could not be rewritten.
+func (o *Outbox) PurgeAll() error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.file == nil {
+		return ErrClosed
+	}
+
+	o.entries = map[string]*Entry{}
+	o.order = nil
+
+	return o.compact()
+}
+
+// Close closes the journal. The outbox must not be used afterwards.
+//
+// Returns:
+//   - error: an error if the journal could not be closed.
+func (o *Outbox) Close() error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.file == nil {
+		return nil
+	}
+
+	err := o.file.Close()
+	o.file = nil
+
+	if err != nil {
+		return fmt.Errorf("closing outbox journal: %w", err)
+	}
+
+	return nil
+}
+
+// apply updates the entry with a failed attempt record.
+func (e *Entry) apply(rec record) {
+	e.Attempts++
+	e.LastAttempt = rec.Time
+	e.LastError = rec.Error
+}
+
+// load replays the journal into memory. A missing journal is treated as empty,
+// and a truncated final record (e.g. from a crash mid-write) is ignored.
+func (o *Outbox) load() error {
+	data, err := os.ReadFile(o.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("reading outbox journal: %w", err)
+	}
+
+	lines := bytes.Split(data, []byte("\n"))
+
+	for i, line := range lines {
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+
+		var rec record
+		if err := json.Unmarshal(line, &rec); err != nil {
+			if i == len(lines)-1 {
+				break
+			}
+
+			return fmt.Errorf("%w: line %d: %w", ErrCorruptJournal, i+1, err)
+		}
+
+		o.replay(rec)
+	}
+
+	return nil
+}
+
+// replay applies a single journal record to the in-memory state.
+func (o *Outbox) replay(rec record) {
+	switch rec.Op {
+	case opAdd:
+		if rec.Entry != nil && o.entries[rec.Entry.ID] == nil {
+			o.entries[rec.Entry.ID] = rec.Entry
+			o.order = append(o.order, rec.Entry.ID)
+		}
+	case opAttempt:
+		if entry, found := o.entries[rec.ID]; found {
+			entry.apply(rec)
+		}
+	case opDone, opPurge:
+		o.remove(rec.ID)
+	}
+}
+
+// dropExpired removes all expired entries from memory.
+func (o *Outbox) dropExpired() {
+	now := o.now()
+
+	for _, id := range slices.Clone(o.order) {
+		if o.expired(o.entries[id], now) {
+			o.remove(id)
+		}
+	}
+}
+
+// expired reports whether the entry has exceeded its maximum age or attempts.
+func (o *Outbox) expired(entry *Entry, now time.Time) bool {
+	if o.opts.MaxAttempts > 0 && entry.Attempts >= o.opts.MaxAttempts {
+		return true
+	}
+
+	return now.Sub(entry.Created) > o.opts.MaxAge
+}
+
+// remove deletes an entry from memory.
+func (o *Outbox) remove(id string) {
+	delete(o.entries, id)
+	o.order = slices.DeleteFunc(o.order, func(other string) bool { return other == id })
+}
+
+// compact atomically rewrites the journal with only the in-memory entries and
+// reopens it for appending.
+func (o *Outbox) compact() error {
+	tmp, err := os.CreateTemp(filepath.Dir(o.path), journalName+".*")
+	if err != nil {
+		return fmt.Errorf("creating outbox journal: %w", err)
+	}
+
+	defer os.Remove(tmp.Name())
+
+	writer := bufio.NewWriter(tmp)
+	encoder := json.NewEncoder(writer)
+
+	for _, id := range o.order {
+		if err := encoder.Encode(record{Op: opAdd, Entry: o.entries[id]}); err != nil {
+			tmp.Close()
+
+			return fmt.Errorf("writing outbox journal: %w", err)
+		}
+	}
+
+	if err := errors.Join(writer.Flush(), tmp.Sync(), tmp.Close()); err != nil {
+		return fmt.Errorf("writing outbox journal: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), o.path); err != nil {
+		return fmt.Errorf("replacing outbox journal: %w", err)
+	}
+
+	if o.file != nil {
+		o.file.Close()
+	}
+
+	o.file, err = os.OpenFile(o.path, os.O_WRONLY|os.O_APPEND, filePerm)
+	if err != nil {
+		return fmt.Errorf("opening outbox journal: %w", err)
+	}
+
+	return nil
+}
+
+// append durably writes a record to the journal.
+func (o *Outbox) append(rec record) error {
+	if o.file == nil {
+		return ErrClosed
+	}
+
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("encoding outbox record: %w", err)
+	}
+
+	if _, err := o.file.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("writing outbox journal: %w", err)
+	}
+
+	if err := o.file.Sync(); err != nil {
+		return fmt.Errorf("syncing outbox journal: %w", err)
+	}
+
+	return nil
+}
+
+// newID returns a random entry ID.
+func newID() (string, error) {
+	buf := make([]byte, idBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("generating outbox entry ID: %w", err)
+	}
+
+	return hex.EncodeToString(buf), nil
+}
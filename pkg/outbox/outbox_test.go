@@ -0,0 +1,183 @@
+package outbox
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nicholas-fedor/shoutrrr/pkg/types"
+)
+
+var errDeliveryFailed = errors.New("delivery failed")
+
+func TestOutboxPersistsPendingEntries(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	box, err := Open(dir, Options{})
+	require.NoError(t, err)
+
+	delivered, err := box.Add(TargetKey("a://"), "a", "delivered", nil)
+	require.NoError(t, err)
+	failed, err := box.Add(TargetKey("b://"), "b", "failed", types.Params{"title": "t"})
+	require.NoError(t, err)
+
+	require.NoError(t, box.RecordAttempt(delivered.ID, nil))
+	require.NoError(t, box.RecordAttempt(failed.ID, errDeliveryFailed))
+	require.NoError(t, box.Close())
+
+	reopened, err := Open(dir, Options{})
+	require.NoError(t, err)
+
+	defer reopened.Close()
+
+	pending := reopened.Pending()
+	require.Len(t, pending, 1)
+	assert.Equal(t, failed.ID, pending[0].ID)
+	assert.Equal(t, "failed", pending[0].Message)
+	assert.Equal(t, types.Params{"title": "t"}, pending[0].Params)
+	assert.Equal(t, 1, pending[0].Attempts)
+	assert.Equal(t, errDeliveryFailed.Error(), pending[0].LastError)
+}
+
+func TestOutboxCompactsJournalOnOpen(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	box, err := Open(dir, Options{})
+	require.NoError(t, err)
+
+	for range 3 {
+		entry, err := box.Add(TargetKey("a://"), "a", "message", nil)
+		require.NoError(t, err)
+		require.NoError(t, box.RecordAttempt(entry.ID, nil))
+	}
+
+	require.NoError(t, box.Close())
+
+	reopened, err := Open(dir, Options{})
+	require.NoError(t, err)
+	require.NoError(t, reopened.Close())
+
+	data, err := os.ReadFile(filepath.Join(dir, journalName))
+	require.NoError(t, err)
+	assert.Empty(t, data)
+}
+
+func TestOutboxExpiresEntries(t *testing.T) {
+	t.Parallel()
+
+	box, err := Open(t.TempDir(), Options{MaxAge: time.Hour, MaxAttempts: 2})
+	require.NoError(t, err)
+
+	defer box.Close()
+
+	start := time.Now()
+	box.now = func() time.Time { return start }
+
+	old, err := box.Add(TargetKey("a://"), "a", "old", nil)
+	require.NoError(t, err)
+	failing, err := box.Add(TargetKey("a://"), "a", "failing", nil)
+	require.NoError(t, err)
+
+	require.NoError(t, box.RecordAttempt(failing.ID, errDeliveryFailed))
+	require.NoError(t, box.RecordAttempt(failing.ID, errDeliveryFailed))
+
+	pending := box.Pending()
+	require.Len(t, pending, 1)
+	assert.Equal(t, old.ID, pending[0].ID)
+
+	box.now = func() time.Time { return start.Add(2 * time.Hour) }
+	assert.Empty(t, box.Pending())
+}
+
+func TestOutboxPurge(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	box, err := Open(dir, Options{})
+	require.NoError(t, err)
+
+	first, err := box.Add(TargetKey("a://"), "a", "first", nil)
+	require.NoError(t, err)
+	_, err = box.Add(TargetKey("a://"), "a", "second", nil)
+	require.NoError(t, err)
+
+	require.NoError(t, box.Purge(first.ID, "unknown"))
+	assert.Len(t, box.Pending(), 1)
+
+	require.NoError(t, box.PurgeAll())
+	assert.Empty(t, box.Pending())
+	require.NoError(t, box.Close())
+
+	reopened, err := Open(dir, Options{})
+	require.NoError(t, err)
+
+	defer reopened.Close()
+
+	assert.Empty(t, reopened.Pending())
+}
+
+func TestOutboxIgnoresTruncatedFinalRecord(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	box, err := Open(dir, Options{})
+	require.NoError(t, err)
+	_, err = box.Add(TargetKey("a://"), "a", "message", nil)
+	require.NoError(t, err)
+	require.NoError(t, box.Close())
+
+	file, err := os.OpenFile(filepath.Join(dir, journalName), os.O_WRONLY|os.O_APPEND, filePerm)
+	require.NoError(t, err)
+	_, err = file.WriteString(`{"op":"add","entry":{"id":`)
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	reopened, err := Open(dir, Options{})
+	require.NoError(t, err)
+
+	defer reopened.Close()
+
+	assert.Len(t, reopened.Pending(), 1)
+}
+
+func TestOutboxRejectsCorruptJournal(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, journalName), []byte("garbage\n{}\n"), filePerm))
+
+	_, err := Open(dir, Options{})
+	require.ErrorIs(t, err, ErrCorruptJournal)
+}
+
+func TestOutboxRecordAttemptUnknownEntry(t *testing.T) {
+	t.Parallel()
+
+	box, err := Open(t.TempDir(), Options{})
+	require.NoError(t, err)
+
+	defer box.Close()
+
+	require.ErrorIs(t, box.RecordAttempt("missing", nil), ErrUnknownEntry)
+}
+
+func TestTargetKeyDoesNotExposeURL(t *testing.T) {
+	t.Parallel()
+
+	key := TargetKey("slack://secret-token@channel")
+
+	assert.NotContains(t, key, "secret")
+	assert.Equal(t, key, TargetKey("slack://secret-token@channel"))
+	assert.NotEqual(t, key, TargetKey("slack://other-token@channel"))
+}
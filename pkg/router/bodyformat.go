@@ -0,0 +1,46 @@
+package router
+
+import (
+	"fmt"
+
+	"github.com/nicholas-fedor/shoutrrr/pkg/format"
+	"github.com/nicholas-fedor/shoutrrr/pkg/types"
+)
+
+// applyBodyFormat handles the format param of a delivery. Services implementing
+// types.MarkupRenderer render the body themselves. For other services, the body
+// is converted to plain text and the param is removed.
+//
+// Parameters:
+//   - delivery: the delivery to update.
+//
+// Returns:
+//   - error: an error wrapping format.ErrUnknownMarkup if the format is unknown.
+func applyBodyFormat(delivery *types.Delivery) error {
+	from, found := delivery.Params[types.FormatKey]
+	if !found {
+		return nil
+	}
+
+	if _, err := format.ParseMarkup(from); err != nil {
+		return fmt.Errorf("rendering message body: %w", err)
+	}
+
+	if _, ok := delivery.Service.(types.MarkupRenderer); ok {
+		return nil
+	}
+
+	delete(delivery.Params, types.FormatKey)
+
+	if delivery.Items == nil {
+		delivery.Message, _ = format.RenderMarkup(delivery.Message, from, format.MarkupText)
+
+		return nil
+	}
+
+	for i := range delivery.Items {
+		delivery.Items[i].Text, _ = format.RenderMarkup(delivery.Items[i].Text, from, format.MarkupText)
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package router
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/nicholas-fedor/shoutrrr/pkg/format"
+	"github.com/nicholas-fedor/shoutrrr/pkg/types"
+)
+
+// markupService is a recording service rendering the body format itself.
+type markupService struct {
+	priorityService
+}
+
+func (s *markupService) Markup() string {
+	return format.MarkupHTML
+}
+
+//nolint:paralleltest // Modifies shared serviceMap; cannot run in parallel.
+func TestBodyFormatFallsBackToText(t *testing.T) {
+	svc := &priorityService{}
+
+	serviceMap["mock-priority"] = func() types.Service { return svc }
+	defer delete(serviceMap, "mock-priority")
+
+	router, err := NewWithOptions(nil, types.SenderOptions{}, "mock-priority://host?__levelmap=none")
+	if err != nil {
+		t.Fatalf("NewWithOptions: %v", err)
+	}
+
+	router.Send("**Disk** at [90%](https://example.com)", &types.Params{"format": "markdown"})
+	router.SendItems([]types.MessageItem{{Text: "<b>Disk</b> full"}}, types.Params{"format": "html"})
+
+	want := []string{"Disk at 90% (https://example.com)"}
+	if got := svc.sent(); !slices.Equal(got, want) {
+		t.Errorf("messages = %q, want %q", got, want)
+	}
+
+	if got := svc.items; len(got) != 1 || got[0].Text != "Disk full" {
+		t.Errorf("items = %v, want a single %q item", got, "Disk full")
+	}
+
+	for _, params := range svc.params {
+		if _, found := params[types.FormatKey]; found {
+			t.Errorf("params = %v, want no %s param", params, types.FormatKey)
+		}
+	}
+}
+
+//nolint:paralleltest // Modifies shared serviceMap; cannot run in parallel.
+func TestBodyFormatLeftToRenderers(t *testing.T) {
+	svc := &markupService{}
+
+	serviceMap["mock-markup"] = func() types.Service { return svc }
+	defer delete(serviceMap, "mock-markup")
+
+	router, err := NewWithOptions(nil, types.SenderOptions{}, "mock-markup://host?__levelmap=none")
+	if err != nil {
+		t.Fatalf("NewWithOptions: %v", err)
+	}
+
+	router.Send("**Disk**", &types.Params{"format": "Markdown"})
+
+	if got, want := svc.sent(), []string{"**Disk**"}; !slices.Equal(got, want) {
+		t.Errorf("messages = %q, want %q", got, want)
+	}
+
+	if len(svc.params) != 1 || svc.params[0][types.FormatKey] != "Markdown" {
+		t.Errorf("params = %v, want the %s param", svc.params, types.FormatKey)
+	}
+}
+
+//nolint:paralleltest // Modifies shared serviceMap; cannot run in parallel.
+func TestBodyFormatRejectsUnknownFormats(t *testing.T) {
+	svc := &markupService{}
+
+	serviceMap["mock-markup"] = func() types.Service { return svc }
+	defer delete(serviceMap, "mock-markup")
+
+	router, err := NewWithOptions(nil, types.SenderOptions{}, "mock-markup://host")
+	if err != nil {
+		t.Fatalf("NewWithOptions: %v", err)
+	}
+
+	errs := router.Send("**Disk**", &types.Params{"format": "rtf"})
+	if len(errs) != 1 || !errors.Is(errs[0], format.ErrUnknownMarkup) {
+		t.Errorf("Send errors = %v, want %v", errs, format.ErrUnknownMarkup)
+	}
+
+	if got := svc.sent(); len(got) != 0 {
+		t.Errorf("messages = %q, want none", got)
+	}
+}
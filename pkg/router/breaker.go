@@ -0,0 +1,248 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/nicholas-fedor/shoutrrr/pkg/types"
+)
+
+// DefaultCircuitCooldown is the cooldown of open circuits when the policy sets none.
+const DefaultCircuitCooldown = time.Minute
+
+// ErrCircuitOpen is the delivery outcome of a target whose circuit is open.
+var ErrCircuitOpen = errors.New("circuit open: target is failing")
+
+// CircuitState is the state of the circuit breaker of a target.
+type CircuitState int
+
+const (
+	// CircuitClosed lets deliveries through.
+	CircuitClosed CircuitState = iota
+	// CircuitOpen fails deliveries fast until the cooldown has elapsed.
+	CircuitOpen
+	// CircuitHalfOpen lets a single probe delivery through, failing the others fast.
+	CircuitHalfOpen
+)
+
+// TargetHealth describes the circuit breaker state of a target.
+type TargetHealth struct {
+	// ServiceID is the service identifier, e.g. "slack".
+	ServiceID string
+	// URL is the service URL with credentials redacted.
+	URL string
+	// Group is the name of the routing group of the target, or "" for router services.
+	Group string
+	// State is the state of the target's circuit.
+	State CircuitState
+	// Failures is the number of consecutive failed deliveries.
+	Failures int
+	// LastError is the error of the last failed delivery, if any.
+	LastError error
+	// OpenedAt is when the circuit last opened, or the zero time.
+	OpenedAt time.Time
+}
+
+// circuitBreaker tracks the circuits of the targets of a router.
+type circuitBreaker struct {
+	policy   types.CircuitBreakerPolicy
+	mu       sync.Mutex
+	circuits map[types.Service]*circuit
+}
+
+// circuit is the breaker state of a single target.
+type circuit struct {
+	state    CircuitState
+	failures int
+	lastErr  error
+	openedAt time.Time
+}
+
+// String returns the textual name of the state.
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return fmt.Sprintf("CircuitState(%d)", int(s))
+	}
+}
+
+// SetCircuitBreaker sets the circuit breaker policy of the router. A zero Threshold
+// disables the circuit breaker. The state of all circuits is reset.
+//
+// Parameters:
+//   - policy: the policy to use.
+func (r *ServiceRouter) SetCircuitBreaker(policy types.CircuitBreakerPolicy) {
+	r.breaker = nil
+
+	if policy.Threshold > 0 {
+		if policy.Cooldown <= 0 {
+			policy.Cooldown = DefaultCircuitCooldown
+		}
+
+		r.breaker = &circuitBreaker{policy: policy, circuits: make(map[types.Service]*circuit)}
+	}
+}
+
+// Health returns the circuit breaker state of every target: the router services
+// in order, followed by the members of each routing group, sorted by group name.
+//
+// Returns:
+//   - []TargetHealth: the state of each target.
+func (r *ServiceRouter) Health() []TargetHealth {
+	health := make([]TargetHealth, 0, len(r.services))
+
+	for i, service := range r.services {
+		health = append(health, r.breaker.health(service, r.redactedURL(i), ""))
+	}
+
+	names := make([]string, 0, len(r.groups))
+	for name := range r.groups {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		group := r.groups[name]
+		for i, service := range group.services {
+			health = append(health, r.breaker.health(service, group.urls[i], name))
+		}
+	}
+
+	return health
+}
+
+// BrokenTargets returns the targets whose circuit is open or half-open.
+//
+// Returns:
+//   - []TargetHealth: the state of each broken target.
+func (r *ServiceRouter) BrokenTargets() []TargetHealth {
+	var broken []TargetHealth
+
+	for _, health := range r.Health() {
+		if health.State != CircuitClosed {
+			broken = append(broken, health)
+		}
+	}
+
+	return broken
+}
+
+// allow reports whether a delivery to service may proceed. An open circuit whose
+// cooldown has elapsed turns half-open and lets the delivery through as a probe.
+//
+// Parameters:
+//   - service: the target service.
+//
+// Returns:
+//   - error: ErrCircuitOpen if the delivery must fail fast, or nil.
+func (b *circuitBreaker) allow(service types.Service) error {
+	if b == nil {
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c := b.circuits[service]
+	if c == nil {
+		return nil
+	}
+
+	switch c.state {
+	case CircuitOpen:
+		if time.Since(c.openedAt) < b.policy.Cooldown {
+			return ErrCircuitOpen
+		}
+
+		c.state = CircuitHalfOpen
+
+		return nil
+	case CircuitHalfOpen:
+		return ErrCircuitOpen
+	default:
+		return nil
+	}
+}
+
+// record updates the circuit of service with the outcome of a delivery that allow
+// let through. Deliveries cancelled by the caller do not count.
+//
+// Parameters:
+//   - service: the target service.
+//   - err: the delivery error, or nil on success.
+//
+// Returns:
+//   - bool: true if the delivery opened the circuit.
+func (b *circuitBreaker) record(service types.Service, err error) bool {
+	if b == nil {
+		return false
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c := b.circuits[service]
+
+	if err == nil {
+		delete(b.circuits, service)
+
+		return false
+	}
+
+	if errors.Is(err, context.Canceled) {
+		if c != nil && c.state == CircuitHalfOpen {
+			c.state = CircuitOpen
+		}
+
+		return false
+	}
+
+	if c == nil {
+		c = &circuit{}
+		b.circuits[service] = c
+	}
+
+	c.failures++
+	c.lastErr = err
+
+	if c.state == CircuitHalfOpen || (c.state == CircuitClosed && c.failures >= b.policy.Threshold) {
+		c.state = CircuitOpen
+		c.openedAt = time.Now()
+
+		return true
+	}
+
+	return false
+}
+
+// health returns the state of the circuit of service.
+func (b *circuitBreaker) health(service types.Service, url, group string) TargetHealth {
+	health := TargetHealth{ServiceID: service.GetID(), URL: url, Group: group}
+
+	if b == nil {
+		return health
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if c := b.circuits[service]; c != nil {
+		health.State = c.state
+		health.Failures = c.failures
+		health.LastError = c.lastErr
+		health.OpenedAt = c.openedAt
+	}
+
+	return health
+}
@@ -0,0 +1,87 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/nicholas-fedor/shoutrrr/pkg/types"
+)
+
+var errWebhookDeleted = errors.New("unknown webhook")
+
+//nolint:paralleltest // Modifies shared serviceMap; cannot run in parallel.
+func TestCircuitBreakerOpensAndProbes(t *testing.T) {
+	svc := &flakyService{errs: []error{errWebhookDeleted, errWebhookDeleted, errWebhookDeleted, errWebhookDeleted}}
+
+	serviceMap["mock-flaky"] = func() types.Service { return svc }
+	defer delete(serviceMap, "mock-flaky")
+
+	synctest.Test(t, func(t *testing.T) {
+		router, err := NewWithOptions(nil, types.SenderOptions{
+			CircuitBreaker: types.CircuitBreakerPolicy{Threshold: 3, Cooldown: time.Minute},
+		}, "mock-flaky://")
+		if err != nil {
+			t.Fatalf("NewWithOptions: %v", err)
+		}
+
+		for range 3 {
+			if errs := router.Send("hello", nil); !errors.Is(errs[0], errWebhookDeleted) {
+				t.Fatalf("Send = %v, want %v", errs[0], errWebhookDeleted)
+			}
+		}
+
+		if errs := router.Send("hello", nil); !errors.Is(errs[0], ErrCircuitOpen) {
+			t.Errorf("Send with open circuit = %v, want %v", errs[0], ErrCircuitOpen)
+		}
+
+		if len(svc.attempts) != 3 {
+			t.Errorf("service was called %d times, want 3", len(svc.attempts))
+		}
+
+		broken := router.BrokenTargets()
+		if len(broken) != 1 || broken[0].State != CircuitOpen || broken[0].Failures != 3 ||
+			!errors.Is(broken[0].LastError, errWebhookDeleted) {
+			t.Errorf("BrokenTargets = %+v, want the open target", broken)
+		}
+
+		time.Sleep(time.Minute)
+
+		if errs := router.Send("hello", nil); !errors.Is(errs[0], errWebhookDeleted) {
+			t.Errorf("failed probe = %v, want %v", errs[0], errWebhookDeleted)
+		}
+
+		if errs := router.Send("hello", nil); !errors.Is(errs[0], ErrCircuitOpen) {
+			t.Errorf("Send after failed probe = %v, want %v", errs[0], ErrCircuitOpen)
+		}
+
+		time.Sleep(time.Minute)
+
+		if errs := router.Send("hello", nil); errs[0] != nil {
+			t.Errorf("successful probe = %v, want delivery", errs[0])
+		}
+
+		if broken := router.BrokenTargets(); len(broken) != 0 {
+			t.Errorf("BrokenTargets = %+v, want none", broken)
+		}
+	})
+}
+
+func TestCircuitBreakerIgnoresCancellation(t *testing.T) {
+	t.Parallel()
+
+	router := &ServiceRouter{}
+	router.SetCircuitBreaker(types.CircuitBreakerPolicy{Threshold: 1})
+
+	svc := &flakyService{}
+
+	if router.breaker.record(svc, context.Canceled) {
+		t.Error("cancelled delivery opened the circuit")
+	}
+
+	if health := router.breaker.health(svc, "", ""); health.State != CircuitClosed || health.Failures != 0 {
+		t.Errorf("health = %+v, want a closed circuit", health)
+	}
+}
@@ -0,0 +1,185 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/nicholas-fedor/shoutrrr/pkg/types"
+	"github.com/nicholas-fedor/shoutrrr/pkg/util"
+)
+
+// ErrRouterClosed is the delivery outcome of a message sent after Close.
+var ErrRouterClosed = errors.New("router is closed")
+
+// lifecycle tracks the deliveries in flight and whether the router is closed.
+type lifecycle struct {
+	mu       sync.Mutex
+	closed   bool
+	inflight sync.WaitGroup
+}
+
+// Close shuts the router down. It sends the messages queued with Enqueue,
+// delivers the pending summaries of suppressed repeats, rejects new deliveries
+// with ErrRouterClosed, waits for in-flight deliveries and closes the services
+// that hold persistent connections or sessions, i.e. those implementing
+// types.ContextCloser or io.Closer. Digests are not tracked by the router and
+// must be closed before it. Calling Close again has no effect.
+//
+// Parameters:
+//   - ctx: the context bounding the shutdown. When it is done, Close stops
+//     waiting for in-flight deliveries and still closes the services.
+//
+// Returns:
+//   - error: the joined errors of the final flush, the wait and the services, or nil.
+func (r *ServiceRouter) Close(ctx context.Context) error {
+	if r.lifecycle.isClosed() {
+		return nil
+	}
+
+	errs := r.flushOnClose(ctx)
+
+	if r.dedup != nil {
+		r.dedup.drain()
+	}
+
+	if !r.lifecycle.close() {
+		return nil
+	}
+
+	if err := r.lifecycle.wait(ctx); err != nil {
+		errs = append(errs, err)
+	}
+
+	for _, service := range r.allServices() {
+		if err := closeService(ctx, service); err != nil {
+			errs = append(errs, &types.TargetError{
+				URL: redactedURL(service, service.GetID()),
+				Err: util.RedactError(err),
+			})
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// flushOnClose sends the queued messages as Flush does, returning the delivery
+// errors. Deliveries through an outbox are kept there on failure and not reported.
+func (r *ServiceRouter) flushOnClose(ctx context.Context) []error {
+	if len(r.queue) == 0 {
+		return nil
+	}
+
+	if r.outbox != nil {
+		r.Flush(nil)
+
+		return nil
+	}
+
+	message := strings.Join(r.queue, "\n")
+	r.queue = []string{}
+
+	var errs []error
+
+	for _, err := range r.SendContext(ctx, message, nil) {
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errs
+}
+
+// allServices returns the targets and the members of all routing groups. Targets
+// shared by AddTargetGroup are returned once.
+func (r *ServiceRouter) allServices() []types.Service {
+	services := append([]types.Service{}, r.services...)
+
+	for _, group := range r.groups {
+		for _, service := range group.services {
+			if !slices.Contains(services, service) {
+				services = append(services, service)
+			}
+		}
+	}
+
+	return services
+}
+
+// closeService releases the resources of the service, preferring CloseContext.
+func closeService(ctx context.Context, service types.Service) error {
+	switch closer := service.(type) {
+	case types.ContextCloser:
+		if err := closer.CloseContext(ctx); err != nil {
+			return fmt.Errorf("closing service: %w", err)
+		}
+	case io.Closer:
+		if err := closer.Close(); err != nil {
+			return fmt.Errorf("closing service: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// begin registers a delivery. It reports false if the router is closed.
+func (l *lifecycle) begin() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.closed {
+		return false
+	}
+
+	l.inflight.Add(1)
+
+	return true
+}
+
+// end unregisters a delivery registered with begin.
+func (l *lifecycle) end() {
+	l.inflight.Done()
+}
+
+// isClosed reports whether close was called.
+func (l *lifecycle) isClosed() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.closed
+}
+
+// close rejects new deliveries. It reports false if it was already called.
+func (l *lifecycle) close() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.closed {
+		return false
+	}
+
+	l.closed = true
+
+	return true
+}
+
+// wait blocks until the registered deliveries end or ctx is done.
+func (l *lifecycle) wait(ctx context.Context) error {
+	done := make(chan struct{})
+
+	go func() {
+		l.inflight.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("waiting for in-flight deliveries: %w", ctx.Err())
+	}
+}
@@ -0,0 +1,193 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/nicholas-fedor/shoutrrr/pkg/types"
+)
+
+// closingService is a recording service that blocks sends until its gate is
+// opened and records how it was closed.
+type closingService struct {
+	recordingService
+
+	gate   chan struct{}
+	closed int
+	ctx    context.Context //nolint:containedctx // Records the context passed to CloseContext.
+}
+
+func (s *closingService) GetID() string {
+	return "mock-closing"
+}
+
+func (s *closingService) Send(message string, params *types.Params) error {
+	if s.gate != nil {
+		<-s.gate
+	}
+
+	return s.recordingService.Send(message, params)
+}
+
+func (s *closingService) CloseContext(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.closed++
+	s.ctx = ctx
+
+	return nil
+}
+
+//nolint:paralleltest // Modifies shared serviceMap; cannot run in parallel.
+func TestCloseFlushesQueueAndClosesServices(t *testing.T) {
+	svc := &closingService{}
+
+	serviceMap["mock-closing"] = func() types.Service { return svc }
+	defer delete(serviceMap, "mock-closing")
+
+	router, err := NewWithOptions(nil, types.SenderOptions{}, "mock-closing://")
+	if err != nil {
+		t.Fatalf("NewWithOptions: %v", err)
+	}
+
+	router.Enqueue("backup started")
+	router.Enqueue("backup finished")
+
+	ctx := context.WithValue(context.Background(), t, "close")
+	if err := router.Close(ctx); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	if got, want := svc.sent(), []string{"backup started\nbackup finished"}; !slices.Equal(got, want) {
+		t.Errorf("sent = %q, want %q", got, want)
+	}
+
+	if svc.closed != 1 || svc.ctx != ctx {
+		t.Errorf("service closed %d times with %v, want once with the Close context", svc.closed, svc.ctx)
+	}
+
+	errs := router.Send("too late", nil)
+
+	var targetErr *types.TargetError
+	if !errors.Is(errs[0], ErrRouterClosed) || !errors.As(errs[0], &targetErr) ||
+		targetErr.Class != types.ErrorClassCanceled {
+		t.Errorf("Send after Close = %v, want a canceled TargetError wrapping ErrRouterClosed", errs[0])
+	}
+
+	if err := router.Close(ctx); err != nil || svc.closed != 1 {
+		t.Errorf("second Close = %v with %d service closes, want nil and no further close", err, svc.closed)
+	}
+}
+
+//nolint:paralleltest // Modifies shared serviceMap; cannot run in parallel.
+func TestCloseWaitsForInFlightSends(t *testing.T) {
+	svc := &closingService{}
+
+	serviceMap["mock-closing"] = func() types.Service { return svc }
+	defer delete(serviceMap, "mock-closing")
+
+	synctest.Test(t, func(t *testing.T) {
+		svc.gate = make(chan struct{})
+
+		router, err := NewWithOptions(nil, types.SenderOptions{}, "mock-closing://")
+		if err != nil {
+			t.Fatalf("NewWithOptions: %v", err)
+		}
+
+		results := router.SendAsync("deploying", nil)
+		synctest.Wait()
+
+		closed := make(chan error, 1)
+
+		go func() { closed <- router.Close(context.Background()) }()
+
+		synctest.Wait()
+
+		select {
+		case err := <-closed:
+			t.Fatalf("Close returned %v before the in-flight send finished", err)
+		default:
+		}
+
+		close(svc.gate)
+
+		if err := <-closed; err != nil {
+			t.Fatalf("Close: %v", err)
+		}
+
+		if err := <-results; err != nil {
+			t.Errorf("in-flight send = %v, want nil", err)
+		}
+
+		if svc.closed != 1 {
+			t.Errorf("service closed %d times, want 1", svc.closed)
+		}
+	})
+}
+
+//nolint:paralleltest // Modifies shared serviceMap; cannot run in parallel.
+func TestCloseStopsWaitingWhenContextDone(t *testing.T) {
+	svc := &closingService{}
+
+	serviceMap["mock-closing"] = func() types.Service { return svc }
+	defer delete(serviceMap, "mock-closing")
+
+	synctest.Test(t, func(t *testing.T) {
+		svc.gate = make(chan struct{})
+
+		router, err := NewWithOptions(nil, types.SenderOptions{Timeout: time.Hour}, "mock-closing://")
+		if err != nil {
+			t.Fatalf("NewWithOptions: %v", err)
+		}
+
+		results := router.SendAsync("deploying", nil)
+		synctest.Wait()
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		if err := router.Close(ctx); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Close = %v, want it to give up waiting at the deadline", err)
+		}
+
+		if svc.closed != 1 {
+			t.Errorf("service closed %d times, want 1 despite the pending send", svc.closed)
+		}
+
+		close(svc.gate)
+		<-results
+	})
+}
+
+//nolint:paralleltest // Modifies shared serviceMap; cannot run in parallel.
+func TestCloseSendsPendingRepeatSummaries(t *testing.T) {
+	svc := &recordingService{}
+
+	serviceMap["mock-recording"] = func() types.Service { return svc }
+	defer delete(serviceMap, "mock-recording")
+
+	synctest.Test(t, func(t *testing.T) {
+		router, err := NewWithOptions(nil, types.SenderOptions{
+			Dedup: types.DedupPolicy{Window: time.Hour},
+		}, "mock-recording://")
+		if err != nil {
+			t.Fatalf("NewWithOptions: %v", err)
+		}
+
+		router.Send("disk full", nil)
+		router.Send("disk full", nil)
+
+		if err := router.Close(context.Background()); err != nil {
+			t.Fatalf("Close: %v", err)
+		}
+
+		if got, want := svc.sent(), []string{"disk full", "disk full (repeated 1 time)"}; !slices.Equal(got, want) {
+			t.Errorf("sent = %q, want %q", got, want)
+		}
+	})
+}
@@ -0,0 +1,114 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+	"text/template"
+	"time"
+
+	"github.com/nicholas-fedor/shoutrrr/pkg/types"
+)
+
+// cancelAwareService is a test ContextSender that blocks until its context is done
+// and reports the context error it observed.
+type cancelAwareService struct {
+	observed chan error
+}
+
+func (s *cancelAwareService) GetID() string {
+	return "mock-cancel"
+}
+
+func (s *cancelAwareService) GetTemplate(_ string) (*template.Template, bool) {
+	return nil, false
+}
+
+func (s *cancelAwareService) Initialize(_ *url.URL, _ types.StdLogger) error {
+	return nil
+}
+
+func (s *cancelAwareService) Send(message string, params *types.Params) error {
+	return s.SendContext(context.Background(), message, params)
+}
+
+func (s *cancelAwareService) SendContext(ctx context.Context, _ string, _ *types.Params) error {
+	<-ctx.Done()
+	s.observed <- ctx.Err()
+
+	return ctx.Err()
+}
+
+func (s *cancelAwareService) SetLogger(_ types.StdLogger) {}
+
+func (s *cancelAwareService) SetTemplateFile(_, _ string) error {
+	return nil
+}
+
+func (s *cancelAwareService) SetTemplateString(_, _ string) error {
+	return nil
+}
+
+//nolint:paralleltest // Modifies shared serviceMap; cannot run in parallel.
+func TestSendContextCancelsInFlightSend(t *testing.T) {
+	svc := &cancelAwareService{observed: make(chan error, 1)}
+
+	serviceMap["mock-cancel"] = func() types.Service { return svc }
+	defer delete(serviceMap, "mock-cancel")
+
+	router, err := NewWithOptions(nil, types.SenderOptions{Timeout: time.Minute}, "mock-cancel://")
+	if err != nil {
+		t.Fatalf("NewWithOptions: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(10*time.Millisecond, cancel)
+
+	errs := router.SendContext(ctx, "test", nil)
+
+	var targetErr *types.TargetError
+	if !errors.As(errs[0], &targetErr) {
+		t.Fatalf("SendContext error is not *types.TargetError: %T", errs[0])
+	}
+
+	if !errors.Is(errs[0], context.Canceled) {
+		t.Errorf("SendContext error = %v, want context.Canceled", errs[0])
+	}
+
+	select {
+	case observed := <-svc.observed:
+		if !errors.Is(observed, context.Canceled) {
+			t.Errorf("service observed %v, want context.Canceled", observed)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("service did not observe the cancellation")
+	}
+}
+
+//nolint:paralleltest // Modifies shared serviceMap; cannot run in parallel.
+func TestSendContextReportsTimeout(t *testing.T) {
+	svc := &cancelAwareService{observed: make(chan error, 1)}
+
+	serviceMap["mock-cancel"] = func() types.Service { return svc }
+	defer delete(serviceMap, "mock-cancel")
+
+	router, err := NewWithOptions(nil, types.SenderOptions{Timeout: 10 * time.Millisecond}, "mock-cancel://")
+	if err != nil {
+		t.Fatalf("NewWithOptions: %v", err)
+	}
+
+	errs := router.SendContext(context.Background(), "test", nil)
+	if !errors.Is(errs[0], ErrServiceTimeout) {
+		t.Errorf("SendContext error = %v, want ErrServiceTimeout", errs[0])
+	}
+
+	select {
+	case observed := <-svc.observed:
+		if !errors.Is(observed, context.DeadlineExceeded) {
+			t.Errorf("service observed %v, want context.DeadlineExceeded", observed)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("service did not observe the deadline")
+	}
+}
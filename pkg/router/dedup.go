@@ -0,0 +1,164 @@
+package router
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/nicholas-fedor/shoutrrr/pkg/types"
+)
+
+// deduplicator tracks the messages sent within the dedup window.
+type deduplicator struct {
+	policy  types.DedupPolicy
+	mu      sync.Mutex
+	entries map[string]*dedupEntry
+}
+
+// dedupEntry tracks the repeats of a message within its window.
+type dedupEntry struct {
+	repeats int
+	params  types.Params
+	summary func(repeats int, params types.Params)
+	timer   *time.Timer
+}
+
+// SetDedupPolicy sets the duplicate suppression policy of the router. A zero
+// Window disables deduplication. Pending summaries of the previous policy are dropped.
+//
+// Parameters:
+//   - policy: the policy to use.
+func (r *ServiceRouter) SetDedupPolicy(policy types.DedupPolicy) {
+	if r.dedup != nil {
+		r.dedup.stop()
+	}
+
+	r.dedup = nil
+
+	if policy.Window > 0 {
+		r.dedup = &deduplicator{policy: policy, entries: make(map[string]*dedupEntry)}
+	}
+}
+
+// suppress reports whether the message is a repeat within the dedup window. For the
+// first occurrence, it opens a window after which summary is called with the number
+// of suppressed repeats, if any, and the params of the last repeat.
+//
+// Parameters:
+//   - message: the message text, used for the key when no explicit key is set.
+//   - params: the message params.
+//   - summary: the function delivering the roll-up of suppressed repeats.
+//
+// Returns:
+//   - bool: true if the message must not be delivered.
+func (r *ServiceRouter) suppress(
+	message string,
+	params types.Params,
+	summary func(repeats int, params types.Params),
+) bool {
+	dedup := r.dedup
+	if dedup == nil {
+		return false
+	}
+
+	key := dedup.key(message, params)
+
+	dedup.mu.Lock()
+	defer dedup.mu.Unlock()
+
+	if entry, found := dedup.entries[key]; found {
+		entry.repeats++
+		entry.params = maps.Clone(params)
+		entry.summary = summary
+
+		return true
+	}
+
+	entry := &dedupEntry{summary: summary}
+	entry.timer = time.AfterFunc(dedup.policy.Window, func() { dedup.close(key) })
+	dedup.entries[key] = entry
+
+	return false
+}
+
+// key returns the identity of the message: the explicit dedup key param, or a hash
+// of the message and the selected params.
+func (d *deduplicator) key(message string, params types.Params) string {
+	if key, found := params[types.DedupKey]; found && key != "" {
+		return "key:" + key
+	}
+
+	hash := sha256.New()
+	hash.Write([]byte(message))
+
+	for _, name := range d.policy.Params {
+		fmt.Fprintf(hash, "\x00%s=%s", name, params[name])
+	}
+
+	return "hash:" + hex.EncodeToString(hash.Sum(nil))
+}
+
+// close ends the window of the message with key and delivers the summary of its repeats.
+func (d *deduplicator) close(key string) {
+	d.mu.Lock()
+	entry, found := d.entries[key]
+	delete(d.entries, key)
+	d.mu.Unlock()
+
+	if found && entry.repeats > 0 {
+		entry.summary(entry.repeats, entry.params)
+	}
+}
+
+// stop ends all windows without delivering their summaries.
+func (d *deduplicator) stop() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for key, entry := range d.entries {
+		entry.timer.Stop()
+		delete(d.entries, key)
+	}
+}
+
+// drain ends all windows, delivering the summaries of their repeats.
+func (d *deduplicator) drain() {
+	d.mu.Lock()
+	keys := slices.Collect(maps.Keys(d.entries))
+	d.mu.Unlock()
+
+	for _, key := range keys {
+		d.mu.Lock()
+		entry, found := d.entries[key]
+		d.mu.Unlock()
+
+		if found && entry.timer.Stop() {
+			d.close(key)
+		}
+	}
+}
+
+// sendRepeatSummary returns the summary function delivering the roll-up of the
+// suppressed repeats of message to all services.
+func (r *ServiceRouter) sendRepeatSummary(message string) func(repeats int, params types.Params) {
+	return func(repeats int, params types.Params) {
+		for err := range r.sendAsync(r.baseContext(), message+" "+repeatedSummary(repeats), params, false) {
+			if err != nil {
+				r.log("Failed to send repeat summary:", err)
+			}
+		}
+	}
+}
+
+// repeatedSummary returns the text appended to a message to roll up its repeats.
+func repeatedSummary(repeats int) string {
+	if repeats == 1 {
+		return "(repeated 1 time)"
+	}
+
+	return fmt.Sprintf("(repeated %d times)", repeats)
+}
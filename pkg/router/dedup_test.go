@@ -0,0 +1,126 @@
+package router
+
+import (
+	"slices"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/nicholas-fedor/shoutrrr/pkg/types"
+)
+
+//nolint:paralleltest // Modifies shared serviceMap; cannot run in parallel.
+func TestDedupSuppressesRepeatsAndSendsSummary(t *testing.T) {
+	svc := &recordingService{}
+
+	serviceMap["mock-params"] = func() types.Service { return svc }
+	defer delete(serviceMap, "mock-params")
+
+	synctest.Test(t, func(t *testing.T) {
+		router, err := NewWithOptions(nil, types.SenderOptions{
+			Dedup: types.DedupPolicy{Window: time.Minute, Params: []string{"title"}},
+		}, "mock-params://")
+		if err != nil {
+			t.Fatalf("NewWithOptions: %v", err)
+		}
+
+		disk := types.Params{"title": "disk"}
+		cpu := types.Params{"title": "cpu"}
+
+		for range 3 {
+			router.Send("usage high", &disk)
+		}
+
+		if errs := router.Send("usage high", &disk); errs[0] != nil {
+			t.Errorf("repeat error = %v, want nil", errs[0])
+		}
+
+		if errs := router.Send("usage high", &cpu); errs[0] != nil {
+			t.Errorf("Send with other params = %v, want delivery", errs[0])
+		}
+
+		time.Sleep(time.Minute)
+		synctest.Wait()
+
+		want := []string{"usage high", "usage high", "usage high (repeated 3 times)"}
+		if !slices.Equal(svc.messages, want) {
+			t.Errorf("messages = %q, want %q", svc.messages, want)
+		}
+
+		if errs := router.Send("usage high", &disk); errs[0] != nil {
+			t.Errorf("Send after window = %v, want delivery", errs[0])
+		}
+	})
+}
+
+//nolint:paralleltest // Modifies shared serviceMap; cannot run in parallel.
+func TestDedupUsesExplicitKey(t *testing.T) {
+	svc := &recordingService{}
+
+	serviceMap["mock-params"] = func() types.Service { return svc }
+	defer delete(serviceMap, "mock-params")
+
+	synctest.Test(t, func(t *testing.T) {
+		router, err := NewWithOptions(nil, types.SenderOptions{
+			Dedup: types.DedupPolicy{Window: time.Minute},
+		}, "mock-params://")
+		if err != nil {
+			t.Fatalf("NewWithOptions: %v", err)
+		}
+
+		params := types.Params{types.DedupKey: "backup-job"}
+
+		router.Send("backup failed at 01:00", &params)
+
+		results := router.SendWithReport("backup failed at 01:05", &params)
+		if !results[0].Suppressed || results[0].Err != nil {
+			t.Errorf("report = %+v, want a suppressed outcome", results[0])
+		}
+
+		time.Sleep(time.Minute)
+		synctest.Wait()
+
+		want := []string{"backup failed at 01:00", "backup failed at 01:05 (repeated 1 time)"}
+		if !slices.Equal(svc.messages, want) {
+			t.Errorf("messages = %q, want %q", svc.messages, want)
+		}
+
+		for _, params := range svc.params {
+			if _, found := params[types.DedupKey]; found {
+				t.Errorf("service received the dedup key param: %v", params)
+			}
+		}
+	})
+}
+
+//nolint:paralleltest // Modifies shared serviceMap; cannot run in parallel.
+func TestDedupSuppressesRepeatedItems(t *testing.T) {
+	svc := &recordingService{}
+
+	serviceMap["mock-recording"] = func() types.Service { return svc }
+	defer delete(serviceMap, "mock-recording")
+
+	synctest.Test(t, func(t *testing.T) {
+		router, err := NewWithOptions(nil, types.SenderOptions{
+			Dedup: types.DedupPolicy{Window: time.Minute},
+		}, "mock-recording://")
+		if err != nil {
+			t.Fatalf("NewWithOptions: %v", err)
+		}
+
+		items := []types.MessageItem{{Text: "watcher fired", Level: types.Warning}}
+
+		router.SendItems(items, types.Params{})
+
+		if errs := router.SendItems(items, types.Params{}); errs[0] != nil {
+			t.Errorf("repeat error = %v, want nil", errs[0])
+		}
+
+		time.Sleep(time.Minute)
+		synctest.Wait()
+
+		if len(svc.items) != 3 || svc.items[2].Text != "(repeated 1 time)" || svc.items[2].Level != types.Warning {
+			t.Errorf("items = %+v, want the original, then the original with a summary", svc.items)
+		}
+	})
+}
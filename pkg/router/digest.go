@@ -0,0 +1,273 @@
+package router
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/nicholas-fedor/shoutrrr/pkg/types"
+)
+
+// ErrDigestClosed is returned when enqueuing to a closed Digest.
+var ErrDigestClosed = errors.New("digest is closed")
+
+// DigestOptions configures when a Digest flushes its queued messages.
+// At least one threshold should be set; otherwise messages are only sent by
+// Flush and Close.
+type DigestOptions struct {
+	// Interval is how long messages are collected after the first one is queued.
+	Interval time.Duration
+	// MaxMessages flushes the digest once this many messages are queued.
+	MaxMessages int
+	// MaxBytes flushes the digest once the queued messages reach this size in bytes.
+	MaxBytes int
+	// Params are the parameters applied to every digest notification.
+	Params types.Params
+}
+
+// Digest collects messages and sends them through a ServiceRouter as one combined
+// notification per target, once the interval elapses or a threshold is reached.
+// Digests larger than the MessageLimit of a service are split into several notifications.
+type Digest struct {
+	router  *ServiceRouter
+	opts    DigestOptions
+	mu      sync.Mutex
+	queue   []string
+	size    int
+	timer   *time.Timer
+	armed   uint64 // armed counts the interval timers started, identifying the current one.
+	closed  bool
+	pending sync.WaitGroup
+}
+
+// NewDigest returns a Digest sending through router.
+//
+// Parameters:
+//   - router: the router to send the digests through.
+//   - opts: the flush thresholds and digest params.
+//
+// Returns:
+//   - *Digest: the new digest.
+func NewDigest(router *ServiceRouter, opts DigestOptions) *Digest {
+	return &Digest{router: router, opts: opts}
+}
+
+// Enqueuef formats the message and adds it to the digest.
+//
+// Parameters:
+//   - format: the message format.
+//   - v: the format arguments.
+//
+// Returns:
+//   - error: ErrDigestClosed if the digest is closed.
+func (d *Digest) Enqueuef(format string, v ...any) error {
+	return d.Enqueue(fmt.Sprintf(format, v...))
+}
+
+// Enqueue adds the message to the digest. If a threshold is reached, the digest
+// is flushed in the background.
+//
+// Parameters:
+//   - message: the message to add.
+//
+// Returns:
+//   - error: ErrDigestClosed if the digest is closed.
+func (d *Digest) Enqueue(message string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.closed {
+		return ErrDigestClosed
+	}
+
+	d.queue = append(d.queue, message)
+	d.size += len(message)
+
+	if (d.opts.MaxMessages > 0 && len(d.queue) >= d.opts.MaxMessages) ||
+		(d.opts.MaxBytes > 0 && d.size >= d.opts.MaxBytes) {
+		messages := d.take()
+
+		d.pending.Go(func() { d.logErrors(d.send(messages)) })
+
+		return nil
+	}
+
+	if d.timer == nil && d.opts.Interval > 0 {
+		d.armed++
+		armed := d.armed
+		d.timer = time.AfterFunc(d.opts.Interval, func() { d.flushTimer(armed) })
+	}
+
+	return nil
+}
+
+// flushTimer sends the queued messages when the interval timer armed as the
+// given generation fires. It does nothing if the digest was closed or the timer
+// was stopped after it fired, as the queue then belongs to Close or to the next
+// interval.
+func (d *Digest) flushTimer(armed uint64) {
+	d.mu.Lock()
+
+	if d.closed || d.timer == nil || d.armed != armed {
+		d.mu.Unlock()
+
+		return
+	}
+
+	messages := d.take()
+	d.pending.Add(1)
+	d.mu.Unlock()
+
+	defer d.pending.Done()
+
+	d.logErrors(d.send(messages))
+}
+
+// Flush sends the queued messages immediately.
+//
+// Returns:
+//   - error: the joined delivery errors, or nil if all deliveries succeeded.
+func (d *Digest) Flush() error {
+	d.mu.Lock()
+	messages := d.take()
+	d.mu.Unlock()
+
+	return d.send(messages)
+}
+
+// Close flushes the queued messages and waits for background flushes to finish.
+// Messages enqueued after Close are rejected.
+//
+// Returns:
+//   - error: the joined delivery errors of the final flush, or nil.
+func (d *Digest) Close() error {
+	d.mu.Lock()
+	d.closed = true
+	messages := d.take()
+	d.mu.Unlock()
+
+	err := d.send(messages)
+
+	d.pending.Wait()
+
+	return err
+}
+
+// take empties the queue and stops the interval timer. The caller must hold d.mu.
+func (d *Digest) take() []string {
+	if d.timer != nil {
+		d.timer.Stop()
+		d.timer = nil
+	}
+
+	messages := d.queue
+	d.queue = nil
+	d.size = 0
+
+	return messages
+}
+
+// send delivers the messages as one digest per target, split to fit each
+// service's MessageLimit.
+func (d *Digest) send(messages []string) error {
+	if len(messages) == 0 {
+		return nil
+	}
+
+	r := d.router
+	params := maps.Clone(d.opts.Params)
+
+	if params == nil {
+		params = types.Params{}
+	}
+
+	var (
+		errs []error
+		mu   sync.Mutex
+		wg   sync.WaitGroup
+	)
+
+	for i, service := range r.services {
+		if !r.allowsMessage(i, params) {
+			continue
+		}
+
+		wg.Go(func() {
+			for _, part := range splitDigest(messages, digestLimit(service)) {
+				result := make(chan error, 1)
+				r.sendToService(r.baseContext(), service, result, part, params)
+
+				if err := <-result; err != nil {
+					mu.Lock()
+					errs = append(errs, err)
+					mu.Unlock()
+				}
+			}
+		})
+	}
+
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// logErrors logs the delivery errors of a background flush.
+func (d *Digest) logErrors(err error) {
+	if err != nil {
+		d.router.log("Failed to send digest:", err)
+	}
+}
+
+// digestLimit returns the maximum number of runes of a single notification to
+// service, or 0 if the service declares no limit.
+func digestLimit(service types.Service) int {
+	limiter, ok := service.(types.MessageLimiter)
+	if !ok {
+		return 0
+	}
+
+	return limiter.MessageLimit().TotalChunkSize
+}
+
+// splitDigest joins the messages with newlines into parts of at most limit runes.
+// Messages are never split; a message longer than limit is sent as its own part.
+//
+// Parameters:
+//   - messages: the messages to join.
+//   - limit: the maximum number of runes per part; 0 means no limit.
+//
+// Returns:
+//   - []string: the digest parts.
+func splitDigest(messages []string, limit int) []string {
+	if limit <= 0 {
+		return []string{strings.Join(messages, "\n")}
+	}
+
+	var (
+		parts   []string
+		current []string
+		length  int
+	)
+
+	for _, message := range messages {
+		runes := utf8.RuneCountInString(message)
+
+		if len(current) > 0 && length+1+runes > limit {
+			parts = append(parts, strings.Join(current, "\n"))
+			current, length = nil, 0
+		}
+
+		if len(current) > 0 {
+			length++
+		}
+
+		current = append(current, message)
+		length += runes
+	}
+
+	return append(parts, strings.Join(current, "\n"))
+}
@@ -0,0 +1,181 @@
+package router
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/nicholas-fedor/shoutrrr/pkg/types"
+)
+
+// limitedService is a test service declaring a small MessageLimit.
+type limitedService struct {
+	recordingService
+}
+
+func (s *limitedService) MessageLimit() types.MessageLimit {
+	return types.MessageLimit{ChunkSize: 10, TotalChunkSize: 10, ChunkCount: 1}
+}
+
+//nolint:paralleltest // Modifies shared serviceMap; cannot run in parallel.
+func TestDigestFlushesAfterInterval(t *testing.T) {
+	svc := &recordingService{}
+
+	serviceMap["mock-recording"] = func() types.Service { return svc }
+	defer delete(serviceMap, "mock-recording")
+
+	synctest.Test(t, func(t *testing.T) {
+		router, err := NewWithOptions(nil, types.SenderOptions{}, "mock-recording://")
+		if err != nil {
+			t.Fatalf("NewWithOptions: %v", err)
+		}
+
+		digest := NewDigest(router, DigestOptions{Interval: time.Minute})
+
+		for i := range 300 {
+			if err := digest.Enqueuef("file %d backed up", i); err != nil {
+				t.Fatalf("Enqueuef: %v", err)
+			}
+		}
+
+		time.Sleep(59 * time.Second)
+		synctest.Wait()
+
+		if sent := svc.sent(); len(sent) != 0 {
+			t.Fatalf("digest sent %d messages before the interval elapsed", len(sent))
+		}
+
+		time.Sleep(time.Second)
+		synctest.Wait()
+
+		if sent := svc.sent(); len(sent) != 1 || strings.Count(sent[0], "\n") != 299 {
+			t.Errorf("got %d messages, want one digest of 300 lines", len(sent))
+		}
+
+		if err := digest.Close(); err != nil {
+			t.Errorf("Close: %v", err)
+		}
+	})
+}
+
+//nolint:paralleltest // Modifies shared serviceMap; cannot run in parallel.
+func TestDigestFlushesAtThresholdAndOnClose(t *testing.T) {
+	svc := &recordingService{}
+
+	serviceMap["mock-recording"] = func() types.Service { return svc }
+	defer delete(serviceMap, "mock-recording")
+
+	router, err := NewWithOptions(nil, types.SenderOptions{}, "mock-recording://")
+	if err != nil {
+		t.Fatalf("NewWithOptions: %v", err)
+	}
+
+	digest := NewDigest(router, DigestOptions{MaxMessages: 2})
+
+	for _, message := range []string{"one", "two", "three"} {
+		if err := digest.Enqueue(message); err != nil {
+			t.Fatalf("Enqueue: %v", err)
+		}
+	}
+
+	if err := digest.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	slices.Sort(svc.messages)
+
+	if want := []string{"one\ntwo", "three"}; !slices.Equal(svc.messages, want) {
+		t.Errorf("messages = %q, want %q", svc.messages, want)
+	}
+
+	if err := digest.Enqueue("late"); !errors.Is(err, ErrDigestClosed) {
+		t.Errorf("Enqueue after Close = %v, want %v", err, ErrDigestClosed)
+	}
+}
+
+//nolint:paralleltest // Modifies shared serviceMap; cannot run in parallel.
+func TestDigestRespectsMessageLimit(t *testing.T) {
+	limited := &limitedService{}
+	unlimited := &recordingService{}
+
+	serviceMap["mock-limited"] = func() types.Service { return limited }
+	serviceMap["mock-recording"] = func() types.Service { return unlimited }
+
+	defer delete(serviceMap, "mock-limited")
+	defer delete(serviceMap, "mock-recording")
+
+	router, err := NewWithOptions(nil, types.SenderOptions{}, "mock-limited://", "mock-recording://")
+	if err != nil {
+		t.Fatalf("NewWithOptions: %v", err)
+	}
+
+	digest := NewDigest(router, DigestOptions{})
+
+	for _, message := range []string{"alpha", "beta", "gamma", "a very long line"} {
+		_ = digest.Enqueue(message)
+	}
+
+	if err := digest.Flush(); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+
+	if want := []string{"alpha\nbeta", "gamma", "a very long line"}; !slices.Equal(limited.messages, want) {
+		t.Errorf("limited service got %q, want %q", limited.messages, want)
+	}
+
+	if want := []string{"alpha\nbeta\ngamma\na very long line"}; !slices.Equal(unlimited.messages, want) {
+		t.Errorf("unlimited service got %q, want %q", unlimited.messages, want)
+	}
+}
+
+//nolint:paralleltest // Modifies shared serviceMap; cannot run in parallel.
+func TestDigestIgnoresStaleTimer(t *testing.T) {
+	svc := &recordingService{}
+
+	serviceMap["mock-recording"] = func() types.Service { return svc }
+	defer delete(serviceMap, "mock-recording")
+
+	router, err := NewWithOptions(nil, types.SenderOptions{}, "mock-recording://")
+	if err != nil {
+		t.Fatalf("NewWithOptions: %v", err)
+	}
+
+	digest := NewDigest(router, DigestOptions{Interval: time.Hour})
+
+	if err := digest.Enqueue("one"); err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+
+	stale := digest.armed
+
+	if err := digest.Flush(); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+
+	if err := digest.Enqueue("two"); err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+
+	// A callback of the flushed interval must not send the next batch early.
+	digest.flushTimer(stale)
+
+	if sent := svc.sent(); !slices.Equal(sent, []string{"one"}) {
+		t.Errorf("messages after stale timer = %q, want %q", sent, []string{"one"})
+	}
+
+	current := digest.armed
+
+	if err := digest.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	// A callback firing after Close must not send or wait on the digest.
+	digest.flushTimer(current)
+
+	if sent := svc.sent(); !slices.Equal(sent, []string{"one", "two"}) {
+		t.Errorf("messages after Close = %q, want %q", sent, []string{"one", "two"})
+	}
+}
@@ -14,14 +14,146 @@
 //   - Initializing services from URLs
 //   - Sending messages synchronously and asynchronously
 //   - Managing service lifecycles
-//   - Queueing and flushing batched messages
+//   - Queueing and flushing batched messages, optionally through a durable outbox
 //   - Dispatching structured MessageItems to services that implement RichSender
 //   - Propagating context.Context to services that implement ContextSender
+//   - Retrying failed deliveries according to a types.RetryPolicy
+//
+// SendContext, SendAsyncContext and SendItemsContext accept a caller-supplied
+// context; canceling it aborts in-flight requests of context-aware services.
 //
 // Errors returned from Send/SendAsync/SendItems are wrapped in *types.TargetError
 // so callers can identify which service failed and use errors.Is/errors.As against
 // the underlying error.
 //
+// Retries (retry.go)
+//
+// When SenderOptions.Retry allows more than one attempt, failed sends are retried
+// with exponential backoff and jitter. Delays requested by the server through
+// types.RetryAfterError (e.g. Retry-After headers surfaced by jsonclient) take
+// precedence, and all attempts share the per-service Timeout.
+//
+// Delivery Reports (report.go)
+//
+// SendWithReport returns a types.DeliveryResult per service with the redacted URL,
+// duration, attempt count, HTTP status or broker ack, provider message ID and error.
+// Services report details through the types.DeliveryReceipt attached to their context.
+//
+// Metrics (metrics.go)
+//
+// SenderOptions.Metrics or SetMetrics installs a types.Metrics hook observing the
+// scheme, duration, attempts, size and error class of every delivery. Errors
+// returned by the router carry their types.ErrorClass in TargetError.Class.
+// Package metrics provides a Prometheus exporter.
+//
+// Router Params (urlparams.go)
+//
+// The __timeout, __retries and __minlevel query parameters of a service URL
+// override the timeout, retries and minimum level of that target. They are removed
+// from the URL before the service is initialized. URLParams documents them.
+// They apply to services added with AddService or AddGroup; Locate only strips
+// them, and HasURLParams lets direct senders reject them.
+//
+// Priority Mapping (levelmap.go)
+//
+// For services implementing types.LevelMapper, the message level is translated to
+// the service's native priority param, unless the message or the target's default
+// params set it. The __levelmap URL param overrides or disables the mapping.
+//
+// Body Format (bodyformat.go)
+//
+// The format param declares the markup of the message body: text, markdown or
+// html. Services implementing types.MarkupRenderer render it themselves. For other
+// services, the body is converted to plain text and the param is removed.
+//
+// Oversized Messages (oversize.go)
+//
+// The OversizePolicy of the router, or the __oversize URL param of a target,
+// splits, truncates or rejects messages longer than the MessageLimit of the
+// service, measured as the service renders the format param. Split messages are
+// sent as numbered parts, cut without breaking code blocks or HTML elements, and a
+// retry resumes from the first part not delivered.
+//
+// Level Routing (level.go)
+//
+// AddServiceWithFilter attaches a LevelFilter to a target. Send filters on the
+// level param and SendItems filters every MessageItem by its Level, so each
+// target only receives the messages it is interested in.
+//
+// Target Options (target.go)
+//
+// AddServiceWithOptions adds a target with a level filter and default params,
+// which the params passed with a message override. SenderOptions.Templates are
+// registered on every service, so that URLs can refer to them by ID.
+//
+// Duplicate Suppression (dedup.go)
+//
+// With a types.DedupPolicy, repeats of a message within the window are not
+// delivered, which is not a failure: Send reports a nil error and SendWithReport
+// sets Suppressed. They are rolled up into a "(repeated N times)" summary when
+// the window closes.
+//
+// Secret References (router.go)
+//
+// With SenderOptions.ExpandSecrets, service URLs are expanded with
+// secrets.ExpandURL before they are parsed, so ${env:NAME}, ${file:/path} and
+// other registered secret references can stand in for embedded tokens and
+// passwords. Expansion is off by default, as it reads local files and
+// environment variables into outbound requests.
+//
+// Redaction (router.go)
+//
+// Service URLs in log lines, delivery results and TargetError values are redacted
+// with format.RedactServiceURL, which masks the config fields tagged
+// `sensitive:"true"`. URLs that HTTP clients embed in errors are redacted too.
+//
+// Circuit Breaker (breaker.go)
+//
+// With a types.CircuitBreakerPolicy, a target whose deliveries keep failing has
+// its circuit opened: deliveries fail fast with ErrCircuitOpen until a probe after
+// the cooldown succeeds. Health and BrokenTargets report the state of each target.
+//
+// Rate Limiting (ratelimit.go)
+//
+// Every HTTP request a service makes is throttled by a token bucket per target,
+// defaulting to the types.RateLimit the service declares, and optionally per API
+// host. SenderOptions.MaxConcurrent caps the sends in flight across all targets.
+//
+// Middleware (middleware.go)
+//
+// Use, or SenderOptions.Middleware, adds types.Middleware that runs around every
+// delivery, in order. Middleware can enrich params, rewrite the message or items,
+// observe results, or veto a delivery by not calling the next handler.
+//
+// Routing Groups (group.go)
+//
+// AddGroup configures a named set of member services that receive messages sent
+// with SendGroup: in order until one delivers (GroupFailover), concurrently until
+// one delivers (GroupFirstSuccess), or concurrently with a minimum number of
+// successful deliveries (GroupQuorum). The GroupResult reports which members delivered.
+// GroupOptions.Filter skips the whole group for excluded levels, and
+// GroupOptions.Params sets default params per member. AddTargetGroup builds a
+// group from targets already added, sharing their services, filters and state.
+//
+// Digests (digest.go)
+//
+// NewDigest collects messages and sends them as one combined notification per
+// target once an interval elapses or a count or size threshold is reached, split
+// to fit the MessageLimit of each service. Close sends what is still queued.
+//
+// Outbox (outbox.go)
+//
+// SetOutbox attaches an outbox.Outbox that journals every delivery made by Flush,
+// and replays the entries left pending by a previous run. Failed deliveries stay
+// in the outbox until they succeed or expire; ReplayOutbox retries them on demand.
+//
+// Lifecycle (close.go)
+//
+// Close sends the queued messages and pending repeat summaries, rejects new
+// deliveries with ErrRouterClosed, waits for in-flight ones and closes the services
+// implementing types.ContextCloser or io.Closer, such as MQTT connections and
+// Matrix sessions.
+//
 // Service Factory (servicemap.go)
 //
 // Maps service schemes to their factory functions, enabling dynamic service
@@ -36,6 +168,8 @@
 //
 // SupportedSchemas and SupportsSchema expose the set of registered service
 // schemes, enabling discovery without constructing a router.
+// RegisterService, OverrideService and UnregisterService add, replace and remove
+// services at runtime, so custom backends can be used through their own URL schemes.
 //
 // Basic usage:
 //
@@ -0,0 +1,446 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/nicholas-fedor/shoutrrr/pkg/types"
+)
+
+// GroupMode determines how a routing group delivers a message to its members.
+type GroupMode int
+
+const (
+	// GroupFailover tries the members one at a time, in order, until one delivers.
+	GroupFailover GroupMode = iota
+	// GroupFirstSuccess sends to all members concurrently and cancels the remaining
+	// deliveries as soon as one member delivers.
+	GroupFirstSuccess
+	// GroupQuorum sends to all members concurrently and succeeds when at least
+	// GroupOptions.Quorum members deliver.
+	GroupQuorum
+)
+
+var (
+	ErrUnknownGroup       = errors.New("unknown routing group")
+	ErrGroupExists        = errors.New("routing group already exists")
+	ErrEmptyGroup         = errors.New("routing group has no members")
+	ErrInvalidGroupMode   = errors.New("invalid routing group mode")
+	ErrInvalidQuorum      = errors.New("invalid routing group quorum")
+	ErrGroupFailed        = errors.New("routing group failed to deliver")
+	ErrMemberSkipped      = errors.New("member skipped")
+	ErrInvalidGroupParams = errors.New("more routing group params than members")
+	ErrUnknownTarget      = errors.New("unknown target")
+	ErrTargetGroupParams  = errors.New("target group members use the params of their targets")
+)
+
+// groupModeNames maps the textual group modes to their values.
+var groupModeNames = map[string]GroupMode{
+	"failover":      GroupFailover,
+	"first-success": GroupFirstSuccess,
+	"quorum":        GroupQuorum,
+}
+
+// GroupOptions configures a routing group.
+type GroupOptions struct {
+	// Mode determines how the message is delivered to the members.
+	Mode GroupMode
+	// Quorum is the number of members that must deliver in GroupQuorum mode.
+	// Zero requires all members to deliver.
+	Quorum int
+	// Filter restricts the message levels delivered to the group. The zero value
+	// allows all levels.
+	Filter LevelFilter
+	// Params holds the default parameters of the members, in member order. It may
+	// be shorter than the member list. Parameters passed with a message take precedence.
+	Params []types.Params
+}
+
+// GroupResult describes the outcome of sending a message to a routing group.
+type GroupResult struct {
+	// Group is the name of the routing group.
+	Group string
+	// Results holds one delivery result per member, in member order. Members that
+	// were not attempted, e.g. after an earlier failover member delivered, have an
+	// error wrapping ErrMemberSkipped.
+	Results []types.DeliveryResult
+	// DeliveredBy holds the indices of the members that delivered the message.
+	DeliveredBy []int
+	// Err is nil if the group delivered the message, or an error wrapping ErrGroupFailed.
+	Err error
+	// Skipped is true if the group's level filter excluded the message. No member
+	// was attempted and Err is nil.
+	Skipped bool
+}
+
+// Delivered returns the results of the members that delivered the message.
+//
+// Returns:
+//   - []types.DeliveryResult: the successful member deliveries.
+func (g GroupResult) Delivered() []types.DeliveryResult {
+	delivered := make([]types.DeliveryResult, 0, len(g.DeliveredBy))
+	for _, index := range g.DeliveredBy {
+		delivered = append(delivered, g.Results[index])
+	}
+
+	return delivered
+}
+
+// routeGroup holds the members of a routing group.
+type routeGroup struct {
+	opts     GroupOptions
+	services []types.Service
+	urls     []string
+	filters  []LevelFilter
+}
+
+// add appends a member with its redacted URL and level filter.
+func (g *routeGroup) add(service types.Service, url string, filter LevelFilter) {
+	g.services = append(g.services, service)
+	g.urls = append(g.urls, url)
+	g.filters = append(g.filters, filter)
+}
+
+// allows reports whether a message with params passes the filter of the member at index.
+func (g *routeGroup) allows(index int, params types.Params) bool {
+	level, _ := params.Level()
+
+	return g.filters[index].Allows(level)
+}
+
+// ParseGroupMode returns the GroupMode for its textual name: "failover",
+// "first-success" or "quorum".
+//
+// Parameters:
+//   - name: the name of the mode.
+//
+// Returns:
+//   - GroupMode: the parsed mode.
+//   - error: an error if the name is not a known mode.
+func ParseGroupMode(name string) (GroupMode, error) {
+	mode, ok := groupModeNames[strings.ToLower(name)]
+	if !ok {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidGroupMode, name)
+	}
+
+	return mode, nil
+}
+
+// String returns the textual name of the mode.
+func (m GroupMode) String() string {
+	switch m {
+	case GroupFailover:
+		return "failover"
+	case GroupFirstSuccess:
+		return "first-success"
+	case GroupQuorum:
+		return "quorum"
+	default:
+		return fmt.Sprintf("GroupMode(%d)", int(m))
+	}
+}
+
+// AddGroup initializes the member services from their URLs and adds them as a
+// routing group. Group members only receive messages sent with SendGroup.
+//
+// Parameters:
+//   - name: the name of the group.
+//   - opts: the group options.
+//   - serviceURLs: the member service URLs, in failover order.
+//
+// Returns:
+//   - error: an error if the options are invalid or any member fails to initialize.
+func (r *ServiceRouter) AddGroup(name string, opts GroupOptions, serviceURLs ...string) error {
+	if err := r.checkGroup(name, opts, len(serviceURLs)); err != nil {
+		return err
+	}
+
+	if len(opts.Params) > len(serviceURLs) {
+		return fmt.Errorf("%w: %d for %d members", ErrInvalidGroupParams, len(opts.Params), len(serviceURLs))
+	}
+
+	group := &routeGroup{opts: opts}
+
+	for _, serviceURL := range serviceURLs {
+		service, overrides, err := r.initService(serviceURL)
+		if err != nil {
+			return fmt.Errorf("error initializing routing group %q: %w", name, err)
+		}
+
+		r.setOverrides(service, overrides)
+		group.add(service, redactedURL(service, serviceURL), LevelFilter{MinLevel: overrides.minLevel})
+	}
+
+	for i, params := range opts.Params {
+		r.setDefaultParams(group.services[i], params)
+	}
+
+	r.addGroup(name, group)
+
+	return nil
+}
+
+// AddTargetGroup adds targets already added to the router as a routing group,
+// so that no service is initialized again. The members share the state of their
+// targets, such as the circuit breaker, and keep their level filters and
+// default params. Group members also receive messages sent with Send.
+//
+// Parameters:
+//   - name: the name of the group.
+//   - opts: the group options. Params must be empty.
+//   - targets: the indices of the member targets, in the order they were added
+//     to the router, in failover order.
+//
+// Returns:
+//   - error: an error if the options are invalid or an index has no target.
+func (r *ServiceRouter) AddTargetGroup(name string, opts GroupOptions, targets ...int) error {
+	if err := r.checkGroup(name, opts, len(targets)); err != nil {
+		return err
+	}
+
+	if len(opts.Params) > 0 {
+		return fmt.Errorf("%w: group %q", ErrTargetGroupParams, name)
+	}
+
+	group := &routeGroup{opts: opts}
+
+	for _, index := range targets {
+		if index < 0 || index >= len(r.services) {
+			return fmt.Errorf("%w: %d in group %q", ErrUnknownTarget, index, name)
+		}
+
+		group.add(r.services[index], r.urls[index], r.filters[index])
+	}
+
+	r.addGroup(name, group)
+
+	return nil
+}
+
+// checkGroup validates the name and options of a new group of size members.
+func (r *ServiceRouter) checkGroup(name string, opts GroupOptions, size int) error {
+	if _, exists := r.groups[name]; exists {
+		return fmt.Errorf("%w: %q", ErrGroupExists, name)
+	}
+
+	if size == 0 {
+		return fmt.Errorf("%w: %q", ErrEmptyGroup, name)
+	}
+
+	if opts.Mode < GroupFailover || opts.Mode > GroupQuorum {
+		return fmt.Errorf("%w: %v", ErrInvalidGroupMode, opts.Mode)
+	}
+
+	if opts.Quorum < 0 || opts.Quorum > size {
+		return fmt.Errorf("%w: %d of %d members", ErrInvalidQuorum, opts.Quorum, size)
+	}
+
+	return nil
+}
+
+// addGroup registers the group under name.
+func (r *ServiceRouter) addGroup(name string, group *routeGroup) {
+	if r.groups == nil {
+		r.groups = make(map[string]*routeGroup)
+	}
+
+	r.groups[name] = group
+}
+
+// Groups returns the names of the configured routing groups.
+//
+// Returns:
+//   - []string: the sorted group names.
+func (r *ServiceRouter) Groups() []string {
+	names := make([]string, 0, len(r.groups))
+	for name := range r.groups {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// SendGroup sends the specified message to the named routing group.
+//
+// Parameters:
+//   - name: the name of the group.
+//   - message: the message to send.
+//   - params: the parameters to apply.
+//
+// Returns:
+//   - GroupResult: the outcome of the delivery, including which members delivered.
+func (r *ServiceRouter) SendGroup(name, message string, params *types.Params) GroupResult {
+	if r == nil {
+		return GroupResult{Group: name, Err: ErrNoSenders}
+	}
+
+	return r.SendGroupContext(r.baseContext(), name, message, params)
+}
+
+// SendGroupContext sends the specified message to the named routing group,
+// aborting in-flight requests when ctx is done. The per-service Timeout applies to
+// every member delivery.
+//
+// Parameters:
+//   - ctx: the context bounding all deliveries.
+//   - name: the name of the group.
+//   - message: the message to send.
+//   - params: the parameters to apply.
+//
+// Returns:
+//   - GroupResult: the outcome of the delivery, including which members delivered.
+func (r *ServiceRouter) SendGroupContext(
+	ctx context.Context,
+	name, message string,
+	params *types.Params,
+) GroupResult {
+	if r == nil {
+		return GroupResult{Group: name, Err: ErrNoSenders}
+	}
+
+	group, ok := r.groups[name]
+	if !ok {
+		return GroupResult{Group: name, Err: fmt.Errorf("%w: %q", ErrUnknownGroup, name)}
+	}
+
+	if params == nil {
+		params = &types.Params{}
+	}
+
+	result := GroupResult{Group: name, Results: make([]types.DeliveryResult, len(group.services))}
+
+	if level, _ := params.Level(); !group.opts.Filter.Allows(level) {
+		for i, service := range group.services {
+			result.Results[i] = types.DeliveryResult{ServiceID: service.GetID(), URL: group.urls[i], Skipped: true}
+		}
+
+		result.Skipped = true
+
+		return result
+	}
+
+	switch group.opts.Mode {
+	case GroupFailover:
+		r.sendFailover(ctx, group, message, *params, result.Results)
+	case GroupFirstSuccess:
+		firstCtx, cancel := context.WithCancel(ctx)
+		r.sendConcurrent(firstCtx, group, message, *params, result.Results, cancel)
+		cancel()
+	case GroupQuorum:
+		r.sendConcurrent(ctx, group, message, *params, result.Results, nil)
+	}
+
+	var errs []error
+
+	for i, delivery := range result.Results {
+		if delivery.Err == nil {
+			result.DeliveredBy = append(result.DeliveredBy, i)
+		} else if !errors.Is(delivery.Err, ErrMemberSkipped) {
+			errs = append(errs, delivery.Err)
+		}
+	}
+
+	required := 1
+	if group.opts.Mode == GroupQuorum {
+		required = group.opts.Quorum
+		if required == 0 {
+			required = len(group.services)
+		}
+	}
+
+	if delivered := len(result.DeliveredBy); delivered < required {
+		result.Err = fmt.Errorf("%w %q: %d of %d members delivered, %d required: %w",
+			ErrGroupFailed, name, delivered, len(group.services), required, errors.Join(errs...))
+	}
+
+	return result
+}
+
+// sendFailover delivers the message to the members in order until one succeeds.
+// Remaining members, and members whose level filter excludes the message, are
+// marked as skipped.
+//
+// Parameters:
+//   - ctx: the base context for the operation.
+//   - group: the routing group.
+//   - message: the message to send.
+//   - params: the parameters to apply.
+//   - results: the slice to store the member results in.
+func (r *ServiceRouter) sendFailover(
+	ctx context.Context,
+	group *routeGroup,
+	message string,
+	params types.Params,
+	results []types.DeliveryResult,
+) {
+	delivered := false
+
+	for i, service := range group.services {
+		if delivered || ctx.Err() != nil || !group.allows(i, params) {
+			results[i] = skippedMember(service, group.urls[i])
+
+			continue
+		}
+
+		results[i] = r.reportDelivery(ctx, service, group.urls[i], message, params)
+		delivered = results[i].Err == nil
+
+		if !delivered {
+			r.log("Routing group member", service.GetID(), "failed, trying next member:", results[i].Err)
+		}
+	}
+}
+
+// sendConcurrent delivers the message to all members concurrently, skipping those
+// whose level filter excludes the message. If onSuccess is set, it is called after every successful delivery.
+//
+// Parameters:
+//   - ctx: the base context for the operation.
+//   - group: the routing group.
+//   - message: the message to send.
+//   - params: the parameters to apply.
+//   - results: the slice to store the member results in.
+//   - onSuccess: an optional function called when a member delivers.
+func (r *ServiceRouter) sendConcurrent(
+	ctx context.Context,
+	group *routeGroup,
+	message string,
+	params types.Params,
+	results []types.DeliveryResult,
+	onSuccess func(),
+) {
+	var wg sync.WaitGroup
+
+	for i, service := range group.services {
+		if !group.allows(i, params) {
+			results[i] = skippedMember(service, group.urls[i])
+
+			continue
+		}
+
+		wg.Go(func() {
+			results[i] = r.reportDelivery(ctx, service, group.urls[i], message, params)
+
+			if results[i].Err == nil && onSuccess != nil {
+				onSuccess()
+			}
+		})
+	}
+
+	wg.Wait()
+}
+
+// skippedMember returns the delivery result for a member that was not attempted.
+func skippedMember(service types.Service, url string) types.DeliveryResult {
+	return types.DeliveryResult{
+		ServiceID: service.GetID(),
+		URL:       url,
+		Err:       &types.TargetError{URL: url, Err: ErrMemberSkipped},
+	}
+}
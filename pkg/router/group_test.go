@@ -0,0 +1,252 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/nicholas-fedor/shoutrrr/pkg/types"
+)
+
+var errMemberDown = errors.New("member down")
+
+//nolint:paralleltest // Modifies shared serviceMap; cannot run in parallel.
+func TestSendGroupFailover(t *testing.T) {
+	primary := &flakyService{errs: []error{errMemberDown}}
+	secondary := &flakyService{}
+	tertiary := &flakyService{}
+
+	serviceMap["mock-primary"] = func() types.Service { return primary }
+	serviceMap["mock-secondary"] = func() types.Service { return secondary }
+	serviceMap["mock-tertiary"] = func() types.Service { return tertiary }
+
+	defer delete(serviceMap, "mock-primary")
+	defer delete(serviceMap, "mock-secondary")
+	defer delete(serviceMap, "mock-tertiary")
+
+	router, err := NewWithOptions(nil, types.SenderOptions{})
+	if err != nil {
+		t.Fatalf("NewWithOptions: %v", err)
+	}
+
+	err = router.AddGroup("escalation", GroupOptions{Mode: GroupFailover},
+		"mock-primary://", "mock-secondary://", "mock-tertiary://")
+	if err != nil {
+		t.Fatalf("AddGroup: %v", err)
+	}
+
+	result := router.SendGroup("escalation", "message", nil)
+	if result.Err != nil {
+		t.Fatalf("SendGroup error = %v, want nil", result.Err)
+	}
+
+	if !slices.Equal(result.DeliveredBy, []int{1}) {
+		t.Errorf("DeliveredBy = %v, want [1]", result.DeliveredBy)
+	}
+
+	if !errors.Is(result.Results[0].Err, errMemberDown) {
+		t.Errorf("primary error = %v, want %v", result.Results[0].Err, errMemberDown)
+	}
+
+	if !errors.Is(result.Results[2].Err, ErrMemberSkipped) {
+		t.Errorf("tertiary error = %v, want %v", result.Results[2].Err, ErrMemberSkipped)
+	}
+
+	if len(tertiary.attempts) != 0 {
+		t.Errorf("tertiary got %d attempts, want 0", len(tertiary.attempts))
+	}
+}
+
+//nolint:paralleltest // Modifies shared serviceMap; cannot run in parallel.
+func TestSendGroupFailoverAllFail(t *testing.T) {
+	serviceMap["mock-primary"] = func() types.Service { return &flakyService{errs: []error{errMemberDown}} }
+	defer delete(serviceMap, "mock-primary")
+
+	router := &ServiceRouter{Timeout: time.Second}
+
+	if err := router.AddGroup("escalation", GroupOptions{}, "mock-primary://", "mock-primary://"); err != nil {
+		t.Fatalf("AddGroup: %v", err)
+	}
+
+	result := router.SendGroup("escalation", "message", nil)
+	if !errors.Is(result.Err, ErrGroupFailed) || !errors.Is(result.Err, errMemberDown) {
+		t.Errorf("SendGroup error = %v, want %v wrapping %v", result.Err, ErrGroupFailed, errMemberDown)
+	}
+
+	if len(result.DeliveredBy) != 0 {
+		t.Errorf("DeliveredBy = %v, want none", result.DeliveredBy)
+	}
+}
+
+//nolint:paralleltest // Modifies shared serviceMap; cannot run in parallel.
+func TestSendGroupFirstSuccessCancelsOthers(t *testing.T) {
+	slow := &cancelAwareService{observed: make(chan error, 1)}
+
+	serviceMap["mock-cancel"] = func() types.Service { return slow }
+	serviceMap["mock-fast"] = func() types.Service { return &flakyService{} }
+
+	defer delete(serviceMap, "mock-cancel")
+	defer delete(serviceMap, "mock-fast")
+
+	router := &ServiceRouter{Timeout: time.Minute}
+
+	if err := router.AddGroup("race", GroupOptions{Mode: GroupFirstSuccess}, "mock-cancel://", "mock-fast://"); err != nil {
+		t.Fatalf("AddGroup: %v", err)
+	}
+
+	result := router.SendGroup("race", "message", nil)
+	if result.Err != nil {
+		t.Fatalf("SendGroup error = %v, want nil", result.Err)
+	}
+
+	if !slices.Equal(result.DeliveredBy, []int{1}) {
+		t.Errorf("DeliveredBy = %v, want [1]", result.DeliveredBy)
+	}
+
+	select {
+	case err := <-slow.observed:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("slow member observed %v, want %v", err, context.Canceled)
+		}
+	case <-time.After(time.Second):
+		t.Error("slow member was not canceled")
+	}
+}
+
+//nolint:paralleltest // Modifies shared serviceMap; cannot run in parallel.
+func TestSendGroupQuorum(t *testing.T) {
+	serviceMap["mock-ok"] = func() types.Service { return &flakyService{} }
+	serviceMap["mock-down"] = func() types.Service { return &flakyService{errs: []error{errMemberDown}} }
+
+	defer delete(serviceMap, "mock-ok")
+	defer delete(serviceMap, "mock-down")
+
+	router := &ServiceRouter{Timeout: time.Second}
+	members := []string{"mock-ok://", "mock-down://", "mock-ok://"}
+
+	if err := router.AddGroup("two", GroupOptions{Mode: GroupQuorum, Quorum: 2}, members...); err != nil {
+		t.Fatalf("AddGroup: %v", err)
+	}
+
+	if err := router.AddGroup("all", GroupOptions{Mode: GroupQuorum}, members...); err != nil {
+		t.Fatalf("AddGroup: %v", err)
+	}
+
+	if result := router.SendGroup("two", "message", nil); result.Err != nil {
+		t.Errorf("quorum of 2 error = %v, want nil", result.Err)
+	} else if !slices.Equal(result.DeliveredBy, []int{0, 2}) {
+		t.Errorf("DeliveredBy = %v, want [0 2]", result.DeliveredBy)
+	}
+
+	if result := router.SendGroup("all", "message", nil); !errors.Is(result.Err, ErrGroupFailed) {
+		t.Errorf("quorum of all error = %v, want %v", result.Err, ErrGroupFailed)
+	}
+}
+
+func TestAddGroupRejectsInvalidOptions(t *testing.T) {
+	t.Parallel()
+
+	router := &ServiceRouter{}
+
+	tests := []struct {
+		name string
+		opts GroupOptions
+		urls []string
+		want error
+	}{
+		{name: "no members", opts: GroupOptions{}, want: ErrEmptyGroup},
+		{name: "unknown mode", opts: GroupOptions{Mode: 42}, urls: []string{"logger://"}, want: ErrInvalidGroupMode},
+		{
+			name: "quorum too large",
+			opts: GroupOptions{Mode: GroupQuorum, Quorum: 2},
+			urls: []string{"logger://"},
+			want: ErrInvalidQuorum,
+		},
+		{name: "unknown service", opts: GroupOptions{}, urls: []string{"nope://"}, want: ErrUnknownService},
+	}
+
+	for _, tt := range tests {
+		if err := router.AddGroup(tt.name, tt.opts, tt.urls...); !errors.Is(err, tt.want) {
+			t.Errorf("%s: AddGroup error = %v, want %v", tt.name, err, tt.want)
+		}
+	}
+
+	if result := router.SendGroup("missing", "message", nil); !errors.Is(result.Err, ErrUnknownGroup) {
+		t.Errorf("SendGroup error = %v, want %v", result.Err, ErrUnknownGroup)
+	}
+}
+
+//nolint:paralleltest // Modifies shared serviceMap; cannot run in parallel.
+func TestAddTargetGroupSharesTargets(t *testing.T) {
+	created := 0
+
+	serviceMap["mock-flaky"] = func() types.Service {
+		created++
+
+		return &flakyService{}
+	}
+	defer delete(serviceMap, "mock-flaky")
+
+	router, err := NewWithOptions(nil, types.SenderOptions{})
+	if err != nil {
+		t.Fatalf("NewWithOptions: %v", err)
+	}
+
+	if err := router.AddServiceWithFilter("mock-flaky://", LevelFilter{MinLevel: types.Error}); err != nil {
+		t.Fatalf("AddServiceWithFilter: %v", err)
+	}
+
+	if err := router.AddService("mock-flaky://"); err != nil {
+		t.Fatalf("AddService: %v", err)
+	}
+
+	if err := router.AddTargetGroup("escalation", GroupOptions{Mode: GroupFailover}, 0, 1); err != nil {
+		t.Fatalf("AddTargetGroup: %v", err)
+	}
+
+	if created != 2 {
+		t.Errorf("created %d services, want the 2 targets only", created)
+	}
+
+	params := types.Params{}
+	params.SetLevel(types.Warning)
+
+	result := router.SendGroup("escalation", "message", &params)
+	if result.Err != nil || !slices.Equal(result.DeliveredBy, []int{1}) {
+		t.Errorf("SendGroup = %+v, want delivery by the second target only", result)
+	}
+
+	if !errors.Is(result.Results[0].Err, ErrMemberSkipped) {
+		t.Errorf("first member error = %v, want %v from its target filter", result.Results[0].Err, ErrMemberSkipped)
+	}
+
+	if len(router.allServices()) != 2 {
+		t.Errorf("allServices returned %d services, want the 2 shared targets", len(router.allServices()))
+	}
+
+	if err := router.AddTargetGroup("missing", GroupOptions{}, 2); !errors.Is(err, ErrUnknownTarget) {
+		t.Errorf("AddTargetGroup with unknown index = %v, want %v", err, ErrUnknownTarget)
+	}
+
+	err = router.AddTargetGroup("params", GroupOptions{Params: []types.Params{{"title": "x"}}}, 0)
+	if !errors.Is(err, ErrTargetGroupParams) {
+		t.Errorf("AddTargetGroup with params = %v, want %v", err, ErrTargetGroupParams)
+	}
+}
+
+func TestParseGroupMode(t *testing.T) {
+	t.Parallel()
+
+	for _, mode := range []GroupMode{GroupFailover, GroupFirstSuccess, GroupQuorum} {
+		parsed, err := ParseGroupMode(mode.String())
+		if err != nil || parsed != mode {
+			t.Errorf("ParseGroupMode(%q) = %v, %v; want %v", mode.String(), parsed, err, mode)
+		}
+	}
+
+	if _, err := ParseGroupMode("broadcast"); !errors.Is(err, ErrInvalidGroupMode) {
+		t.Errorf("ParseGroupMode error = %v, want %v", err, ErrInvalidGroupMode)
+	}
+}
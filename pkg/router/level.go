@@ -0,0 +1,93 @@
+package router
+
+import (
+	"slices"
+
+	"github.com/nicholas-fedor/shoutrrr/pkg/types"
+)
+
+// LevelFilter restricts the message levels the router delivers to a target.
+// Messages without a level are treated as types.Info. The zero value allows all levels.
+type LevelFilter struct {
+	// MinLevel is the lowest level delivered to the target.
+	MinLevel types.MessageLevel
+	// Levels, if not empty, additionally restricts delivery to the listed levels.
+	Levels []types.MessageLevel
+}
+
+// Allows reports whether a message of the given level passes the filter.
+//
+// Parameters:
+//   - level: the message level.
+//
+// Returns:
+//   - bool: true if the message should be delivered.
+func (f LevelFilter) Allows(level types.MessageLevel) bool {
+	if level == types.Unknown {
+		level = types.Info
+	}
+
+	if level < f.MinLevel {
+		return false
+	}
+
+	return len(f.Levels) == 0 || slices.Contains(f.Levels, level)
+}
+
+// AddServiceWithFilter initializes the specified service from its URL and adds it
+// with a level filter, so that only messages passing the filter are delivered to it.
+//
+// Parameters:
+//   - serviceURL: the service URL to initialize and add.
+//   - filter: the level filter for the service.
+//
+// Returns:
+//   - error: an error if initialization fails.
+func (r *ServiceRouter) AddServiceWithFilter(serviceURL string, filter LevelFilter) error {
+	return r.AddServiceWithOptions(serviceURL, TargetOptions{Filter: filter})
+}
+
+// allowsMessage reports whether the message with params passes the filter of the
+// service at index.
+func (r *ServiceRouter) allowsMessage(index int, params types.Params) bool {
+	if index >= len(r.filters) {
+		return true
+	}
+
+	level, _ := params.Level()
+
+	return r.filters[index].Allows(level)
+}
+
+// filterItems returns the items passing the filter of the service at index. Items
+// without a level use the level from params.
+//
+// Parameters:
+//   - index: the index of the service.
+//   - items: the message items to filter.
+//   - params: the parameters of the message.
+//
+// Returns:
+//   - []types.MessageItem: the items to deliver; empty if none pass.
+func (r *ServiceRouter) filterItems(index int, items []types.MessageItem, params types.Params) []types.MessageItem {
+	if index >= len(r.filters) {
+		return items
+	}
+
+	fallback, _ := params.Level()
+
+	filtered := make([]types.MessageItem, 0, len(items))
+
+	for _, item := range items {
+		level := item.Level
+		if level == types.Unknown {
+			level = fallback
+		}
+
+		if r.filters[index].Allows(level) {
+			filtered = append(filtered, item)
+		}
+	}
+
+	return filtered
+}
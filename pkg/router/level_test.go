@@ -0,0 +1,194 @@
+package router
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/nicholas-fedor/shoutrrr/pkg/types"
+)
+
+func TestLevelFilterAllows(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		filter LevelFilter
+		level  types.MessageLevel
+		want   bool
+	}{
+		{name: "zero filter allows debug", level: types.Debug, want: true},
+		{name: "below minimum", filter: LevelFilter{MinLevel: types.Warning}, level: types.Info, want: false},
+		{name: "at minimum", filter: LevelFilter{MinLevel: types.Warning}, level: types.Warning, want: true},
+		{name: "unknown is info", filter: LevelFilter{MinLevel: types.Info}, level: types.Unknown, want: true},
+		{
+			name:   "not in level set",
+			filter: LevelFilter{Levels: []types.MessageLevel{types.Debug, types.Error}},
+			level:  types.Warning,
+			want:   false,
+		},
+		{
+			name:   "in level set",
+			filter: LevelFilter{Levels: []types.MessageLevel{types.Debug, types.Error}},
+			level:  types.Error,
+			want:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		if got := tt.filter.Allows(tt.level); got != tt.want {
+			t.Errorf("%s: Allows(%v) = %v, want %v", tt.name, tt.level, got, tt.want)
+		}
+	}
+}
+
+//nolint:paralleltest // Modifies shared serviceMap; cannot run in parallel.
+func TestSendRoutesByLevel(t *testing.T) {
+	chat := &recordingService{}
+	pager := &recordingService{}
+
+	serviceMap["mock-chat"] = func() types.Service { return chat }
+	serviceMap["mock-pager"] = func() types.Service { return pager }
+
+	defer delete(serviceMap, "mock-chat")
+	defer delete(serviceMap, "mock-pager")
+
+	router, err := NewWithOptions(nil, types.SenderOptions{})
+	if err != nil {
+		t.Fatalf("NewWithOptions: %v", err)
+	}
+
+	if err := router.AddServiceWithFilter("mock-chat://", LevelFilter{MinLevel: types.Debug}); err != nil {
+		t.Fatalf("AddServiceWithFilter: %v", err)
+	}
+
+	if err := router.AddServiceWithFilter("mock-pager://", LevelFilter{MinLevel: types.Error}); err != nil {
+		t.Fatalf("AddServiceWithFilter: %v", err)
+	}
+
+	for _, level := range []types.MessageLevel{types.Debug, types.Info, types.Error} {
+		params := types.Params{}
+		params.SetLevel(level)
+
+		for _, err := range router.Send(level.String(), &params) {
+			if err != nil {
+				t.Fatalf("Send: %v", err)
+			}
+		}
+	}
+
+	if len(chat.messages) != 3 {
+		t.Errorf("chat got %v, want all messages", chat.messages)
+	}
+
+	if len(pager.messages) != 1 || pager.messages[0] != "Error" {
+		t.Errorf("pager got %v, want only the error", pager.messages)
+	}
+
+	params := types.Params{}
+	params.SetLevel(types.Info)
+
+	results := router.SendWithReport("info", &params)
+	if results[0].Skipped || !results[1].Skipped {
+		t.Errorf("report skipped = [%v %v], want [false true]", results[0].Skipped, results[1].Skipped)
+	}
+}
+
+// apiTransport answers every request with an empty JSON object, counting them.
+type apiTransport struct {
+	mu       sync.Mutex
+	requests int
+}
+
+func (tr *apiTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	tr.mu.Lock()
+	tr.requests++
+	tr.mu.Unlock()
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       io.NopCloser(strings.NewReader("{}")),
+		Request:    req,
+	}, nil
+}
+
+func TestSendPassesLevelToConfiguredServices(t *testing.T) {
+	t.Parallel()
+
+	transport := &apiTransport{}
+
+	router, err := NewWithOptions(nil, types.SenderOptions{
+		HTTPClient: &http.Client{Transport: transport},
+	}, "ntfy://ntfy.sh/alerts")
+	if err != nil {
+		t.Fatalf("NewWithOptions: %v", err)
+	}
+
+	if errs := router.Send("disk full", &types.Params{types.LevelKey: "error"}); errs[0] != nil {
+		t.Fatalf("Send: %v", errs[0])
+	}
+
+	if transport.requests != 1 {
+		t.Errorf("requests = %d, want 1", transport.requests)
+	}
+}
+
+//nolint:paralleltest // Modifies shared serviceMap; cannot run in parallel.
+func TestSendItemsFiltersItemsByLevel(t *testing.T) {
+	chat := &recordingService{}
+	pager := &recordingService{}
+
+	serviceMap["mock-chat"] = func() types.Service { return chat }
+	serviceMap["mock-pager"] = func() types.Service { return pager }
+
+	defer delete(serviceMap, "mock-chat")
+	defer delete(serviceMap, "mock-pager")
+
+	router, err := NewWithOptions(nil, types.SenderOptions{}, "mock-chat://")
+	if err != nil {
+		t.Fatalf("NewWithOptions: %v", err)
+	}
+
+	if err := router.AddServiceWithFilter("mock-pager://", LevelFilter{MinLevel: types.Error}); err != nil {
+		t.Fatalf("AddServiceWithFilter: %v", err)
+	}
+
+	items := []types.MessageItem{
+		{Text: "starting", Level: types.Debug},
+		{Text: "disk full", Level: types.Error},
+		{Text: "inherits level"},
+	}
+
+	params := types.Params{}
+	params.SetLevel(types.Info)
+
+	for _, err := range router.SendItems(items, params) {
+		if err != nil {
+			t.Fatalf("SendItems: %v", err)
+		}
+	}
+
+	if len(chat.items) != 3 {
+		t.Errorf("chat got %d items, want 3", len(chat.items))
+	}
+
+	if len(pager.items) != 1 || pager.items[0].Text != "disk full" {
+		t.Errorf("pager got %+v, want only the error item", pager.items)
+	}
+
+	pager.items = nil
+
+	debugOnly := []types.MessageItem{{Text: "noise", Level: types.Debug}}
+	for _, err := range router.SendItems(debugOnly, types.Params{}) {
+		if err != nil {
+			t.Fatalf("SendItems: %v", err)
+		}
+	}
+
+	if len(pager.items) != 0 {
+		t.Errorf("pager got %+v, want no delivery", pager.items)
+	}
+}
@@ -0,0 +1,121 @@
+package router
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/nicholas-fedor/shoutrrr/pkg/types"
+)
+
+// levelMapNone is the ParamLevelMap value disabling level mapping.
+const levelMapNone = "none"
+
+// parseLevelMap parses a ParamLevelMap value, a comma-separated list of
+// level:value entries such as "warning:4,error:5".
+//
+// Parameters:
+//   - value: the param value.
+//
+// Returns:
+//   - map[types.MessageLevel]string: the mapped values by level.
+//   - error: an error if an entry has no value or an invalid level.
+func parseLevelMap(value string) (map[types.MessageLevel]string, error) {
+	levelMap := make(map[types.MessageLevel]string)
+
+	for entry := range strings.SplitSeq(value, ",") {
+		name, mapped, found := strings.Cut(strings.TrimSpace(entry), ":")
+
+		level, ok := types.ParseMessageLevel(strings.TrimSpace(name))
+		if !found || !ok || level == types.Unknown || strings.TrimSpace(mapped) == "" {
+			return nil, fmt.Errorf("%s entries must be level:value pairs, got %q", ParamLevelMap, entry)
+		}
+
+		levelMap[level] = strings.TrimSpace(mapped)
+	}
+
+	return levelMap, nil
+}
+
+// checkLevelMap validates the ParamLevelMap of a service URL against its service,
+// and disables level mapping if the URL sets the mapped param itself.
+//
+// Parameters:
+//   - service: the service of the URL.
+//   - serviceURL: the service URL, without router params.
+//
+// Returns:
+//   - error: an error wrapping ErrInvalidRouterParam if the service has no level mapping.
+func (o *urlOverrides) checkLevelMap(service types.Service, serviceURL *url.URL) error {
+	mapper, ok := service.(types.LevelMapper)
+	if !ok {
+		if o.levelMap != nil {
+			return fmt.Errorf("%w: %s is not supported by the %s service",
+				ErrInvalidRouterParam, ParamLevelMap, serviceURL.Scheme)
+		}
+
+		return nil
+	}
+
+	if o.levelMap != nil || o.noLevelMap {
+		return nil
+	}
+
+	param := mapper.LevelMapping().Param
+	for key := range serviceURL.Query() {
+		if strings.EqualFold(key, param) {
+			o.noLevelMap = true
+		}
+	}
+
+	return nil
+}
+
+// applyLevelMapping sets the native priority param of the delivery's service from
+// the message level, unless the param is already set. Messages without a level are
+// left to the service's configured priority.
+//
+// Parameters:
+//   - delivery: the delivery to update.
+func (r *ServiceRouter) applyLevelMapping(delivery *types.Delivery) {
+	mapper, ok := delivery.Service.(types.LevelMapper)
+	if !ok {
+		return
+	}
+
+	overrides, _ := r.urlOverrides(delivery.Service)
+	if overrides.noLevelMap {
+		return
+	}
+
+	mapping := mapper.LevelMapping()
+	if _, set := delivery.Params[mapping.Param]; set {
+		return
+	}
+
+	level := deliveryLevel(delivery)
+
+	value, ok := overrides.levelMap[level]
+	if !ok {
+		value, ok = mapping.Value(level)
+	}
+
+	if ok {
+		delivery.Params[mapping.Param] = value
+	}
+}
+
+// deliveryLevel returns the level of the delivery: its level param, or else the
+// highest level of its items.
+func deliveryLevel(delivery *types.Delivery) types.MessageLevel {
+	if level, ok := delivery.Params.Level(); ok && level != types.Unknown {
+		return level
+	}
+
+	level := types.Unknown
+	for _, item := range delivery.Items {
+		level = max(level, item.Level)
+	}
+
+	return level
+}
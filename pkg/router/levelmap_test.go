@@ -0,0 +1,127 @@
+package router
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/nicholas-fedor/shoutrrr/pkg/types"
+)
+
+// priorityService is a recording service with a native priority param.
+type priorityService struct {
+	recordingService
+}
+
+func (s *priorityService) LevelMapping() types.LevelMapping {
+	return types.LevelMapping{
+		Param: "priority",
+		Values: map[types.MessageLevel]string{
+			types.Debug:   "low",
+			types.Info:    "normal",
+			types.Warning: "medium",
+			types.Error:   "high",
+		},
+	}
+}
+
+// priorities returns the priority param of every send, "-" where it was not set.
+func (s *priorityService) priorities() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	priorities := make([]string, len(s.params))
+	for i, params := range s.params {
+		priority, ok := params["priority"]
+		if !ok {
+			priority = "-"
+		}
+
+		priorities[i] = priority
+	}
+
+	return priorities
+}
+
+//nolint:paralleltest // Modifies shared serviceMap; cannot run in parallel.
+func TestLevelMappingSetsNativePriority(t *testing.T) {
+	svc := &priorityService{}
+
+	serviceMap["mock-priority"] = func() types.Service { return svc }
+	defer delete(serviceMap, "mock-priority")
+
+	router, err := NewWithOptions(nil, types.SenderOptions{}, "mock-priority://host")
+	if err != nil {
+		t.Fatalf("NewWithOptions: %v", err)
+	}
+
+	router.Send("error", &types.Params{"level": "error"})
+	router.Send("debug", &types.Params{"level": "debug"})
+	router.Send("no level", nil)
+	router.Send("explicit", &types.Params{"level": "error", "priority": "custom"})
+	router.SendItems([]types.MessageItem{
+		{Text: "warning", Level: types.Warning},
+		{Text: "info", Level: types.Info},
+	}, nil)
+
+	want := []string{"high", "low", "-", "custom", "medium"}
+	if got := svc.priorities(); !slices.Equal(got, want) {
+		t.Errorf("priorities = %q, want %q", got, want)
+	}
+}
+
+//nolint:paralleltest // Modifies shared serviceMap; cannot run in parallel.
+func TestLevelMappingURLOverrides(t *testing.T) {
+	for _, test := range []struct {
+		query string
+		want  []string
+	}{
+		{query: "__levelmap=error:urgent", want: []string{"urgent", "medium"}},
+		{query: "__LevelMap=None", want: []string{"-", "-"}},
+		{query: "priority=5", want: []string{"-", "-"}},
+		{query: "priority=5&__levelmap=warning:4", want: []string{"high", "4"}},
+	} {
+		t.Run(test.query, func(t *testing.T) {
+			svc := &priorityService{}
+
+			serviceMap["mock-priority"] = func() types.Service { return svc }
+			defer delete(serviceMap, "mock-priority")
+
+			router, err := NewWithOptions(nil, types.SenderOptions{}, "mock-priority://host?"+test.query)
+			if err != nil {
+				t.Fatalf("NewWithOptions: %v", err)
+			}
+
+			router.Send("error", &types.Params{"level": "error"})
+			router.Send("warning", &types.Params{"level": "warning"})
+
+			if got := svc.priorities(); !slices.Equal(got, test.want) {
+				t.Errorf("priorities = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+//nolint:paralleltest // Modifies shared serviceMap; cannot run in parallel.
+func TestLevelMapRejectsInvalidValues(t *testing.T) {
+	serviceMap["mock-priority"] = func() types.Service { return &priorityService{} }
+	defer delete(serviceMap, "mock-priority")
+
+	serviceMap["mock-url"] = func() types.Service { return &urlService{} }
+	defer delete(serviceMap, "mock-url")
+
+	for _, serviceURL := range []string{
+		"mock-priority://host?__levelmap=error",
+		"mock-priority://host?__levelmap=error:",
+		"mock-priority://host?__levelmap=loud:5",
+		"mock-priority://host?__levelmap=unknown:5",
+		"mock-url://host?__levelmap=error:5",
+	} {
+		t.Run(serviceURL, func(t *testing.T) {
+			_, err := NewWithOptions(nil, types.SenderOptions{}, serviceURL)
+			if !errors.Is(err, ErrInvalidRouterParam) {
+				t.Errorf("NewWithOptions error = %v, want %v", err, ErrInvalidRouterParam)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package router
+
+import (
+	"errors"
+	"time"
+
+	"github.com/nicholas-fedor/shoutrrr/pkg/types"
+)
+
+// SetMetrics sets the metrics hook receiving an observation of every delivery
+// made by the router. Passing nil disables metrics.
+//
+// Parameters:
+//   - metrics: the metrics hook to use.
+func (r *ServiceRouter) SetMetrics(metrics types.Metrics) {
+	r.metrics = metrics
+}
+
+// observe reports a completed delivery to the metrics hook, if any.
+//
+// Parameters:
+//   - delivery: the completed delivery.
+//   - duration: the time spent delivering.
+//   - attempts: the number of send attempts made.
+//   - err: the delivery error, or nil on success.
+func (r *ServiceRouter) observe(delivery *types.Delivery, duration time.Duration, attempts int, err error) {
+	if r.metrics == nil {
+		return
+	}
+
+	r.metrics.ObserveDelivery(types.DeliveryObservation{
+		Scheme:   delivery.Service.GetID(),
+		Duration: duration,
+		Attempts: attempts,
+		Bytes:    deliveryBytes(delivery),
+		Err:      err,
+		Class:    types.ClassifyError(err),
+	})
+}
+
+// classifyError returns the class of a delivery error, recognizing the errors
+// of the router itself before falling back to types.ClassifyError.
+func classifyError(err error) types.ErrorClass {
+	switch {
+	case errors.Is(err, ErrServiceTimeout):
+		return types.ErrorClassTimeout
+	case errors.Is(err, ErrCircuitOpen):
+		return types.ErrorClassCircuitOpen
+	case errors.Is(err, ErrMessageTooLong):
+		return types.ErrorClassClient
+	default:
+		return types.ClassifyError(err)
+	}
+}
+
+// deliveryBytes returns the size of the message text of the delivery.
+func deliveryBytes(delivery *types.Delivery) int {
+	if delivery.Items == nil {
+		return len(delivery.Message)
+	}
+
+	size := 0
+	for _, item := range delivery.Items {
+		size += len(item.Text)
+	}
+
+	return size
+}
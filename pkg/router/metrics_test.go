@@ -0,0 +1,106 @@
+package router
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/nicholas-fedor/shoutrrr/pkg/types"
+)
+
+// observations is a metrics hook recording every observation.
+type observations struct {
+	mu   sync.Mutex
+	list []types.DeliveryObservation
+}
+
+func (o *observations) ObserveDelivery(observation types.DeliveryObservation) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.list = append(o.list, observation)
+}
+
+//nolint:paralleltest // Modifies shared serviceMap; cannot run in parallel.
+func TestSendReportsMetrics(t *testing.T) {
+	svc := &flakyService{errs: []error{
+		&statusError{status: 503},
+		nil,
+		&statusError{status: 401},
+	}}
+
+	serviceMap["mock-flaky"] = func() types.Service { return svc }
+	defer delete(serviceMap, "mock-flaky")
+
+	synctest.Test(t, func(t *testing.T) {
+		hook := &observations{}
+
+		router, err := NewWithOptions(nil, types.SenderOptions{
+			Retry:   types.RetryPolicy{MaxAttempts: 3, BaseBackoff: time.Second},
+			Metrics: hook,
+		}, "mock-flaky://")
+		if err != nil {
+			t.Fatalf("NewWithOptions: %v", err)
+		}
+
+		if errs := router.Send("deployed", nil); errs[0] != nil {
+			t.Fatalf("first Send: %v", errs[0])
+		}
+
+		errs := router.Send("failed", nil)
+
+		var targetErr *types.TargetError
+		if !errors.As(errs[0], &targetErr) || targetErr.Class != types.ErrorClassAuth {
+			t.Fatalf("second Send = %#v, want a TargetError of class %q", errs[0], types.ErrorClassAuth)
+		}
+
+		if len(hook.list) != 2 {
+			t.Fatalf("got %d observations, want 2", len(hook.list))
+		}
+
+		sent, failed := hook.list[0], hook.list[1]
+
+		if sent.Scheme != "mock-flaky" || sent.Attempts != 2 || sent.Retries() != 1 ||
+			sent.Bytes != len("deployed") || sent.Duration != time.Second || sent.Err != nil || sent.Class != "" {
+			t.Errorf("first observation = %+v, want one retry of a successful delivery", sent)
+		}
+
+		if failed.Attempts != 1 || failed.Err != errs[0] || failed.Class != types.ErrorClassAuth {
+			t.Errorf("second observation = %+v, want a single failed attempt", failed)
+		}
+	})
+}
+
+//nolint:paralleltest // Modifies shared serviceMap; cannot run in parallel.
+func TestSendClassifiesRouterErrors(t *testing.T) {
+	svc := &flakyService{errs: []error{errConnectionRefused}}
+
+	serviceMap["mock-flaky"] = func() types.Service { return svc }
+	defer delete(serviceMap, "mock-flaky")
+
+	hook := &observations{}
+
+	router, err := NewWithOptions(nil, types.SenderOptions{
+		CircuitBreaker: types.CircuitBreakerPolicy{Threshold: 1},
+		Metrics:        hook,
+	}, "mock-flaky://")
+	if err != nil {
+		t.Fatalf("NewWithOptions: %v", err)
+	}
+
+	router.Send("down", nil)
+	router.Send("still down", nil)
+
+	want := []types.ErrorClass{types.ErrorClassOther, types.ErrorClassCircuitOpen}
+	for i, observation := range hook.list {
+		if observation.Class != want[i] {
+			t.Errorf("observation %d class = %q, want %q", i, observation.Class, want[i])
+		}
+	}
+
+	if hook.list[1].Attempts != 0 {
+		t.Errorf("attempts with an open circuit = %d, want 0", hook.list[1].Attempts)
+	}
+}
@@ -0,0 +1,13 @@
+package router
+
+import "github.com/nicholas-fedor/shoutrrr/pkg/types"
+
+// Use appends middleware to the chain run around every delivery made by the router.
+// Middleware runs in the order it was added; the first middleware is the outermost.
+// It applies to Send, SendItems, Flush, SendWithReport and SendGroup deliveries.
+//
+// Parameters:
+//   - middleware: the middleware to append.
+func (r *ServiceRouter) Use(middleware ...types.Middleware) {
+	r.middleware = append(r.middleware, middleware...)
+}
@@ -0,0 +1,119 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/nicholas-fedor/shoutrrr/pkg/types"
+)
+
+var errMaintenance = errors.New("maintenance window")
+
+//nolint:paralleltest // Modifies shared serviceMap; cannot run in parallel.
+func TestMiddlewareRunsInOrder(t *testing.T) {
+	svc := &recordingService{}
+
+	serviceMap["mock-params"] = func() types.Service { return svc }
+	defer delete(serviceMap, "mock-params")
+
+	var calls []string
+
+	trace := func(name string) types.Middleware {
+		return func(next types.DeliverFunc) types.DeliverFunc {
+			return func(ctx context.Context, delivery *types.Delivery) error {
+				calls = append(calls, name+" before")
+				err := next(ctx, delivery)
+				calls = append(calls, name+" after")
+
+				return err
+			}
+		}
+	}
+
+	enrich := func(next types.DeliverFunc) types.DeliverFunc {
+		return func(ctx context.Context, delivery *types.Delivery) error {
+			delivery.Params["host"] = "web-1"
+			delivery.Message = strings.ReplaceAll(delivery.Message, "alice@example.com", "[email]")
+
+			return next(ctx, delivery)
+		}
+	}
+
+	opts := types.SenderOptions{Middleware: []types.Middleware{trace("options")}}
+
+	router, err := NewWithOptions(nil, opts, "mock-params://")
+	if err != nil {
+		t.Fatalf("NewWithOptions: %v", err)
+	}
+
+	router.Use(trace("use"), enrich)
+
+	params := types.Params{"title": "login"}
+	if errs := router.Send("login by alice@example.com", &params); errs[0] != nil {
+		t.Fatalf("Send: %v", errs[0])
+	}
+
+	want := []string{"options before", "use before", "use after", "options after"}
+	if !slices.Equal(calls, want) {
+		t.Errorf("calls = %v, want %v", calls, want)
+	}
+
+	if svc.messages[0] != "login by [email]" {
+		t.Errorf("message = %q, want the scrubbed message", svc.messages[0])
+	}
+
+	if svc.params[0]["host"] != "web-1" {
+		t.Errorf("params = %v, want the enriched params", svc.params[0])
+	}
+
+	if _, ok := params["host"]; ok {
+		t.Errorf("middleware modified the caller's params: %v", params)
+	}
+}
+
+//nolint:paralleltest // Modifies shared serviceMap; cannot run in parallel.
+func TestMiddlewareCanVetoDeliveries(t *testing.T) {
+	svc := &recordingService{}
+
+	serviceMap["mock-recording"] = func() types.Service { return svc }
+	defer delete(serviceMap, "mock-recording")
+
+	router, err := NewWithOptions(nil, types.SenderOptions{}, "mock-recording://")
+	if err != nil {
+		t.Fatalf("NewWithOptions: %v", err)
+	}
+
+	var results []error
+
+	router.Use(
+		func(next types.DeliverFunc) types.DeliverFunc {
+			return func(ctx context.Context, delivery *types.Delivery) error {
+				err := next(ctx, delivery)
+				results = append(results, err)
+
+				return err
+			}
+		},
+		func(types.DeliverFunc) types.DeliverFunc {
+			return func(context.Context, *types.Delivery) error { return errMaintenance }
+		},
+	)
+
+	errs := router.SendItems([]types.MessageItem{{Text: "deploy"}}, types.Params{})
+
+	var targetErr *types.TargetError
+	if !errors.As(errs[0], &targetErr) || !errors.Is(errs[0], errMaintenance) {
+		t.Errorf("SendItems error = %v, want a TargetError wrapping %v", errs[0], errMaintenance)
+	}
+
+	if len(svc.items) != 0 {
+		t.Errorf("service got %+v, want no delivery", svc.items)
+	}
+
+	if len(results) != 1 || !errors.Is(results[0], errMaintenance) {
+		t.Errorf("outer middleware saw %v, want the veto error", results)
+	}
+}
@@ -0,0 +1,142 @@
+package router
+
+import (
+	"sync"
+
+	"github.com/nicholas-fedor/shoutrrr/pkg/outbox"
+	"github.com/nicholas-fedor/shoutrrr/pkg/types"
+)
+
+// SetOutbox sets the durable outbox used to journal deliveries made by Flush,
+// and replays its pending entries addressed to the services of the router, so
+// that deliveries left over from a previous run are retried on start. Add the
+// services before setting the outbox. Passing nil disables journaling.
+//
+// Parameters:
+//   - box: the outbox to use.
+//
+// Returns:
+//   - []error: the errors of the replayed entries, as returned by ReplayOutbox.
+func (r *ServiceRouter) SetOutbox(box *outbox.Outbox) []error {
+	r.outbox = box
+
+	return r.ReplayOutbox()
+}
+
+// ReplayOutbox retries all pending outbox entries addressed to services of this router.
+// Entries for other targets are left untouched. Successful deliveries are removed from
+// the outbox; failures are recorded and kept until they succeed or expire.
+//
+// Returns:
+//   - []error: one error per replayed entry, wrapped in *types.TargetError; nil for successes.
+func (r *ServiceRouter) ReplayOutbox() []error {
+	if r == nil || r.outbox == nil {
+		return nil
+	}
+
+	var deliveries []delivery
+
+	for _, entry := range r.outbox.Pending() {
+		if index := r.targetIndex(entry.Target); index >= 0 {
+			deliveries = append(deliveries, delivery{service: r.services[index], entry: entry})
+		}
+	}
+
+	return r.deliverAll(deliveries)
+}
+
+// delivery pairs an outbox entry with the service it is delivered through.
+type delivery struct {
+	service types.Service
+	entry   outbox.Entry
+}
+
+// flushToOutbox journals the message for every service, delivers it and records the outcome.
+// Services whose entry could not be journaled are still sent to, without tracking.
+//
+// Parameters:
+//   - message: the combined message to deliver.
+//   - params: the parameters to apply.
+//
+// Returns:
+//   - []error: one error per delivery, nil for successes.
+func (r *ServiceRouter) flushToOutbox(message string, params *types.Params) []error {
+	if params == nil {
+		params = &types.Params{}
+	}
+
+	if r.suppress(message, *params, r.sendRepeatSummary(message)) {
+		r.log("Suppressed repeated message")
+
+		return nil
+	}
+
+	deliveries := make([]delivery, 0, len(r.services))
+
+	for i, service := range r.services {
+		if !r.allowsMessage(i, *params) {
+			continue
+		}
+
+		entry, err := r.outbox.Add(r.targets[i], service.GetID(), message, *params)
+		if err != nil {
+			r.log("Failed to journal delivery to", service.GetID()+":", err)
+
+			entry = outbox.Entry{Message: message, Params: *params}
+		}
+
+		deliveries = append(deliveries, delivery{service: service, entry: entry})
+	}
+
+	return r.deliverAll(deliveries)
+}
+
+// deliverAll concurrently delivers the entries and records each outcome in the outbox.
+// Entries without an ID were not journaled and are only sent.
+//
+// Parameters:
+//   - deliveries: the deliveries to perform.
+//
+// Returns:
+//   - []error: one error per delivery, in the same order.
+func (r *ServiceRouter) deliverAll(deliveries []delivery) []error {
+	errs := make([]error, len(deliveries))
+
+	var wg sync.WaitGroup
+
+	for i, d := range deliveries {
+		wg.Go(func() {
+			params := d.entry.Params
+			if params == nil {
+				params = types.Params{}
+			}
+
+			result := make(chan error, 1)
+			r.sendToService(r.baseContext(), d.service, result, d.entry.Message, params)
+			errs[i] = <-result
+
+			if d.entry.ID == "" {
+				return
+			}
+
+			if err := r.outbox.RecordAttempt(d.entry.ID, errs[i]); err != nil {
+				r.log("Failed to record delivery to", d.service.GetID()+":", err)
+			}
+		})
+	}
+
+	wg.Wait()
+
+	return errs
+}
+
+// targetIndex returns the index of the service with the given target key, or -1.
+func (r *ServiceRouter) targetIndex(target string) int {
+	for i, key := range r.targets {
+		if key == target {
+			return i
+		}
+	}
+
+	return -1
+}
@@ -0,0 +1,107 @@
+package router
+
+import (
+	"bytes"
+	"log"
+	"strings"
+	"testing"
+
+	"github.com/nicholas-fedor/shoutrrr/pkg/outbox"
+	"github.com/nicholas-fedor/shoutrrr/pkg/types"
+)
+
+//nolint:paralleltest // Modifies shared serviceMap; cannot run in parallel.
+func TestFlushJournalsFailedDeliveriesForReplay(t *testing.T) {
+	svc := &flakyService{errs: []error{&statusError{status: 503}}}
+
+	serviceMap["mock-flaky"] = func() types.Service { return svc }
+	defer delete(serviceMap, "mock-flaky")
+
+	dir := t.TempDir()
+
+	box, err := outbox.Open(dir, outbox.Options{})
+	if err != nil {
+		t.Fatalf("outbox.Open: %v", err)
+	}
+
+	var logs bytes.Buffer
+
+	router, err := NewWithOptions(log.New(&logs, "", 0), types.SenderOptions{}, "mock-flaky://")
+	if err != nil {
+		t.Fatalf("NewWithOptions: %v", err)
+	}
+
+	router.SetOutbox(box)
+	router.Enqueue("first")
+	router.Enqueue("second")
+	router.Flush(nil)
+
+	if !strings.Contains(logs.String(), "Failed to flush queued messages") {
+		t.Errorf("logs = %q, want the failed delivery logged", logs.String())
+	}
+
+	if err := box.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	// Simulate a process restart with a fresh outbox and router.
+	box, err = outbox.Open(dir, outbox.Options{})
+	if err != nil {
+		t.Fatalf("outbox.Open: %v", err)
+	}
+	defer box.Close()
+
+	pending := box.Pending()
+	if len(pending) != 1 || pending[0].Message != "first\nsecond" {
+		t.Fatalf("pending entries = %+v, want the flushed message", pending)
+	}
+
+	router, err = NewWithOptions(nil, types.SenderOptions{}, "mock-flaky://")
+	if err != nil {
+		t.Fatalf("NewWithOptions: %v", err)
+	}
+
+	errs := router.SetOutbox(box)
+	if len(errs) != 1 || errs[0] != nil {
+		t.Fatalf("SetOutbox = %v, want the pending entry replayed", errs)
+	}
+
+	if len(svc.attempts) != 2 {
+		t.Errorf("service got %d attempts, want 2", len(svc.attempts))
+	}
+
+	if pending := box.Pending(); len(pending) != 0 {
+		t.Errorf("pending entries after replay = %+v, want none", pending)
+	}
+}
+
+//nolint:paralleltest // Modifies shared serviceMap; cannot run in parallel.
+func TestReplayOutboxSkipsOtherTargets(t *testing.T) {
+	svc := &flakyService{}
+
+	serviceMap["mock-flaky"] = func() types.Service { return svc }
+	defer delete(serviceMap, "mock-flaky")
+
+	box, err := outbox.Open(t.TempDir(), outbox.Options{})
+	if err != nil {
+		t.Fatalf("outbox.Open: %v", err)
+	}
+	defer box.Close()
+
+	if _, err := box.Add(outbox.TargetKey("mock-flaky://other"), "mock-flaky", "message", nil); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	router, err := NewWithOptions(nil, types.SenderOptions{}, "mock-flaky://")
+	if err != nil {
+		t.Fatalf("NewWithOptions: %v", err)
+	}
+
+	if errs := router.SetOutbox(box); len(errs) != 0 {
+		t.Errorf("SetOutbox = %v, want no deliveries", errs)
+	}
+
+	if len(box.Pending()) != 1 {
+		t.Errorf("entry for another target was removed")
+	}
+}
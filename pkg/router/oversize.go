@@ -0,0 +1,233 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/nicholas-fedor/shoutrrr/pkg/format"
+	"github.com/nicholas-fedor/shoutrrr/pkg/types"
+	"github.com/nicholas-fedor/shoutrrr/pkg/util"
+)
+
+// partSuffix numbers the parts of a split message.
+const partSuffix = "\n(%d/%d)"
+
+var (
+	// ErrMessageTooLong is returned for a message longer than the MessageLimit of a
+	// service under the OversizeReject policy.
+	ErrMessageTooLong = errors.New("message exceeds the limit of the service")
+	// ErrUnknownOversizePolicy is returned for an OversizePolicy that is not
+	// none, split, truncate or reject.
+	ErrUnknownOversizePolicy = errors.New("unknown oversize policy")
+)
+
+// SetOversizePolicy sets how the router handles messages longer than the
+// MessageLimit of a service. The zero value passes them to the service as is.
+// The ParamOversize URL param overrides the policy for a target.
+//
+// Parameters:
+//   - policy: the policy to use.
+//
+// Returns:
+//   - error: an error wrapping ErrUnknownOversizePolicy if policy is unknown.
+func (r *ServiceRouter) SetOversizePolicy(policy types.OversizePolicy) error {
+	policy, err := parseOversizePolicy(string(policy))
+	if err != nil {
+		return err
+	}
+
+	r.oversize = policy
+
+	return nil
+}
+
+// parseOversizePolicy parses the name of an OversizePolicy, case-insensitively.
+// An empty name is the zero policy.
+func parseOversizePolicy(name string) (types.OversizePolicy, error) {
+	switch policy := types.OversizePolicy(strings.ToLower(strings.TrimSpace(name))); policy {
+	case "", types.OversizeNone, types.OversizeSplit, types.OversizeTruncate, types.OversizeReject:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnknownOversizePolicy, name)
+	}
+}
+
+// fitMessage applies the oversize policy of the delivery's service to its message.
+// Item deliveries are left to the service, which partitions them itself. The
+// length of a message is measured as the service renders it, see renderedLength.
+//
+// Parameters:
+//   - delivery: the delivery to fit.
+//
+// Returns:
+//   - []string: the messages to send in order, nil for item deliveries.
+//   - error: an error wrapping ErrMessageTooLong if the policy rejects the message
+//     or no part fits once rendered.
+func (r *ServiceRouter) fitMessage(delivery *types.Delivery) ([]string, error) {
+	if delivery.Items != nil {
+		return nil, nil
+	}
+
+	message := delivery.Message
+
+	policy := r.oversize
+	if overrides, _ := r.urlOverrides(delivery.Service); overrides.oversize != "" {
+		policy = overrides.oversize
+	}
+
+	limit := messageLimit(delivery.Service)
+	if policy == "" || policy == types.OversizeNone || limit <= 0 {
+		return []string{message}, nil
+	}
+
+	measure := renderedLength(delivery.Service, delivery.Params)
+
+	length := measure(message)
+	if length <= limit {
+		return []string{message}, nil
+	}
+
+	switch policy {
+	case types.OversizeSplit:
+		return shrinkToFit(limit, measure, func(budget int) []string {
+			return splitNumbered(message, budget)
+		})
+	case types.OversizeTruncate:
+		return shrinkToFit(limit, measure, func(budget int) []string {
+			return []string{util.TruncateMessage(message, budget)}
+		})
+	default:
+		return nil, fmt.Errorf("%w: %d characters, the limit is %d", ErrMessageTooLong, length, limit)
+	}
+}
+
+// renderedLength returns a function measuring the number of runes of a message
+// once the service renders it. Services implementing types.MarkupRenderer render
+// a body declaring its format themselves, which may lengthen it, e.g. by escaping
+// "<" as "&lt;" for Telegram.
+//
+// Parameters:
+//   - service: the service the message is sent to.
+//   - params: the parameters of the delivery.
+//
+// Returns:
+//   - func(string) int: the measuring function.
+func renderedLength(service types.Service, params types.Params) func(string) int {
+	renderer, ok := service.(types.MarkupRenderer)
+	if _, found := params[types.FormatKey]; !ok || !found {
+		return utf8.RuneCountInString
+	}
+
+	return func(message string) int {
+		rendered, _, err := format.RenderBody(message, &params, renderer.Markup())
+		if err != nil {
+			// The service fails to render it too.
+			return utf8.RuneCountInString(message)
+		}
+
+		return utf8.RuneCountInString(rendered)
+	}
+}
+
+// shrinkToFit fits a message within limit once rendered, shrinking the budget it
+// is fitted to by the excess of the longest part until every part fits.
+//
+// Parameters:
+//   - limit: the maximum number of runes of a rendered part.
+//   - measure: the function measuring a rendered part.
+//   - fit: the function fitting the message to a budget, returning its parts.
+//
+// Returns:
+//   - []string: the parts.
+//   - error: an error wrapping ErrMessageTooLong if no budget fits.
+func shrinkToFit(limit int, measure func(string) int, fit func(budget int) []string) ([]string, error) {
+	for budget := limit; budget > 0; {
+		parts := fit(budget)
+
+		longest := 0
+		for _, part := range parts {
+			longest = max(longest, measure(part))
+		}
+
+		if longest <= limit {
+			return parts, nil
+		}
+
+		budget -= longest - limit
+	}
+
+	return nil, fmt.Errorf("%w: no part fits the limit of %d characters once rendered",
+		ErrMessageTooLong, limit)
+}
+
+// messageLimit returns the maximum number of runes of a single notification to
+// service, or 0 if the service declares no limit.
+func messageLimit(service types.Service) int {
+	limiter, ok := service.(types.MessageLimiter)
+	if !ok {
+		return 0
+	}
+
+	return limiter.MessageLimit().TotalChunkSize
+}
+
+// splitNumbered splits message into parts of at most limit runes, each ending
+// with its number, e.g. "(1/3)".
+//
+// Parameters:
+//   - message: the message to split.
+//   - limit: the maximum number of runes per part.
+//
+// Returns:
+//   - []string: the numbered parts.
+func splitNumbered(message string, limit int) []string {
+	// Leave room for the widest number, retrying with wider numbers while there
+	// are more parts than fit.
+	for maxParts := 9; ; maxParts = maxParts*10 + 9 {
+		suffixLen := len(fmt.Sprintf(partSuffix, maxParts, maxParts))
+
+		parts := util.SplitMessage(message, max(limit-suffixLen, 1))
+		if len(parts) > maxParts {
+			continue
+		}
+
+		for i, part := range parts {
+			parts[i] = part + fmt.Sprintf(partSuffix, i+1, len(parts))
+		}
+
+		return parts
+	}
+}
+
+// partsSendFunc returns a function sending the parts of a split message in order,
+// each throttled as a request of its own. A retry resumes from the first part not
+// yet delivered.
+//
+// Parameters:
+//   - service: the service to send to.
+//   - parts: the parts to send.
+//   - params: the parameters to apply.
+//
+// Returns:
+//   - sendFunc: the attempt function.
+func (r *ServiceRouter) partsSendFunc(service types.Service, parts []string, params types.Params) sendFunc {
+	sends := make([]sendFunc, len(parts))
+	for i, part := range parts {
+		sends[i] = r.throttle.wrap(service, messageSendFunc(service, part, params))
+	}
+
+	next := 0
+
+	return func(ctx context.Context) error {
+		for ; next < len(sends); next++ {
+			if err := sends[next](ctx); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
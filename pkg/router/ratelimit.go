@@ -0,0 +1,190 @@
+package router
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/nicholas-fedor/shoutrrr/pkg/types"
+)
+
+// throttle holds the rate limiters and the in-flight cap of a router.
+type throttle struct {
+	overrides map[string]types.RateLimit
+	hosts     map[string]*tokenBucket
+	slots     chan struct{}
+	mu        sync.Mutex
+	targets   map[types.Service]*tokenBucket
+}
+
+// tokenBucket is a token bucket rate limiter. Waiters reserve a token, letting the
+// balance go negative, and sleep until it would have been refilled.
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// newThrottle returns the throttle configured by the rate limit options.
+//
+// Parameters:
+//   - opts: the sender options holding the rate limits and the in-flight cap.
+//
+// Returns:
+//   - *throttle: the throttle.
+func newThrottle(opts types.SenderOptions) *throttle {
+	t := &throttle{
+		overrides: opts.RateLimits,
+		hosts:     make(map[string]*tokenBucket, len(opts.HostRateLimits)),
+		targets:   make(map[types.Service]*tokenBucket),
+	}
+
+	for host, limit := range opts.HostRateLimits {
+		if bucket := newTokenBucket(limit); bucket != nil {
+			t.hosts[strings.ToLower(host)] = bucket
+		}
+	}
+
+	if opts.MaxConcurrent > 0 {
+		t.slots = make(chan struct{}, opts.MaxConcurrent)
+	}
+
+	return t
+}
+
+// wrap returns send throttled by the rate limit of service and the in-flight cap.
+// The attempt itself consumes one token of the target; every HTTP request after
+// the first one within the attempt, announced through types.WaitRateLimit,
+// consumes another. Requests also consume a token of their host's limit, if any.
+//
+// Parameters:
+//   - service: the target service.
+//   - send: the attempt function.
+//
+// Returns:
+//   - sendFunc: the throttled attempt function.
+func (t *throttle) wrap(service types.Service, send sendFunc) sendFunc {
+	if t == nil {
+		return send
+	}
+
+	target := t.target(service)
+
+	return func(ctx context.Context) error {
+		if err := target.wait(ctx); err != nil {
+			return err
+		}
+
+		if t.slots != nil {
+			select {
+			case t.slots <- struct{}{}:
+				defer func() { <-t.slots }()
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		var requested atomic.Bool
+
+		return send(types.WithRateLimitWait(ctx, func(ctx context.Context, host string) error {
+			if requested.Swap(true) {
+				if err := target.wait(ctx); err != nil {
+					return err
+				}
+			}
+
+			return t.hosts[strings.ToLower(host)].wait(ctx)
+		}))
+	}
+}
+
+// target returns the token bucket of service, or nil if it is not rate limited.
+// The limit is the RateLimits override for the service's scheme, if any, or else
+// the default the service declares by implementing types.RateLimiter.
+func (t *throttle) target(service types.Service) *tokenBucket {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if bucket, found := t.targets[service]; found {
+		return bucket
+	}
+
+	limit, found := t.overrides[service.GetID()]
+	if !found {
+		if limiter, ok := service.(types.RateLimiter); ok {
+			limit = limiter.RateLimit()
+		}
+	}
+
+	bucket := newTokenBucket(limit)
+	t.targets[service] = bucket
+
+	return bucket
+}
+
+// newTokenBucket returns a full token bucket enforcing limit, or nil if limit is
+// not enabled.
+func newTokenBucket(limit types.RateLimit) *tokenBucket {
+	if !limit.Enabled() {
+		return nil
+	}
+
+	burst := limit.Burst
+	if burst <= 0 {
+		burst = limit.Requests
+	}
+
+	return &tokenBucket{
+		rate:   float64(limit.Requests) / limit.Interval.Seconds(),
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// wait takes a token, blocking until one is available or ctx is done. A nil
+// bucket never blocks.
+//
+// Parameters:
+//   - ctx: the context bounding the wait.
+//
+// Returns:
+//   - error: the context error if ctx is done first.
+func (b *tokenBucket) wait(ctx context.Context) error {
+	if b == nil {
+		return nil
+	}
+
+	b.mu.Lock()
+
+	now := time.Now()
+	b.tokens = min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+	b.tokens--
+
+	delay := time.Duration(-b.tokens / b.rate * float64(time.Second))
+
+	b.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		b.mu.Lock()
+		b.tokens++
+		b.mu.Unlock()
+
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,174 @@
+package router
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/nicholas-fedor/shoutrrr/pkg/types"
+)
+
+// chunkedService is a test ContextSender that sends every message as several
+// requests to api.example.com, like a service splitting messages into chunks.
+type chunkedService struct {
+	recordingService
+
+	chunks   int
+	limit    types.RateLimit
+	inFlight atomic.Int32
+	peak     atomic.Int32
+	release  chan struct{}
+}
+
+func (s *chunkedService) GetID() string {
+	return "mock-chunked"
+}
+
+func (s *chunkedService) RateLimit() types.RateLimit {
+	return s.limit
+}
+
+func (s *chunkedService) SendContext(ctx context.Context, message string, params *types.Params) error {
+	inFlight := s.inFlight.Add(1)
+	defer s.inFlight.Add(-1)
+
+	for peak := s.peak.Load(); inFlight > peak && !s.peak.CompareAndSwap(peak, inFlight); {
+		peak = s.peak.Load()
+	}
+
+	if s.release != nil {
+		<-s.release
+	}
+
+	for range s.chunks {
+		if err := types.WaitRateLimit(ctx, "API.example.com"); err != nil {
+			return err
+		}
+	}
+
+	return s.Send(message, params)
+}
+
+//nolint:paralleltest // Modifies shared serviceMap; cannot run in parallel.
+func TestRateLimitThrottlesEveryRequest(t *testing.T) {
+	svc := &chunkedService{chunks: 10, limit: types.RateLimit{Requests: 5, Interval: 2 * time.Second}}
+
+	serviceMap["mock-chunked"] = func() types.Service { return svc }
+	defer delete(serviceMap, "mock-chunked")
+
+	synctest.Test(t, func(t *testing.T) {
+		router, err := NewWithOptions(nil, types.SenderOptions{}, "mock-chunked://")
+		if err != nil {
+			t.Fatalf("NewWithOptions: %v", err)
+		}
+
+		start := time.Now()
+
+		if errs := router.Send("hello", nil); errs[0] != nil {
+			t.Fatalf("Send: %v", errs[0])
+		}
+
+		// The first 5 requests use the burst; the other 5 wait 400ms each.
+		if elapsed := time.Since(start); elapsed != 2*time.Second {
+			t.Errorf("sending 10 requests took %v, want 2s", elapsed)
+		}
+	})
+}
+
+//nolint:paralleltest // Modifies shared serviceMap; cannot run in parallel.
+func TestRateLimitOverrides(t *testing.T) {
+	svc := &chunkedService{chunks: 10, limit: types.RateLimit{Requests: 1, Interval: time.Second}}
+
+	serviceMap["mock-chunked"] = func() types.Service { return svc }
+	defer delete(serviceMap, "mock-chunked")
+
+	synctest.Test(t, func(t *testing.T) {
+		router, err := NewWithOptions(nil, types.SenderOptions{
+			RateLimits: map[string]types.RateLimit{"mock-chunked": {}},
+		}, "mock-chunked://")
+		if err != nil {
+			t.Fatalf("NewWithOptions: %v", err)
+		}
+
+		start := time.Now()
+
+		router.Send("hello", nil)
+
+		if elapsed := time.Since(start); elapsed != 0 {
+			t.Errorf("disabled rate limit delayed the send by %v", elapsed)
+		}
+	})
+}
+
+//nolint:paralleltest // Modifies shared serviceMap; cannot run in parallel.
+func TestHostRateLimitIsShared(t *testing.T) {
+	svc := &chunkedService{chunks: 1}
+
+	serviceMap["mock-chunked"] = func() types.Service { return svc }
+	defer delete(serviceMap, "mock-chunked")
+
+	synctest.Test(t, func(t *testing.T) {
+		router, err := NewWithOptions(nil, types.SenderOptions{
+			HostRateLimits: map[string]types.RateLimit{
+				"api.example.com": {Requests: 1, Interval: time.Second},
+			},
+		}, "mock-chunked://", "mock-chunked://", "mock-chunked://")
+		if err != nil {
+			t.Fatalf("NewWithOptions: %v", err)
+		}
+
+		start := time.Now()
+
+		for _, err := range router.Send("hello", nil) {
+			if err != nil {
+				t.Fatalf("Send: %v", err)
+			}
+		}
+
+		if elapsed := time.Since(start); elapsed != 2*time.Second {
+			t.Errorf("sending to 3 targets on one host took %v, want 2s", elapsed)
+		}
+	})
+}
+
+//nolint:paralleltest // Modifies shared serviceMap; cannot run in parallel.
+func TestMaxConcurrentCapsInFlightSends(t *testing.T) {
+	svc := &chunkedService{release: make(chan struct{})}
+
+	serviceMap["mock-chunked"] = func() types.Service { return svc }
+	defer delete(serviceMap, "mock-chunked")
+
+	router, err := NewWithOptions(nil, types.SenderOptions{MaxConcurrent: 2})
+	if err != nil {
+		t.Fatalf("NewWithOptions: %v", err)
+	}
+
+	for range 6 {
+		if err := router.AddService("mock-chunked://"); err != nil {
+			t.Fatalf("AddService: %v", err)
+		}
+	}
+
+	var wg sync.WaitGroup
+
+	wg.Go(func() {
+		for range 6 {
+			svc.release <- struct{}{}
+		}
+	})
+
+	for _, err := range router.Send("hello", nil) {
+		if err != nil {
+			t.Fatalf("Send: %v", err)
+		}
+	}
+
+	wg.Wait()
+
+	if peak := svc.peak.Load(); peak > 2 {
+		t.Errorf("peak in-flight sends = %d, want at most 2", peak)
+	}
+}
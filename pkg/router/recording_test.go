@@ -0,0 +1,75 @@
+package router
+
+import (
+	"maps"
+	"net/url"
+	"slices"
+	"sync"
+	"text/template"
+
+	"github.com/nicholas-fedor/shoutrrr/pkg/types"
+)
+
+// recordingService is a test RichSender that records the messages, items and
+// params it receives. Test services with extra capabilities embed it.
+type recordingService struct {
+	mu       sync.Mutex
+	messages []string
+	items    []types.MessageItem
+	params   []types.Params
+}
+
+func (s *recordingService) GetID() string {
+	return "mock-recording"
+}
+
+func (s *recordingService) GetTemplate(_ string) (*template.Template, bool) {
+	return nil, false
+}
+
+func (s *recordingService) Initialize(_ *url.URL, _ types.StdLogger) error {
+	return nil
+}
+
+func (s *recordingService) Send(message string, params *types.Params) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.messages = append(s.messages, message)
+
+	if params != nil {
+		s.params = append(s.params, maps.Clone(*params))
+	} else {
+		s.params = append(s.params, types.Params{})
+	}
+
+	return nil
+}
+
+func (s *recordingService) SendItems(items []types.MessageItem, params types.Params) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.items = append(s.items, items...)
+	s.params = append(s.params, maps.Clone(params))
+
+	return nil
+}
+
+// sent returns a copy of the messages received so far.
+func (s *recordingService) sent() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return slices.Clone(s.messages)
+}
+
+func (s *recordingService) SetLogger(_ types.StdLogger) {}
+
+func (s *recordingService) SetTemplateFile(_, _ string) error {
+	return nil
+}
+
+func (s *recordingService) SetTemplateString(_, _ string) error {
+	return nil
+}
@@ -0,0 +1,142 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/nicholas-fedor/shoutrrr/pkg/types"
+)
+
+// SendWithReport sends the specified message using the routers underlying services
+// and reports the outcome of every delivery.
+//
+// Parameters:
+//   - message: the message to send.
+//   - params: the parameters to apply.
+//
+// Returns:
+//   - []types.DeliveryResult: one result per service, in the same order as the configured URLs.
+func (r *ServiceRouter) SendWithReport(message string, params *types.Params) []types.DeliveryResult {
+	if r == nil {
+		return []types.DeliveryResult{{Err: ErrNoSenders}}
+	}
+
+	return r.SendWithReportContext(r.baseContext(), message, params)
+}
+
+// SendWithReportContext sends the specified message using the routers underlying services,
+// aborting in-flight requests when ctx is done, and reports the outcome of every delivery.
+//
+// Parameters:
+//   - ctx: the context bounding all deliveries.
+//   - message: the message to send.
+//   - params: the parameters to apply.
+//
+// Returns:
+//   - []types.DeliveryResult: one result per service, in the same order as the configured URLs.
+func (r *ServiceRouter) SendWithReportContext(
+	ctx context.Context,
+	message string,
+	params *types.Params,
+) []types.DeliveryResult {
+	if r == nil {
+		return []types.DeliveryResult{{Err: ErrNoSenders}}
+	}
+
+	if params == nil {
+		params = &types.Params{}
+	}
+
+	results := make([]types.DeliveryResult, len(r.services))
+	suppressed := r.suppress(message, *params, r.sendRepeatSummary(message))
+
+	var wg sync.WaitGroup
+
+	for i, service := range r.services {
+		if !r.allowsMessage(i, *params) {
+			results[i] = types.DeliveryResult{ServiceID: service.GetID(), URL: r.redactedURL(i), Skipped: true}
+
+			continue
+		}
+
+		if suppressed {
+			results[i] = types.DeliveryResult{ServiceID: service.GetID(), URL: r.redactedURL(i), Suppressed: true}
+
+			continue
+		}
+
+		wg.Go(func() {
+			results[i] = r.reportDelivery(ctx, service, r.redactedURL(i), message, *params)
+		})
+	}
+
+	wg.Wait()
+
+	return results
+}
+
+// redactedURL returns the redacted URL of the service at index, or "" if unknown.
+func (r *ServiceRouter) redactedURL(index int) string {
+	if index < len(r.urls) {
+		return r.urls[index]
+	}
+
+	return ""
+}
+
+// reportDelivery sends the message to service through the middleware chain and
+// collects the delivery details.
+//
+// Parameters:
+//   - ctx: the base context for the operation.
+//   - service: the service being delivered to.
+//   - url: the redacted service URL.
+//   - message: the message to send.
+//   - params: the parameters to apply.
+//
+// Returns:
+//   - types.DeliveryResult: the outcome of the delivery.
+func (r *ServiceRouter) reportDelivery(
+	ctx context.Context,
+	service types.Service,
+	url string,
+	message string,
+	params types.Params,
+) types.DeliveryResult {
+	receipt := &types.DeliveryReceipt{}
+
+	var attempts atomic.Int64
+
+	counted := func(send sendFunc) sendFunc {
+		return func(ctx context.Context) error {
+			attempts.Add(1)
+
+			return send(types.WithDeliveryReceipt(ctx, receipt))
+		}
+	}
+
+	start := time.Now()
+	delivery := &types.Delivery{Service: service, Message: message, Params: maps.Clone(params)}
+	err := r.deliver(ctx, delivery, counted)
+	result := types.DeliveryResult{
+		ServiceID:  service.GetID(),
+		URL:        url,
+		Duration:   time.Since(start),
+		Attempts:   int(attempts.Load()),
+		HTTPStatus: receipt.HTTPStatus(),
+		BrokerAck:  receipt.BrokerAck(),
+		MessageID:  receipt.MessageID(),
+		Err:        err,
+	}
+
+	var statusErr types.HTTPStatusError
+	if result.HTTPStatus == 0 && errors.As(err, &statusErr) {
+		result.HTTPStatus = statusErr.HTTPStatus()
+	}
+
+	return result
+}
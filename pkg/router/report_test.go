@@ -0,0 +1,93 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+	"text/template"
+
+	"github.com/nicholas-fedor/shoutrrr/pkg/types"
+)
+
+// receiptService is a test ContextSender that reports delivery details.
+type receiptService struct{}
+
+func (s *receiptService) GetID() string {
+	return "mock-receipt"
+}
+
+func (s *receiptService) GetTemplate(_ string) (*template.Template, bool) {
+	return nil, false
+}
+
+func (s *receiptService) Initialize(_ *url.URL, _ types.StdLogger) error {
+	return nil
+}
+
+func (s *receiptService) Send(message string, params *types.Params) error {
+	return s.SendContext(context.Background(), message, params)
+}
+
+func (s *receiptService) SendContext(ctx context.Context, _ string, _ *types.Params) error {
+	types.ReportHTTPStatus(ctx, 201)
+	types.ReportMessageID(ctx, "msg-42")
+
+	return nil
+}
+
+func (s *receiptService) SetLogger(_ types.StdLogger) {}
+
+func (s *receiptService) SetTemplateFile(_, _ string) error {
+	return nil
+}
+
+func (s *receiptService) SetTemplateString(_, _ string) error {
+	return nil
+}
+
+//nolint:paralleltest // Modifies shared serviceMap; cannot run in parallel.
+func TestSendWithReportReturnsResultPerTarget(t *testing.T) {
+	flaky := &flakyService{errs: []error{&statusError{status: 503}, &statusError{status: 401}}}
+
+	serviceMap["mock-flaky"] = func() types.Service { return flaky }
+	serviceMap["mock-receipt"] = func() types.Service { return &receiptService{} }
+
+	defer delete(serviceMap, "mock-flaky")
+	defer delete(serviceMap, "mock-receipt")
+
+	router, err := NewWithOptions(nil, types.SenderOptions{
+		Retry: types.RetryPolicy{MaxAttempts: 3},
+	}, "mock-flaky://secret-token@example.com/path", "mock-receipt://")
+	if err != nil {
+		t.Fatalf("NewWithOptions: %v", err)
+	}
+
+	results := router.SendWithReport("test", nil)
+	if len(results) != 2 {
+		t.Fatalf("got %d results, want 2", len(results))
+	}
+
+	failed := results[0]
+	if failed.ServiceID != "mock-flaky" || failed.URL != "mock-flaky://REDACTED@example.com/REDACTED" {
+		t.Errorf("failed target = %q %q, want mock-flaky with redacted URL", failed.ServiceID, failed.URL)
+	}
+
+	if failed.Attempts != 2 || failed.HTTPStatus != 401 {
+		t.Errorf("failed target attempts/status = %d/%d, want 2/401", failed.Attempts, failed.HTTPStatus)
+	}
+
+	var targetErr *types.TargetError
+	if !errors.As(failed.Err, &targetErr) {
+		t.Errorf("failed target error is not *types.TargetError: %v", failed.Err)
+	}
+
+	delivered := results[1]
+	if delivered.Err != nil || delivered.Attempts != 1 {
+		t.Errorf("delivered target err/attempts = %v/%d, want nil/1", delivered.Err, delivered.Attempts)
+	}
+
+	if delivered.HTTPStatus != 201 || delivered.MessageID != "msg-42" {
+		t.Errorf("delivered target status/id = %d/%q, want 201/msg-42", delivered.HTTPStatus, delivered.MessageID)
+	}
+}
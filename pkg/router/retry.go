@@ -0,0 +1,96 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand/v2"
+	"time"
+
+	"github.com/nicholas-fedor/shoutrrr/pkg/types"
+)
+
+// sendFunc performs a single delivery attempt using the given context.
+type sendFunc func(ctx context.Context) error
+
+// sendWithRetry invokes send until it succeeds, the retry policy gives up, or ctx is done.
+//
+// Waits between attempts honor server-provided delays (see types.RetryAfterError)
+// and never extend past the deadline of ctx; if the next attempt could not start
+// before the deadline, the last error is returned right away.
+//
+// Parameters:
+//   - ctx: the context bounding all attempts.
+//   - policy: the retry policy to apply.
+//   - send: the function performing a single attempt.
+//
+// Returns:
+//   - error: nil on success, otherwise the error of the last attempt.
+func sendWithRetry(ctx context.Context, policy types.RetryPolicy, send sendFunc) error {
+	maxAttempts := max(policy.MaxAttempts, 1)
+
+	for attempt := 1; ; attempt++ {
+		err := send(ctx)
+		if err == nil || attempt >= maxAttempts || !policy.IsRetryable(err) {
+			return err
+		}
+
+		delay := retryDelay(policy, attempt, err)
+
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) <= delay {
+			return err
+		}
+
+		timer := time.NewTimer(delay)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+// retryDelay returns how long to wait after the given number of failed attempts.
+//
+// A delay requested by the server takes precedence over the exponential backoff.
+//
+// Parameters:
+//   - policy: the retry policy to apply.
+//   - failures: the number of attempts that have failed so far (>= 1).
+//   - err: the error of the last attempt.
+//
+// Returns:
+//   - time.Duration: the delay before the next attempt.
+func retryDelay(policy types.RetryPolicy, failures int, err error) time.Duration {
+	var retryAfterErr types.RetryAfterError
+	if errors.As(err, &retryAfterErr) {
+		if delay, ok := retryAfterErr.RetryAfter(); ok {
+			return delay
+		}
+	}
+
+	maxDelay := time.Duration(math.MaxInt64)
+	if policy.MaxBackoff > 0 {
+		maxDelay = policy.MaxBackoff
+	}
+
+	delay := min(max(policy.BaseBackoff, 0), maxDelay)
+	for i := 1; i < failures; i++ {
+		if delay > maxDelay/2 {
+			delay = maxDelay
+
+			break
+		}
+
+		delay *= 2
+	}
+
+	if jitter := min(max(policy.Jitter, 0), 1); jitter > 0 && delay > 0 {
+		delay -= time.Duration(rand.Float64() * jitter * float64(delay)) //nolint:gosec // Jitter does not need a secure source.
+	}
+
+	return delay
+}
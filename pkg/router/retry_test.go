@@ -0,0 +1,307 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"testing/synctest"
+	"text/template"
+	"time"
+
+	"github.com/nicholas-fedor/shoutrrr/pkg/types"
+)
+
+// statusError is a test error carrying an HTTP status and an optional retry delay.
+type statusError struct {
+	status     int
+	retryAfter time.Duration
+}
+
+func (e *statusError) Error() string {
+	return "status error"
+}
+
+func (e *statusError) HTTPStatus() int {
+	return e.status
+}
+
+func (e *statusError) RetryAfter() (time.Duration, bool) {
+	return e.retryAfter, e.retryAfter > 0
+}
+
+// flakyService is a test service that returns the queued errors in order and
+// records the time of every attempt.
+type flakyService struct {
+	errs     []error
+	attempts []time.Time
+}
+
+func (s *flakyService) GetID() string {
+	return "mock-flaky"
+}
+
+func (s *flakyService) GetTemplate(_ string) (*template.Template, bool) {
+	return nil, false
+}
+
+func (s *flakyService) Initialize(_ *url.URL, _ types.StdLogger) error {
+	return nil
+}
+
+func (s *flakyService) Send(_ string, _ *types.Params) error {
+	s.attempts = append(s.attempts, time.Now())
+
+	if len(s.errs) == 0 {
+		return nil
+	}
+
+	err := s.errs[0]
+	s.errs = s.errs[1:]
+
+	return err
+}
+
+func (s *flakyService) SetLogger(_ types.StdLogger) {}
+
+func (s *flakyService) SetTemplateFile(_, _ string) error {
+	return nil
+}
+
+func (s *flakyService) SetTemplateString(_, _ string) error {
+	return nil
+}
+
+//nolint:paralleltest // Modifies shared serviceMap; cannot run in parallel.
+func TestSendRetriesTransientErrors(t *testing.T) {
+	svc := &flakyService{errs: []error{
+		&statusError{status: 503},
+		&statusError{status: 502},
+	}}
+
+	serviceMap["mock-flaky"] = func() types.Service { return svc }
+	defer delete(serviceMap, "mock-flaky")
+
+	synctest.Test(t, func(t *testing.T) {
+		router, err := NewWithOptions(nil, types.SenderOptions{
+			Retry: types.RetryPolicy{MaxAttempts: 3, BaseBackoff: time.Second},
+		}, "mock-flaky://")
+		if err != nil {
+			t.Fatalf("NewWithOptions: %v", err)
+		}
+
+		errs := router.Send("test", nil)
+		if errs[0] != nil {
+			t.Fatalf("Send returned error: %v", errs[0])
+		}
+
+		if len(svc.attempts) != 3 {
+			t.Fatalf("service got %d attempts, want 3", len(svc.attempts))
+		}
+
+		if gap := svc.attempts[2].Sub(svc.attempts[1]); gap != 2*time.Second {
+			t.Errorf("second backoff = %v, want %v", gap, 2*time.Second)
+		}
+	})
+}
+
+// statusTransport answers requests with the given statuses in turn, then with 200 OK.
+type statusTransport struct {
+	mu       sync.Mutex
+	statuses []int
+	requests int
+}
+
+func (tr *statusTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	status := http.StatusOK
+	if tr.requests < len(tr.statuses) {
+		status = tr.statuses[tr.requests]
+	}
+
+	tr.requests++
+
+	return &http.Response{
+		StatusCode: status,
+		Status:     fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(`{"status":1}`)),
+		Request:    req,
+	}, nil
+}
+
+func TestSendRetriesServiceStatusErrors(t *testing.T) {
+	t.Parallel()
+
+	transport := &statusTransport{statuses: []int{http.StatusServiceUnavailable}}
+
+	router, err := NewWithOptions(nil, types.SenderOptions{
+		HTTPClient: &http.Client{Transport: transport},
+		Retry:      types.RetryPolicy{MaxAttempts: 2, BaseBackoff: time.Millisecond},
+	}, "pushover://:apptoken@usertoken")
+	if err != nil {
+		t.Fatalf("NewWithOptions: %v", err)
+	}
+
+	if errs := router.Send("test", nil); errs[0] != nil {
+		t.Fatalf("Send returned error: %v", errs[0])
+	}
+
+	if transport.requests != 2 {
+		t.Errorf("requests = %d, want 2", transport.requests)
+	}
+}
+
+//nolint:paralleltest // Modifies shared serviceMap; cannot run in parallel.
+func TestSendDoesNotRetryPermanentErrors(t *testing.T) {
+	svc := &flakyService{errs: []error{&statusError{status: 401}}}
+
+	serviceMap["mock-flaky"] = func() types.Service { return svc }
+	defer delete(serviceMap, "mock-flaky")
+
+	router, err := NewWithOptions(nil, types.SenderOptions{
+		Retry: types.RetryPolicy{MaxAttempts: 3},
+	}, "mock-flaky://")
+	if err != nil {
+		t.Fatalf("NewWithOptions: %v", err)
+	}
+
+	errs := router.Send("test", nil)
+
+	var targetErr *types.TargetError
+	if !errors.As(errs[0], &targetErr) {
+		t.Fatalf("Send error is not *types.TargetError: %T", errs[0])
+	}
+
+	if len(svc.attempts) != 1 {
+		t.Errorf("service got %d attempts, want 1", len(svc.attempts))
+	}
+}
+
+//nolint:paralleltest // Modifies shared serviceMap; cannot run in parallel.
+func TestSendHonorsRetryAfter(t *testing.T) {
+	svc := &flakyService{errs: []error{&statusError{status: 429, retryAfter: 3 * time.Second}}}
+
+	serviceMap["mock-flaky"] = func() types.Service { return svc }
+	defer delete(serviceMap, "mock-flaky")
+
+	synctest.Test(t, func(t *testing.T) {
+		router, err := NewWithOptions(nil, types.SenderOptions{
+			Retry: types.RetryPolicy{MaxAttempts: 2, BaseBackoff: time.Millisecond},
+		}, "mock-flaky://")
+		if err != nil {
+			t.Fatalf("NewWithOptions: %v", err)
+		}
+
+		errs := router.Send("test", nil)
+		if errs[0] != nil {
+			t.Fatalf("Send returned error: %v", errs[0])
+		}
+
+		if gap := svc.attempts[1].Sub(svc.attempts[0]); gap != 3*time.Second {
+			t.Errorf("retry delay = %v, want %v", gap, 3*time.Second)
+		}
+	})
+}
+
+//nolint:paralleltest // Modifies shared serviceMap; cannot run in parallel.
+func TestSendRetriesStayWithinTimeout(t *testing.T) {
+	svc := &flakyService{errs: []error{&statusError{status: 429, retryAfter: time.Minute}}}
+
+	serviceMap["mock-flaky"] = func() types.Service { return svc }
+	defer delete(serviceMap, "mock-flaky")
+
+	synctest.Test(t, func(t *testing.T) {
+		router, err := NewWithOptions(nil, types.SenderOptions{
+			Timeout: 5 * time.Second,
+			Retry:   types.RetryPolicy{MaxAttempts: 3},
+		}, "mock-flaky://")
+		if err != nil {
+			t.Fatalf("NewWithOptions: %v", err)
+		}
+
+		start := time.Now()
+		errs := router.Send("test", nil)
+
+		var statusErr *statusError
+		if !errors.As(errs[0], &statusErr) {
+			t.Fatalf("Send error = %v, want the last attempt's error", errs[0])
+		}
+
+		if elapsed := time.Since(start); elapsed != 0 {
+			t.Errorf("Send waited %v for a retry that could not fit in the timeout", elapsed)
+		}
+
+		if len(svc.attempts) != 1 {
+			t.Errorf("service got %d attempts, want 1", len(svc.attempts))
+		}
+	})
+}
+
+//nolint:paralleltest // Simple isolated test, no shared state.
+func TestRetryDelay(t *testing.T) {
+	policy := types.RetryPolicy{BaseBackoff: time.Second, MaxBackoff: 5 * time.Second}
+	err := errors.New("transient")
+
+	tests := []struct {
+		failures int
+		want     time.Duration
+	}{
+		{failures: 1, want: time.Second},
+		{failures: 2, want: 2 * time.Second},
+		{failures: 3, want: 4 * time.Second},
+		{failures: 4, want: 5 * time.Second},
+		{failures: 100, want: 5 * time.Second},
+	}
+
+	for _, tt := range tests {
+		if got := retryDelay(policy, tt.failures, err); got != tt.want {
+			t.Errorf("retryDelay(%d) = %v, want %v", tt.failures, got, tt.want)
+		}
+	}
+
+	policy.Jitter = 0.5
+	for range 100 {
+		if got := retryDelay(policy, 1, err); got < 500*time.Millisecond || got > time.Second {
+			t.Fatalf("retryDelay with jitter = %v, want within [500ms, 1s]", got)
+		}
+	}
+}
+
+//nolint:paralleltest // Simple isolated test, no shared state.
+func TestDefaultRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "nil", err: nil, want: false},
+		{name: "rate limited", err: &statusError{status: 429}, want: true},
+		{name: "server error", err: &statusError{status: 500}, want: true},
+		{name: "unauthorized", err: &statusError{status: 401}, want: false},
+		{name: "canceled", err: context.Canceled, want: false},
+		{name: "deadline", err: context.DeadlineExceeded, want: true},
+		{name: "network", err: &url.Error{Op: "Post", URL: "x", Err: &timeoutError{}}, want: true},
+		{name: "plain", err: errors.New("invalid config"), want: false},
+	}
+
+	for _, tt := range tests {
+		if got := types.DefaultRetryable(tt.err); got != tt.want {
+			t.Errorf("DefaultRetryable(%s) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+// timeoutError is a test net.Error reporting a timeout.
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
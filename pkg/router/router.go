@@ -4,21 +4,48 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"net/http"
 	"net/url"
+	"reflect"
+	"slices"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/nicholas-fedor/shoutrrr/pkg/format"
+	"github.com/nicholas-fedor/shoutrrr/pkg/outbox"
+	"github.com/nicholas-fedor/shoutrrr/pkg/secrets"
 	"github.com/nicholas-fedor/shoutrrr/pkg/types"
+	"github.com/nicholas-fedor/shoutrrr/pkg/util"
 )
 
 // ServiceRouter is responsible for routing a message to a specific notification service using the notification URL.
 type ServiceRouter struct {
-	logger     types.StdLogger
-	services   []types.Service
-	queue      []string
-	Timeout    time.Duration
-	httpClient types.HTTPClient
+	logger        types.StdLogger
+	services      []types.Service
+	targets       []string
+	urls          []string
+	filters       []LevelFilter
+	defaults      map[types.Service]types.Params
+	overrides     map[types.Service]urlOverrides
+	overMu        sync.RWMutex
+	templates     map[string]string
+	groups        map[string]*routeGroup
+	queue         []string
+	Timeout       time.Duration
+	Retry         types.RetryPolicy
+	httpClient    types.HTTPClient
+	middleware    []types.Middleware
+	dedup         *deduplicator
+	oversize      types.OversizePolicy
+	throttle      *throttle
+	breaker       *circuitBreaker
+	outbox        *outbox.Outbox
+	metrics       types.Metrics
+	expandSecrets bool
+	lifecycle     lifecycle
 	//nolint:containedctx // Intentional: router derives per-service timeout contexts from this base.
 	ctx context.Context
 }
@@ -51,7 +78,7 @@ func New(logger types.StdLogger, serviceURLs ...string) (*ServiceRouter, error)
 //
 // Parameters:
 //   - logger: the logger to use for service output.
-//   - opts: the sender options, including timeout and HTTP client.
+//   - opts: the sender options, including timeout, retry policy and HTTP client.
 //   - serviceURLs: the service URLs to initialize.
 //
 // Returns:
@@ -59,12 +86,25 @@ func New(logger types.StdLogger, serviceURLs ...string) (*ServiceRouter, error)
 //   - error: an error if any service fails to initialize.
 func NewWithOptions(logger types.StdLogger, opts types.SenderOptions, serviceURLs ...string) (*ServiceRouter, error) {
 	router := ServiceRouter{
-		logger:     logger,
-		services:   nil,
-		queue:      nil,
-		Timeout:    DefaultTimeout,
-		httpClient: opts.HTTPClient,
-		ctx:        context.Background(),
+		logger:        logger,
+		services:      nil,
+		queue:         nil,
+		Timeout:       DefaultTimeout,
+		Retry:         opts.Retry,
+		httpClient:    opts.HTTPClient,
+		middleware:    opts.Middleware,
+		templates:     opts.Templates,
+		throttle:      newThrottle(opts),
+		metrics:       opts.Metrics,
+		expandSecrets: opts.ExpandSecrets,
+		ctx:           context.Background(),
+	}
+
+	router.SetDedupPolicy(opts.Dedup)
+	router.SetCircuitBreaker(opts.CircuitBreaker)
+
+	if err := router.SetOversizePolicy(opts.Oversize); err != nil {
+		return nil, err
 	}
 
 	if opts.Timeout > 0 {
@@ -88,14 +128,35 @@ func NewWithOptions(logger types.StdLogger, opts types.SenderOptions, serviceURL
 // Returns:
 //   - error: an error if initialization fails.
 func (r *ServiceRouter) AddService(serviceURL string) error {
-	service, err := r.initService(serviceURL)
+	service, overrides, err := r.initService(serviceURL)
 	if err == nil {
+		r.setOverrides(service, overrides)
 		r.services = append(r.services, service)
+		r.targets = append(r.targets, outbox.TargetKey(serviceURL))
+		r.urls = append(r.urls, redactedURL(service, serviceURL))
+		r.filters = append(r.filters, LevelFilter{MinLevel: overrides.minLevel})
 	}
 
 	return err
 }
 
+// redactedURL returns the URL of the initialized service with its credentials
+// redacted, falling back to redacting rawURL for services without a config.
+//
+// Parameters:
+//   - service: the initialized service.
+//   - rawURL: the URL the service was initialized from.
+//
+// Returns:
+//   - string: the redacted URL.
+func redactedURL(service types.Service, rawURL string) string {
+	if redacted := format.RedactServiceURL(service); redacted != service.GetID() {
+		return redacted
+	}
+
+	return util.RedactURL(rawURL)
+}
+
 // Enqueue adds the message to an internal queue and sends it when Flush is invoked.
 //
 // Parameters:
@@ -121,7 +182,7 @@ func (r *ServiceRouter) Enqueue(message string, v ...any) {
 func (r *ServiceRouter) ExtractServiceName(rawURL string) (string, *url.URL, error) {
 	serviceURL, err := url.Parse(rawURL)
 	if err != nil {
-		return "", &url.URL{}, fmt.Errorf("%s: %w", rawURL, ErrParseURLFailed)
+		return "", &url.URL{}, fmt.Errorf("%s: %w", util.RedactURL(rawURL), ErrParseURLFailed)
 	}
 
 	scheme := serviceURL.Scheme
@@ -135,33 +196,46 @@ func (r *ServiceRouter) ExtractServiceName(rawURL string) (string, *url.URL, err
 }
 
 // Flush sends all messages that have been queued up as a combined message.
+// If an outbox is set, each delivery is journaled first and failed deliveries
+// are kept there, to be replayed when the outbox is set on the next start.
+// As Flush is typically deferred, failed deliveries are logged rather than
+// returned.
 //
 // Parameters:
 //   - params: the parameters to apply to the combined message.
 func (r *ServiceRouter) Flush(params *types.Params) {
-	// Since this method is supposed to be deferred we just have to ignore errors
-	_ = r.Send(strings.Join(r.queue, "\n"), params)
+	message := strings.Join(r.queue, "\n")
 	r.queue = []string{}
-}
 
-// ListServices returns the available services.
-//
-// Returns:
-//   - []string: the list of supported service schemas.
-func (r *ServiceRouter) ListServices() []string {
-	services := make([]string, len(serviceMap))
+	if r.outbox != nil {
+		r.logFlushErrors(r.flushToOutbox(message, params))
+
+		return
+	}
 
-	i := 0
+	r.logFlushErrors(r.Send(message, params))
+}
 
-	for key := range serviceMap {
-		services[i] = key
-		i++
+// logFlushErrors logs the failed deliveries of Flush.
+func (r *ServiceRouter) logFlushErrors(errs []error) {
+	for _, err := range errs {
+		if err != nil {
+			r.log("Failed to flush queued messages:", err)
+		}
 	}
+}
 
-	return services
+// ListServices returns the available services, including services added with RegisterService.
+//
+// Returns:
+//   - []string: the sorted list of supported service schemas.
+func (r *ServiceRouter) ListServices() []string {
+	return SupportedSchemas()
 }
 
 // Locate returns the service implementation that corresponds to the given service URL.
+// Router params of the URL are validated and removed, but not applied, as the
+// service is not added to the router.
 //
 // Parameters:
 //   - rawURL: the service URL to locate.
@@ -170,11 +244,117 @@ func (r *ServiceRouter) ListServices() []string {
 //   - types.Service: the located service implementation.
 //   - error: an error if the service cannot be located or initialized.
 func (r *ServiceRouter) Locate(rawURL string) (types.Service, error) {
-	service, err := r.initService(rawURL)
+	service, _, err := r.initService(rawURL)
 
 	return service, err
 }
 
+// SetExpandSecrets sets whether the router expands the ${provider:ref} secret
+// references of service URLs when services are added, see secrets.ExpandURL.
+// Expansion reads environment variables and files, so it is off by default and
+// should only be enabled for URLs from trusted sources.
+//
+// Parameters:
+//   - enabled: whether to expand secret references.
+func (r *ServiceRouter) SetExpandSecrets(enabled bool) {
+	r.expandSecrets = enabled
+}
+
+// ValidateURL checks a service URL without initializing its service, so that no
+// network request is made. The scheme, router params and service config are
+// checked by parsing the URL. If the router expands secrets, secret references
+// are checked but not resolved; as the config cannot be parsed without them, only
+// the scheme of a URL with references is checked.
+//
+// Parameters:
+//   - rawURL: the service URL to check.
+//
+// Returns:
+//   - error: an error if the URL is invalid.
+func (r *ServiceRouter) ValidateURL(rawURL string) error {
+	hasSecrets := false
+
+	if r.expandSecrets {
+		var err error
+		if hasSecrets, err = secrets.Check(rawURL); err != nil {
+			return fmt.Errorf("checking secrets: %w", err)
+		}
+	}
+
+	if hasSecrets {
+		scheme, _, _ := strings.Cut(rawURL, ":")
+		scheme, _, _ = strings.Cut(scheme, "+")
+
+		_, err := newService(scheme)
+
+		return err
+	}
+
+	scheme, serviceURL, err := r.ExtractServiceName(rawURL)
+	if err != nil {
+		return err
+	}
+
+	overrides, err := extractURLParams(serviceURL)
+	if err != nil {
+		return err
+	}
+
+	service, err := newService(scheme)
+	if err != nil {
+		return err
+	}
+
+	if serviceURL.Scheme != scheme {
+		customURLService, ok := service.(types.CustomURLService)
+		if !ok {
+			return fmt.Errorf("%w: '%s' service", ErrCustomURLsNotSupported, scheme)
+		}
+
+		customURL := serviceURL
+
+		serviceURL, err = customURLService.GetServiceURLFromCustom(customURL)
+		if err != nil {
+			return fmt.Errorf("%s: %w", util.RedactURL(customURL.String()), ErrCustomURLConversion)
+		}
+	}
+
+	if err := overrides.checkLevelMap(service, serviceURL); err != nil {
+		return err
+	}
+
+	if config, ok := newServiceConfig(service); ok {
+		if err := config.SetURL(serviceURL); err != nil {
+			return fmt.Errorf("%s: %w", scheme, err)
+		}
+	}
+
+	return nil
+}
+
+// newServiceConfig returns a new instance of the type of the Config field of
+// service, or false if it has none.
+func newServiceConfig(service types.Service) (types.ServiceConfig, bool) {
+	value := reflect.Indirect(reflect.ValueOf(service))
+	if value.Kind() != reflect.Struct {
+		return nil, false
+	}
+
+	field, ok := value.Type().FieldByName("Config")
+	if !ok || !field.IsExported() {
+		return nil, false
+	}
+
+	configType := field.Type
+	if configType.Kind() == reflect.Pointer {
+		configType = configType.Elem()
+	}
+
+	config, ok := reflect.New(configType).Interface().(types.ServiceConfig)
+
+	return config, ok
+}
+
 // NewService returns a new uninitialized service instance.
 //
 // Parameters:
@@ -202,7 +382,7 @@ func (r *ServiceRouter) Route(rawURL, message string) error {
 	}
 
 	if err := service.Send(message, nil); err != nil {
-		return &types.TargetError{URL: service.GetID(), Err: err}
+		return &types.TargetError{URL: format.RedactServiceURL(service), Err: util.RedactError(err)}
 	}
 
 	return nil
@@ -221,9 +401,27 @@ func (r *ServiceRouter) Send(message string, params *types.Params) []error {
 		return []error{ErrNoSenders}
 	}
 
+	return r.SendContext(r.baseContext(), message, params)
+}
+
+// SendContext sends the specified message using the routers underlying services,
+// aborting in-flight requests when ctx is done. The per-service Timeout still applies.
+//
+// Parameters:
+//   - ctx: the context bounding all deliveries.
+//   - message: the message to send.
+//   - params: the parameters to apply.
+//
+// Returns:
+//   - []error: one error per service.
+func (r *ServiceRouter) SendContext(ctx context.Context, message string, params *types.Params) []error {
+	if r == nil {
+		return []error{ErrNoSenders}
+	}
+
 	serviceCount := len(r.services)
 	errs := make([]error, serviceCount)
-	results := r.SendAsync(message, params)
+	results := r.SendAsyncContext(ctx, message, params)
 
 	for i := range r.services {
 		errs[i] = <-results
@@ -241,16 +439,51 @@ func (r *ServiceRouter) Send(message string, params *types.Params) []error {
 // Returns:
 //   - chan error: a channel that will contain one error per service.
 func (r *ServiceRouter) SendAsync(message string, params *types.Params) chan error {
-	serviceCount := len(r.services)
-	proxy := make(chan error, serviceCount)
-	errs := make(chan error, serviceCount)
+	return r.SendAsyncContext(r.baseContext(), message, params)
+}
 
+// SendAsyncContext sends the specified message using the routers underlying services,
+// aborting in-flight requests when ctx is done. The per-service Timeout still applies.
+//
+// Parameters:
+//   - ctx: the context bounding all deliveries.
+//   - message: the message to send.
+//   - params: the parameters to apply.
+//
+// Returns:
+//   - chan error: a channel that will contain one error per service.
+func (r *ServiceRouter) SendAsyncContext(ctx context.Context, message string, params *types.Params) chan error {
 	if params == nil {
 		params = &types.Params{}
 	}
 
-	for _, service := range r.services {
-		go sendToService(service, proxy, r.Timeout, message, *params, r.ctx)
+	return r.sendAsync(ctx, message, *params, true)
+}
+
+// sendAsync sends the message to all services whose level filter it passes.
+//
+// Parameters:
+//   - ctx: the context bounding all deliveries.
+//   - message: the message to send.
+//   - params: the parameters to apply.
+//   - dedup: whether repeats of the message are suppressed.
+//
+// Returns:
+//   - chan error: a channel that will contain one error per service.
+func (r *ServiceRouter) sendAsync(ctx context.Context, message string, params types.Params, dedup bool) chan error {
+	serviceCount := len(r.services)
+	proxy := make(chan error, serviceCount)
+	errs := make(chan error, serviceCount)
+
+	suppressed := dedup && r.suppress(message, params, r.sendRepeatSummary(message))
+
+	for i, service := range r.services {
+		switch {
+		case !r.allowsMessage(i, params), suppressed:
+			proxy <- nil
+		default:
+			go r.sendToService(ctx, service, proxy, message, params)
+		}
 	}
 
 	go func() {
@@ -277,12 +510,64 @@ func (r *ServiceRouter) SendItems(items []types.MessageItem, params types.Params
 		return []error{ErrNoSenders}
 	}
 
+	return r.SendItemsContext(r.baseContext(), items, params)
+}
+
+// SendItemsContext sends the specified message items using the routers underlying
+// services, aborting in-flight requests when ctx is done. The per-service Timeout still applies.
+//
+// Parameters:
+//   - ctx: the context bounding all deliveries.
+//   - items: the message items to send.
+//   - params: the parameters to apply.
+//
+// Returns:
+//   - []error: one error per service.
+func (r *ServiceRouter) SendItemsContext(ctx context.Context, items []types.MessageItem, params types.Params) []error {
+	if r == nil {
+		return []error{ErrNoSenders}
+	}
+
+	return r.sendItems(ctx, items, params, true)
+}
+
+// sendItems sends the items passing the level filter of each service to it.
+//
+// Parameters:
+//   - ctx: the context bounding all deliveries.
+//   - items: the message items to send.
+//   - params: the parameters to apply.
+//   - dedup: whether repeats of the items are suppressed.
+//
+// Returns:
+//   - []error: one error per service.
+func (r *ServiceRouter) sendItems(ctx context.Context, items []types.MessageItem, params types.Params, dedup bool) []error {
 	serviceCount := len(r.services)
 	proxy := make(chan error, serviceCount)
 	errs := make([]error, serviceCount)
 
-	for _, service := range r.services {
-		go sendItemsToService(service, proxy, r.Timeout, items, params, r.ctx)
+	suppressed := dedup && r.suppress(types.ItemsToPlain(items), params, func(repeats int, params types.Params) {
+		summary := types.MessageItem{Text: repeatedSummary(repeats), Timestamp: time.Now()}
+		if len(items) > 0 {
+			summary.Level = items[len(items)-1].Level
+		}
+
+		for _, err := range r.sendItems(r.baseContext(), append(slices.Clone(items), summary), params, false) {
+			if err != nil {
+				r.log("Failed to send repeat summary:", err)
+			}
+		}
+	})
+
+	for i, service := range r.services {
+		filtered := r.filterItems(i, items, params)
+
+		switch {
+		case len(filtered) == 0 && len(items) > 0, suppressed:
+			proxy <- nil
+		default:
+			go r.sendItemsToService(ctx, service, proxy, filtered, params)
+		}
 	}
 
 	for i := range r.services {
@@ -303,44 +588,89 @@ func (r *ServiceRouter) SetLogger(logger types.StdLogger) {
 	}
 }
 
-// initService initializes a service from the given URL.
+// baseContext returns the context that Send, SendAsync and SendItems derive from.
+//
+// Returns:
+//   - context.Context: the router base context, or context.Background for a zero-value router.
+func (r *ServiceRouter) baseContext() context.Context {
+	if r.ctx == nil {
+		return context.Background()
+	}
+
+	return r.ctx
+}
+
+// initService initializes a service from the given URL, after expanding its secret
+// references if the router expands secrets.
+// The router params of the URL are returned for the caller to record with setOverrides.
 //
 // Parameters:
 //   - rawURL: the raw service URL.
 //
 // Returns:
 //   - types.Service: the initialized service.
+//   - urlOverrides: the router params of the URL.
 //   - error: an error if initialization fails.
-func (r *ServiceRouter) initService(rawURL string) (types.Service, error) {
+func (r *ServiceRouter) initService(rawURL string) (types.Service, urlOverrides, error) {
+	var overrides urlOverrides
+
+	if r.expandSecrets {
+		expanded, err := secrets.ExpandURL(r.baseContext(), rawURL)
+		if err != nil {
+			return nil, overrides, fmt.Errorf("expanding secrets: %w", err)
+		}
+
+		rawURL = expanded
+	}
+
 	scheme, serviceURL, err := r.ExtractServiceName(rawURL)
 	if err != nil {
-		return nil, err
+		return nil, overrides, err
+	}
+
+	overrides, err = extractURLParams(serviceURL)
+	if err != nil {
+		return nil, overrides, err
 	}
 
 	service, err := newService(scheme)
 	if err != nil {
-		return nil, err
+		return nil, overrides, err
 	}
 
-	if serviceURL.Scheme != scheme {
-		r.log("Got custom URL:", serviceURL.String())
+	customURL := serviceURL.Scheme != scheme
+	if customURL {
+		redactedCustomURL := util.RedactURL(serviceURL.String())
+		r.log("Got custom URL:", redactedCustomURL)
 
 		customURLService, ok := service.(types.CustomURLService)
 		if !ok {
-			return nil, fmt.Errorf("%w: '%s' service", ErrCustomURLsNotSupported, scheme)
+			return nil, overrides, fmt.Errorf("%w: '%s' service", ErrCustomURLsNotSupported, scheme)
 		}
 
 		serviceURL, err = customURLService.GetServiceURLFromCustom(serviceURL)
 		if err != nil {
-			return nil, fmt.Errorf("%s: %w", serviceURL.String(), ErrCustomURLConversion)
+			return nil, overrides, fmt.Errorf("%s: %w", redactedCustomURL, ErrCustomURLConversion)
 		}
+	}
 
-		r.log("Converted service URL:", serviceURL.String())
+	if err := overrides.checkLevelMap(service, serviceURL); err != nil {
+		return nil, overrides, err
 	}
 
 	err = service.Initialize(serviceURL, r.logger)
 	if err != nil {
-		return service, fmt.Errorf("%s: %w", scheme, err)
+		return service, overrides, fmt.Errorf("%s: %w", scheme, err)
+	}
+
+	if customURL {
+		r.log("Converted service URL:", format.RedactServiceURL(service))
+	}
+
+	for id, body := range r.templates {
+		if err := service.SetTemplateString(id, body); err != nil {
+			return service, overrides, fmt.Errorf("setting template %q: %w", id, err)
+		}
 	}
 
 	// Inject custom HTTP client if provided and the service supports it.
@@ -352,7 +682,7 @@ func (r *ServiceRouter) initService(rawURL string) (types.Service, error) {
 		}
 	}
 
-	return service, nil
+	return service, overrides, nil
 }
 
 // log writes a log message if a logger is configured.
@@ -369,109 +699,273 @@ func (r *ServiceRouter) log(v ...any) {
 
 // newService returns a new uninitialized service instance.
 func newService(serviceScheme string) (types.Service, error) {
-	serviceFactory, valid := serviceMap[strings.ToLower(serviceScheme)]
+	factory, valid := serviceFactory(serviceScheme)
 	if !valid {
 		return nil, fmt.Errorf("%w: %q", ErrUnknownService, serviceScheme)
 	}
 
-	return serviceFactory(), nil
+	return factory(), nil
 }
 
-// awaitResult waits for either the service result or a timeout, wrapping the
+// awaitResult waits for either the service result or ctx to be done, wrapping the
 // result in a TargetError if needed.
 //
 // Parameters:
+//   - ctx: the context bounding the operation, including its timeout.
 //   - results: the channel to report the final error to.
 //   - result: the channel carrying the service result.
-//   - timeout: the operation timeout.
-//   - serviceID: the identifier of the service for error wrapping.
-func awaitResult(results chan error, result <-chan error, timeout time.Duration, serviceID string) {
+//   - target: the redacted URL of the service for error wrapping.
+func awaitResult(ctx context.Context, results chan error, result <-chan error, target string) {
 	select {
 	case res := <-result:
 		if res != nil {
 			if errors.Is(res, context.DeadlineExceeded) {
-				res = &types.TargetError{URL: serviceID, Err: fmt.Errorf("%w: %v", ErrServiceTimeout, serviceID)}
+				res = &types.TargetError{URL: target, Err: fmt.Errorf("%w: %v", ErrServiceTimeout, target)}
 			} else {
-				res = &types.TargetError{URL: serviceID, Err: res}
+				res = &types.TargetError{URL: target, Err: util.RedactError(res)}
 			}
 		}
 
 		results <- res
-	case <-time.After(timeout):
-		results <- &types.TargetError{URL: serviceID, Err: fmt.Errorf("%w: %v", ErrServiceTimeout, serviceID)}
+	case <-ctx.Done():
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			results <- &types.TargetError{URL: target, Err: fmt.Errorf("%w: %v", ErrServiceTimeout, target)}
+		} else {
+			results <- &types.TargetError{URL: target, Err: ctx.Err()}
+		}
 	}
 }
 
-// sendToService sends a message to a single service, respecting context and timeout.
+// sendToService sends a message to a single service through the middleware chain.
 //
 // Parameters:
+//   - ctx: the base context for the operation.
 //   - service: the service to send to.
 //   - results: the channel to report the result error to.
-//   - timeout: the operation timeout.
 //   - message: the message to send.
 //   - params: the parameters to apply.
-//   - ctx: the base context for the operation.
-func sendToService(
+func (r *ServiceRouter) sendToService(
+	ctx context.Context,
 	service types.Service,
 	results chan error,
-	timeout time.Duration,
 	message string,
 	params types.Params,
-	ctx context.Context,
 ) {
-	result := make(chan error, 1)
-
-	serviceID := service.GetID()
-
-	if sender, ok := service.(types.ContextSender); ok {
-		sendCtx, cancel := context.WithTimeout(ctx, timeout)
-		defer cancel()
-
-		go func() { result <- sender.SendContext(sendCtx, message, &params) }()
-	} else {
-		go func() { result <- service.Send(message, &params) }()
-	}
-
-	awaitResult(results, result, timeout, serviceID)
+	results <- r.deliver(ctx, &types.Delivery{Service: service, Message: message, Params: maps.Clone(params)}, nil)
 }
 
-// sendItemsToService sends message items to a single service, respecting context and timeout.
+// sendItemsToService sends message items to a single service through the middleware chain.
 //
 // Parameters:
+//   - ctx: the base context for the operation.
 //   - service: the service to send to.
 //   - results: the channel to report the result error to.
-//   - timeout: the operation timeout.
 //   - items: the message items to send.
 //   - params: the parameters to apply.
-//   - ctx: the base context for the operation.
-func sendItemsToService(
+func (r *ServiceRouter) sendItemsToService(
+	ctx context.Context,
 	service types.Service,
 	results chan error,
-	timeout time.Duration,
 	items []types.MessageItem,
 	params types.Params,
+) {
+	delivery := &types.Delivery{Service: service, Items: slices.Clone(items), Params: maps.Clone(params)}
+	if delivery.Items == nil {
+		delivery.Items = []types.MessageItem{}
+	}
+
+	results <- r.deliver(ctx, delivery, nil)
+}
+
+// deliver runs the delivery through the middleware chain. The innermost handler
+// sends to the service with retries within the timeout. Errors are wrapped in
+// *types.TargetError carrying their class, and the outcome is reported to the
+// metrics hook.
+//
+// Parameters:
+//   - ctx: the base context for the operation.
+//   - delivery: the delivery to perform.
+//   - wrap: an optional function wrapping every send attempt.
+//
+// Returns:
+//   - error: the delivery error, or nil on success.
+func (r *ServiceRouter) deliver(ctx context.Context, delivery *types.Delivery, wrap func(sendFunc) sendFunc) error {
+	target := format.RedactServiceURL(delivery.Service)
+	start := time.Now()
+
+	if !r.lifecycle.begin() {
+		err := &types.TargetError{URL: target, Err: ErrRouterClosed, Class: types.ErrorClassCanceled}
+		r.observe(delivery, 0, 0, err)
+
+		return err
+	}
+
+	defer r.lifecycle.end()
+
+	var attempts atomic.Int64
+
+	var handler types.DeliverFunc = func(ctx context.Context, delivery *types.Delivery) error {
+		delete(delivery.Params, types.DedupKey)
+
+		parts, err := r.fitMessage(delivery)
+		if err != nil {
+			return err
+		}
+
+		if err := r.breaker.allow(delivery.Service); err != nil {
+			return err
+		}
+
+		var send sendFunc
+
+		switch {
+		case delivery.Items != nil:
+			send = itemsSendFunc(delivery.Service, delivery.Items, delivery.Params)
+		case len(parts) > 1:
+			// Every part is throttled by partsSendFunc.
+			send = r.partsSendFunc(delivery.Service, parts, delivery.Params)
+		default:
+			send = messageSendFunc(delivery.Service, parts[0], delivery.Params)
+		}
+
+		send = countAttempts(&attempts, send)
+
+		if wrap != nil {
+			send = wrap(send)
+		}
+
+		if len(parts) < 2 {
+			send = r.throttle.wrap(delivery.Service, send)
+		}
+
+		result := make(chan error, 1)
+		timeout, policy := r.sendPolicy(delivery.Service)
+		runSend(ctx, target, result, timeout, policy, send)
+
+		err = <-result
+		if r.breaker.record(delivery.Service, err) {
+			r.log("Circuit opened for", target, "after repeated failures:", err)
+		}
+
+		return err
+	}
+
+	for i := len(r.middleware) - 1; i >= 0; i-- {
+		handler = r.middleware[i](handler)
+	}
+
+	if delivery.Params == nil {
+		delivery.Params = types.Params{}
+	}
+
+	r.applyDefaultParams(delivery)
+	r.applyLevelMapping(delivery)
+
+	err := applyBodyFormat(delivery)
+	if err == nil {
+		err = handler(ctx, delivery)
+	}
+	if err != nil {
+		var targetErr *types.TargetError
+		if errors.As(err, &targetErr) {
+			targetErr.Err = util.RedactError(targetErr.Err)
+		} else {
+			targetErr = &types.TargetError{URL: target, Err: util.RedactError(err)}
+			err = targetErr
+		}
+
+		if targetErr.Class == "" {
+			targetErr.Class = classifyError(targetErr.Err)
+		}
+	}
+
+	r.observe(delivery, time.Since(start), int(attempts.Load()), err)
+
+	return err
+}
+
+// countAttempts returns send, counting its invocations in attempts.
+//
+// Parameters:
+//   - attempts: the counter to increment.
+//   - send: the function performing a single attempt.
+//
+// Returns:
+//   - sendFunc: the counting attempt function.
+func countAttempts(attempts *atomic.Int64, send sendFunc) sendFunc {
+	return func(ctx context.Context) error {
+		attempts.Add(1)
+
+		return send(ctx)
+	}
+}
+
+// runSend performs send with retries within the timeout and reports the wrapped result.
+//
+// Parameters:
+//   - ctx: the base context for the operation.
+//   - target: the redacted URL of the service for error wrapping.
+//   - results: the channel to report the result error to.
+//   - timeout: the operation timeout, covering all attempts.
+//   - policy: the retry policy to apply.
+//   - send: the function performing a single attempt.
+func runSend(
 	ctx context.Context,
+	target string,
+	results chan error,
+	timeout time.Duration,
+	policy types.RetryPolicy,
+	send sendFunc,
 ) {
 	result := make(chan error, 1)
 
-	serviceID := service.GetID()
+	sendCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
 
+	go func() { result <- sendWithRetry(sendCtx, policy, send) }()
+
+	awaitResult(sendCtx, results, result, target)
+}
+
+// messageSendFunc returns a function performing a single attempt to send a message,
+// passing the context to services that implement types.ContextSender.
+//
+// Parameters:
+//   - service: the service to send to.
+//   - message: the message to send.
+//   - params: the parameters to apply.
+//
+// Returns:
+//   - sendFunc: the attempt function.
+func messageSendFunc(service types.Service, message string, params types.Params) sendFunc {
+	if sender, ok := service.(types.ContextSender); ok {
+		return func(ctx context.Context) error { return sender.SendContext(ctx, message, &params) }
+	}
+
+	return func(context.Context) error { return service.Send(message, &params) }
+}
+
+// itemsSendFunc returns a function performing a single attempt to send message items,
+// preferring the richest interface the service implements.
+//
+// Parameters:
+//   - service: the service to send to.
+//   - items: the message items to send.
+//   - params: the parameters to apply.
+//
+// Returns:
+//   - sendFunc: the attempt function.
+func itemsSendFunc(service types.Service, items []types.MessageItem, params types.Params) sendFunc {
 	switch sender := service.(type) {
 	case types.ContextAttachmentSender:
-		sendCtx, cancel := context.WithTimeout(ctx, timeout)
-		defer cancel()
-
-		go func() { result <- sender.SendItemsContext(sendCtx, items, params) }()
+		return func(ctx context.Context) error { return sender.SendItemsContext(ctx, items, params) }
 	case types.RichSender:
-		go func() { result <- sender.SendItems(items, params) }()
+		return func(context.Context) error { return sender.SendItems(items, params) }
 	case types.ContextSender:
-		sendCtx, cancel := context.WithTimeout(ctx, timeout)
-		defer cancel()
-
-		go func() { result <- sender.SendContext(sendCtx, types.ItemsToPlain(items), &params) }()
+		return func(ctx context.Context) error {
+			return sender.SendContext(ctx, types.ItemsToPlain(items), &params)
+		}
 	default:
-		go func() { result <- service.Send(types.ItemsToPlain(items), &params) }()
+		return func(context.Context) error { return service.Send(types.ItemsToPlain(items), &params) }
 	}
-
-	awaitResult(results, result, timeout, serviceID)
 }
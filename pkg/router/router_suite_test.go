@@ -57,7 +57,7 @@ var _ = ginkgo.Describe("the router suite", func() {
 
 	ginkgo.When("initializing a service with a custom URL", func() {
 		ginkgo.It("should return an error if the service does not support it", func() {
-			service, err := sr.initService("log+https://hybr.is")
+			service, _, err := sr.initService("log+https://hybr.is")
 			gomega.Expect(err).To(gomega.HaveOccurred())
 			gomega.Expect(service).To(gomega.BeNil())
 		})
@@ -80,12 +80,12 @@ var _ = ginkgo.Describe("the router suite", func() {
 
 	ginkgo.When("initializing a service with a custom URL", func() {
 		ginkgo.It("should return an error if the service does not support it", func() {
-			service, err := sr.initService("log+https://hybr.is")
+			service, _, err := sr.initService("log+https://hybr.is")
 			gomega.Expect(err).To(gomega.HaveOccurred())
 			gomega.Expect(service).To(gomega.BeNil())
 		})
 		ginkgo.It("should successfully init a service that does support it", func() {
-			service, err := sr.initService(mockCustomURL)
+			service, _, err := sr.initService(mockCustomURL)
 			gomega.Expect(err).NotTo(gomega.HaveOccurred())
 			gomega.Expect(service).NotTo(gomega.BeNil())
 		})
@@ -117,7 +117,7 @@ var _ = ginkgo.Describe("the router suite", func() {
 	ginkgo.When("router has not been provided a logger", func() {
 		ginkgo.It("should not crash when trying to log", func() {
 			router := ServiceRouter{}
-			_, err := router.initService(mockCustomURL)
+			_, _, err := router.initService(mockCustomURL)
 			gomega.Expect(err).NotTo(gomega.HaveOccurred())
 		})
 	})
@@ -1,17 +1,87 @@
 package router
 
-import "sort"
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
 
-// SupportedSchemas returns the sorted list of service schemas supported by this build.
+	"github.com/nicholas-fedor/shoutrrr/pkg/types"
+)
+
+var (
+	ErrInvalidScheme     = errors.New("invalid service scheme")
+	ErrNilServiceFactory = errors.New("service factory must not be nil")
+	ErrServiceRegistered = errors.New("service scheme already registered")
+)
+
+// serviceMapMu guards serviceMap against concurrent registration and lookup.
+var serviceMapMu sync.RWMutex
+
+// RegisterService registers a factory for the given URL scheme, making services
+// created by it available to routers, SupportedSchemas and the CLI.
+// Schemes are case-insensitive. Registering a scheme that is already known fails;
+// use OverrideService to replace an existing service.
+//
+// Parameters:
+//   - scheme: the URL scheme, e.g. "ourthing" for ourthing:// URLs.
+//   - factory: the function returning a new, uninitialized service instance.
+//
+// Returns:
+//   - error: an error if the scheme is invalid, already registered or factory is nil.
+func RegisterService(scheme string, factory func() types.Service) error {
+	return registerService(scheme, factory, false)
+}
+
+// OverrideService registers a factory for the given URL scheme, replacing any
+// service previously registered for it, including built-in services.
+//
+// Parameters:
+//   - scheme: the URL scheme, e.g. "ourthing" for ourthing:// URLs.
+//   - factory: the function returning a new, uninitialized service instance.
+//
+// Returns:
+//   - error: an error if the scheme is invalid or factory is nil.
+func OverrideService(scheme string, factory func() types.Service) error {
+	return registerService(scheme, factory, true)
+}
+
+// UnregisterService removes the service registered for the given URL scheme.
+// Routers that already located the service are not affected.
+//
+// Parameters:
+//   - scheme: the URL scheme to remove.
+//
+// Returns:
+//   - bool: true if a service was registered for the scheme.
+func UnregisterService(scheme string) bool {
+	scheme = strings.ToLower(scheme)
+
+	serviceMapMu.Lock()
+	defer serviceMapMu.Unlock()
+
+	_, ok := serviceMap[scheme]
+	delete(serviceMap, scheme)
+
+	return ok
+}
+
+// SupportedSchemas returns the sorted list of service schemas supported by this build,
+// including services added with RegisterService.
 //
 // Returns:
 //   - []string: the supported service schemas.
 func SupportedSchemas() []string {
+	serviceMapMu.RLock()
+
 	schemas := make([]string, 0, len(serviceMap))
 	for scheme := range serviceMap {
 		schemas = append(schemas, scheme)
 	}
 
+	serviceMapMu.RUnlock()
+
 	sort.Strings(schemas)
 
 	return schemas
@@ -25,7 +95,60 @@ func SupportedSchemas() []string {
 // Returns:
 //   - bool: true if the schema is supported.
 func SupportsSchema(schema string) bool {
-	_, ok := serviceMap[schema]
+	_, ok := serviceFactory(schema)
 
 	return ok
 }
+
+// registerService validates and stores the factory for scheme.
+func registerService(scheme string, factory func() types.Service, override bool) error {
+	if !validScheme(scheme) {
+		return fmt.Errorf("%w: %q", ErrInvalidScheme, scheme)
+	}
+
+	if factory == nil {
+		return fmt.Errorf("%w: %q", ErrNilServiceFactory, scheme)
+	}
+
+	scheme = strings.ToLower(scheme)
+
+	serviceMapMu.Lock()
+	defer serviceMapMu.Unlock()
+
+	if _, exists := serviceMap[scheme]; exists && !override {
+		return fmt.Errorf("%w: %q", ErrServiceRegistered, scheme)
+	}
+
+	serviceMap[scheme] = factory
+
+	return nil
+}
+
+// serviceFactory returns the factory registered for scheme, ignoring case.
+func serviceFactory(scheme string) (func() types.Service, bool) {
+	serviceMapMu.RLock()
+	defer serviceMapMu.RUnlock()
+
+	factory, ok := serviceMap[strings.ToLower(scheme)]
+
+	return factory, ok
+}
+
+// validScheme reports whether scheme is a valid URL scheme as defined by RFC 3986.
+// The '+' character is rejected as ExtractServiceName splits custom schemes on it.
+func validScheme(scheme string) bool {
+	if scheme == "" {
+		return false
+	}
+
+	for i, char := range scheme {
+		switch {
+		case char >= 'a' && char <= 'z', char >= 'A' && char <= 'Z':
+		case i > 0 && (char >= '0' && char <= '9' || char == '-' || char == '.'):
+		default:
+			return false
+		}
+	}
+
+	return true
+}
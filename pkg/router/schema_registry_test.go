@@ -0,0 +1,92 @@
+package router
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/nicholas-fedor/shoutrrr/pkg/services/specialized/logger"
+	"github.com/nicholas-fedor/shoutrrr/pkg/types"
+)
+
+//nolint:paralleltest // Modifies shared serviceMap; cannot run in parallel.
+func TestRegisterService(t *testing.T) {
+	svc := &flakyService{}
+
+	if err := RegisterService("Mock-Custom", func() types.Service { return svc }); err != nil {
+		t.Fatalf("RegisterService: %v", err)
+	}
+	defer UnregisterService("mock-custom")
+
+	if !SupportsSchema("mock-custom") || !slices.Contains(SupportedSchemas(), "mock-custom") {
+		t.Errorf("registered scheme is not reported as supported")
+	}
+
+	router := &ServiceRouter{}
+	if !slices.Contains(router.ListServices(), "mock-custom") {
+		t.Errorf("ListServices does not include the registered scheme")
+	}
+
+	service, err := router.Locate("mock-custom://host")
+	if err != nil {
+		t.Fatalf("Locate: %v", err)
+	}
+
+	if service != svc {
+		t.Errorf("Locate returned %T, want the registered service", service)
+	}
+
+	err = RegisterService("mock-custom", func() types.Service { return &flakyService{} })
+	if !errors.Is(err, ErrServiceRegistered) {
+		t.Errorf("duplicate RegisterService error = %v, want %v", err, ErrServiceRegistered)
+	}
+}
+
+func TestRegisterServiceRejectsInvalidInput(t *testing.T) {
+	t.Parallel()
+
+	factory := func() types.Service { return &flakyService{} }
+
+	for _, scheme := range []string{"", "1abc", "has space", "with:colon", "foo+bar"} {
+		if err := RegisterService(scheme, factory); !errors.Is(err, ErrInvalidScheme) {
+			t.Errorf("RegisterService(%q) error = %v, want %v", scheme, err, ErrInvalidScheme)
+		}
+	}
+
+	if err := RegisterService("mock-nil", nil); !errors.Is(err, ErrNilServiceFactory) {
+		t.Errorf("RegisterService with nil factory error = %v, want %v", err, ErrNilServiceFactory)
+	}
+}
+
+//nolint:paralleltest // Modifies shared serviceMap; cannot run in parallel.
+func TestOverrideAndUnregisterService(t *testing.T) {
+	svc := &flakyService{}
+
+	if err := OverrideService("logger", func() types.Service { return svc }); err != nil {
+		t.Fatalf("OverrideService: %v", err)
+	}
+	defer OverrideService("logger", func() types.Service { return &logger.Service{} })
+
+	router := &ServiceRouter{}
+
+	service, err := router.Locate("logger://")
+	if err != nil {
+		t.Fatalf("Locate: %v", err)
+	}
+
+	if service != svc {
+		t.Errorf("Locate returned %T, want the overriding service", service)
+	}
+
+	if !UnregisterService("LOGGER") {
+		t.Errorf("UnregisterService reported the scheme as unknown")
+	}
+
+	if UnregisterService("logger") {
+		t.Errorf("UnregisterService reported a removed scheme as known")
+	}
+
+	if _, err := router.Locate("logger://"); !errors.Is(err, ErrUnknownService) {
+		t.Errorf("Locate after unregister error = %v, want %v", err, ErrUnknownService)
+	}
+}
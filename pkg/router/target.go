@@ -0,0 +1,64 @@
+package router
+
+import (
+	"maps"
+
+	"github.com/nicholas-fedor/shoutrrr/pkg/types"
+)
+
+// TargetOptions configures a target added with AddServiceWithOptions.
+type TargetOptions struct {
+	// Filter restricts the message levels delivered to the target. The zero value
+	// allows all levels. Without a MinLevel, the ParamMinLevel of the URL applies.
+	Filter LevelFilter
+	// Params are the default parameters of the target's deliveries. Parameters
+	// passed with a message take precedence.
+	Params types.Params
+}
+
+// AddServiceWithOptions initializes the specified service from its URL and adds it
+// with a level filter and default parameters.
+//
+// Parameters:
+//   - serviceURL: the service URL to initialize and add.
+//   - opts: the options of the target.
+//
+// Returns:
+//   - error: an error if initialization fails.
+func (r *ServiceRouter) AddServiceWithOptions(serviceURL string, opts TargetOptions) error {
+	if err := r.AddService(serviceURL); err != nil {
+		return err
+	}
+
+	if opts.Filter.MinLevel == types.Unknown {
+		opts.Filter.MinLevel = r.filters[len(r.filters)-1].MinLevel
+	}
+
+	r.filters[len(r.filters)-1] = opts.Filter
+	r.setDefaultParams(r.services[len(r.services)-1], opts.Params)
+
+	return nil
+}
+
+// setDefaultParams sets the default parameters of the deliveries to service.
+func (r *ServiceRouter) setDefaultParams(service types.Service, params types.Params) {
+	if len(params) == 0 {
+		return
+	}
+
+	if r.defaults == nil {
+		r.defaults = make(map[types.Service]types.Params)
+	}
+
+	r.defaults[service] = maps.Clone(params)
+}
+
+// applyDefaultParams adds the default parameters of the delivery's service that
+// are not already set to its params.
+func (r *ServiceRouter) applyDefaultParams(delivery *types.Delivery) {
+	for key, value := range r.defaults[delivery.Service] {
+		if _, ok := delivery.Params[key]; !ok {
+			delivery.Params[key] = value
+		}
+	}
+}
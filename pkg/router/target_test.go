@@ -0,0 +1,73 @@
+package router
+
+import (
+	"testing"
+
+	"github.com/nicholas-fedor/shoutrrr/pkg/types"
+)
+
+//nolint:paralleltest // Modifies shared serviceMap; cannot run in parallel.
+func TestAddServiceWithOptionsAppliesDefaultParams(t *testing.T) {
+	svc := &recordingService{}
+
+	serviceMap["mock-params"] = func() types.Service { return svc }
+	defer delete(serviceMap, "mock-params")
+
+	router, err := NewWithOptions(nil, types.SenderOptions{})
+	if err != nil {
+		t.Fatalf("NewWithOptions: %v", err)
+	}
+
+	err = router.AddServiceWithOptions("mock-params://", TargetOptions{
+		Params: types.Params{"title": "Ops", "color": "red"},
+	})
+	if err != nil {
+		t.Fatalf("AddServiceWithOptions: %v", err)
+	}
+
+	router.Send("deployed", &types.Params{"title": "Deploys"})
+
+	if got := svc.params[0]; got["title"] != "Deploys" || got["color"] != "red" {
+		t.Errorf("params = %v, want the message title and the default color", got)
+	}
+}
+
+//nolint:paralleltest // Modifies shared serviceMap; cannot run in parallel.
+func TestGroupFilterAndMemberParams(t *testing.T) {
+	primary := &recordingService{}
+
+	serviceMap["mock-params"] = func() types.Service { return primary }
+	defer delete(serviceMap, "mock-params")
+
+	router, err := NewWithOptions(nil, types.SenderOptions{})
+	if err != nil {
+		t.Fatalf("NewWithOptions: %v", err)
+	}
+
+	err = router.AddGroup("oncall", GroupOptions{
+		Mode:   GroupFailover,
+		Filter: LevelFilter{MinLevel: types.Warning},
+		Params: []types.Params{{"title": "Pager"}, {"title": "Spare"}},
+	}, "mock-params://")
+	if err == nil {
+		t.Error("AddGroup with more params than members succeeded")
+	}
+
+	err = router.AddGroup("oncall", GroupOptions{
+		Mode:   GroupFailover,
+		Filter: LevelFilter{MinLevel: types.Warning},
+		Params: []types.Params{{"title": "Pager"}},
+	}, "mock-params://")
+	if err != nil {
+		t.Fatalf("AddGroup: %v", err)
+	}
+
+	if result := router.SendGroup("oncall", "fyi", nil); !result.Skipped || result.Err != nil || !result.Results[0].Skipped {
+		t.Errorf("info result = %+v, want the group skipped", result)
+	}
+
+	result := router.SendGroup("oncall", "down", &types.Params{"level": "error"})
+	if result.Err != nil || len(primary.params) != 1 || primary.params[0]["title"] != "Pager" {
+		t.Errorf("error result = %+v with params %v, want delivery with the member title", result, primary.params)
+	}
+}
@@ -0,0 +1,281 @@
+package router
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nicholas-fedor/shoutrrr/pkg/types"
+)
+
+// Router params are query parameters accepted on every service URL. They are
+// applied by the router and removed from the URL before the service is initialized.
+const (
+	// ParamTimeout overrides the router Timeout for the target, e.g. "60s".
+	ParamTimeout = "__timeout"
+	// ParamRetries sets the number of retries of a failed delivery to the target.
+	ParamRetries = "__retries"
+	// ParamMinLevel is the lowest message level delivered to the target, e.g. "warning".
+	ParamMinLevel = "__minlevel"
+	// ParamLevelMap overrides the mapping of message levels to the target's native
+	// priority, e.g. "warning:4,error:5", or disables it with "none".
+	ParamLevelMap = "__levelmap"
+	// ParamOversize sets how messages longer than the target's MessageLimit are
+	// handled: "split", "truncate", "reject" or "none".
+	ParamOversize = "__oversize"
+)
+
+// routerParamPrefix is the prefix reserved for router params.
+const routerParamPrefix = "__"
+
+// DefaultRetryBackoff is the delay before the first retry requested with
+// ParamRetries when the router's RetryPolicy has no BaseBackoff.
+const DefaultRetryBackoff = time.Second
+
+// ErrInvalidRouterParam is returned for a router param that is unknown or has an invalid value.
+var ErrInvalidRouterParam = errors.New("invalid router parameter")
+
+// URLParam describes a router param.
+type URLParam struct {
+	// Name is the query parameter name, including its "__" prefix.
+	Name string
+	// Type is the kind of value the param takes.
+	Type string
+	// Description explains the effect of the param.
+	Description string
+}
+
+// urlParams documents the router params, in display order.
+var urlParams = []URLParam{
+	{
+		Name:        ParamTimeout,
+		Type:        "duration",
+		Description: "Time allowed for each delivery to the target, including retries",
+	},
+	{
+		Name:        ParamRetries,
+		Type:        "int",
+		Description: "Number of retries of a failed delivery to the target",
+	},
+	{
+		Name:        ParamMinLevel,
+		Type:        "option",
+		Description: "Lowest message level delivered to the target (debug, info, warning, error)",
+	},
+	{
+		Name:        ParamLevelMap,
+		Type:        "map",
+		Description: "Message level to native priority mapping, e.g. warning:4,error:5, or none to disable it",
+	},
+	{
+		Name:        ParamOversize,
+		Type:        "option",
+		Description: "Handling of messages longer than the service limit (split, truncate, reject, none)",
+	},
+}
+
+// URLParams returns the router params accepted on every service URL.
+//
+// Returns:
+//   - []URLParam: the router params, in display order.
+func URLParams() []URLParam {
+	return slices.Clone(urlParams)
+}
+
+// urlOverrides holds the router params of a service URL.
+type urlOverrides struct {
+	timeout    time.Duration
+	retries    int
+	hasRetries bool
+	minLevel   types.MessageLevel
+	levelMap   map[types.MessageLevel]string
+	noLevelMap bool
+	oversize   types.OversizePolicy
+}
+
+// isZero reports whether no router params are set.
+func (o urlOverrides) isZero() bool {
+	return o.timeout == 0 && !o.hasRetries && o.minLevel == types.Unknown &&
+		o.levelMap == nil && !o.noLevelMap && o.oversize == ""
+}
+
+// HasURLParams reports whether the query of rawURL contains router params.
+// Router params only take effect for services added to a ServiceRouter, so
+// callers delivering to a service directly use it to reject them.
+//
+// Parameters:
+//   - rawURL: the service URL.
+//
+// Returns:
+//   - bool: true if the URL has a query parameter with the "__" prefix.
+func HasURLParams(rawURL string) bool {
+	_, query, _ := strings.Cut(rawURL, "?")
+	query, _, _ = strings.Cut(query, "#")
+
+	if !strings.Contains(query, routerParamPrefix) {
+		return false
+	}
+
+	values, _ := url.ParseQuery(query)
+	for key := range values {
+		if strings.HasPrefix(key, routerParamPrefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// extractURLParams removes the router params from the query of serviceURL and
+// returns their values.
+//
+// Parameters:
+//   - serviceURL: the service URL, modified in place.
+//
+// Returns:
+//   - urlOverrides: the parsed router params.
+//   - error: an error wrapping ErrInvalidRouterParam for an unknown param or an invalid value.
+func extractURLParams(serviceURL *url.URL) (urlOverrides, error) {
+	var overrides urlOverrides
+
+	if !strings.Contains(serviceURL.RawQuery, routerParamPrefix) {
+		return overrides, nil
+	}
+
+	query := serviceURL.Query()
+	found := false
+
+	for key, values := range query {
+		if !strings.HasPrefix(key, routerParamPrefix) {
+			continue
+		}
+
+		found = true
+		value := values[len(values)-1]
+
+		if err := overrides.set(strings.ToLower(key), value); err != nil {
+			return overrides, fmt.Errorf("%w: %w", ErrInvalidRouterParam, err)
+		}
+
+		query.Del(key)
+	}
+
+	if found {
+		serviceURL.RawQuery = query.Encode()
+	}
+
+	return overrides, nil
+}
+
+// set parses the value of the router param named key.
+func (o *urlOverrides) set(key, value string) error {
+	switch key {
+	case ParamTimeout:
+		timeout, err := time.ParseDuration(value)
+		if err != nil || timeout <= 0 {
+			return fmt.Errorf("%s must be a positive duration, got %q", key, value)
+		}
+
+		o.timeout = timeout
+	case ParamRetries:
+		retries, err := strconv.Atoi(value)
+		if err != nil || retries < 0 {
+			return fmt.Errorf("%s must be a non-negative integer, got %q", key, value)
+		}
+
+		o.retries, o.hasRetries = retries, true
+	case ParamMinLevel:
+		level, ok := types.ParseMessageLevel(value)
+		if !ok || level == types.Unknown {
+			return fmt.Errorf("%s must be a message level, got %q", key, value)
+		}
+
+		o.minLevel = level
+	case ParamLevelMap:
+		if strings.EqualFold(value, levelMapNone) {
+			o.levelMap, o.noLevelMap = nil, true
+
+			return nil
+		}
+
+		levelMap, err := parseLevelMap(value)
+		if err != nil {
+			return err
+		}
+
+		o.levelMap, o.noLevelMap = levelMap, false
+	case ParamOversize:
+		policy, err := parseOversizePolicy(value)
+		if err != nil || policy == "" {
+			return fmt.Errorf("%s must be split, truncate, reject or none, got %q", key, value)
+		}
+
+		o.oversize = policy
+	default:
+		return fmt.Errorf("unknown parameter %s", key)
+	}
+
+	return nil
+}
+
+// setOverrides records the router params of a service added to the router.
+func (r *ServiceRouter) setOverrides(service types.Service, overrides urlOverrides) {
+	if overrides.isZero() {
+		return
+	}
+
+	r.overMu.Lock()
+	defer r.overMu.Unlock()
+
+	if r.overrides == nil {
+		r.overrides = make(map[types.Service]urlOverrides)
+	}
+
+	r.overrides[service] = overrides
+}
+
+// urlOverrides returns the router params recorded for service.
+func (r *ServiceRouter) urlOverrides(service types.Service) (urlOverrides, bool) {
+	r.overMu.RLock()
+	defer r.overMu.RUnlock()
+
+	overrides, ok := r.overrides[service]
+
+	return overrides, ok
+}
+
+// sendPolicy returns the timeout and retry policy of the deliveries to service,
+// with the router params of its URL applied.
+//
+// Parameters:
+//   - service: the service being delivered to.
+//
+// Returns:
+//   - time.Duration: the timeout covering all attempts.
+//   - types.RetryPolicy: the retry policy.
+func (r *ServiceRouter) sendPolicy(service types.Service) (time.Duration, types.RetryPolicy) {
+	timeout, policy := r.Timeout, r.Retry
+
+	overrides, ok := r.urlOverrides(service)
+	if !ok {
+		return timeout, policy
+	}
+
+	if overrides.timeout > 0 {
+		timeout = overrides.timeout
+	}
+
+	if overrides.hasRetries {
+		policy.MaxAttempts = overrides.retries + 1
+
+		if policy.BaseBackoff <= 0 {
+			policy.BaseBackoff = DefaultRetryBackoff
+		}
+	}
+
+	return timeout, policy
+}
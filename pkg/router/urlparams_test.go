@@ -0,0 +1,205 @@
+package router
+
+import (
+	"errors"
+	"net/url"
+	"sync"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/nicholas-fedor/shoutrrr/pkg/types"
+)
+
+// urlService is a recording service that keeps the URL it was initialized with.
+type urlService struct {
+	recordingService
+
+	url *url.URL
+}
+
+func (s *urlService) Initialize(serviceURL *url.URL, _ types.StdLogger) error {
+	s.url = serviceURL
+
+	return nil
+}
+
+//nolint:paralleltest // Modifies shared serviceMap; cannot run in parallel.
+func TestURLParamsAreRemovedBeforeInitialize(t *testing.T) {
+	svc := &urlService{}
+
+	serviceMap["mock-url"] = func() types.Service { return svc }
+	defer delete(serviceMap, "mock-url")
+
+	_, err := NewWithOptions(nil, types.SenderOptions{},
+		"mock-url://host/path?title=Backup&__timeout=60s&__Retries=2&__minlevel=warning")
+	if err != nil {
+		t.Fatalf("NewWithOptions: %v", err)
+	}
+
+	if got, want := svc.url.String(), "mock-url://host/path?title=Backup"; got != want {
+		t.Errorf("service initialized with %q, want %q", got, want)
+	}
+}
+
+//nolint:paralleltest // Modifies shared serviceMap; cannot run in parallel.
+func TestURLParamsRejectInvalidValues(t *testing.T) {
+	serviceMap["mock-url"] = func() types.Service { return &urlService{} }
+	defer delete(serviceMap, "mock-url")
+
+	for _, query := range []string{
+		"__timeout=soon",
+		"__timeout=-1s",
+		"__retries=-1",
+		"__retries=many",
+		"__minlevel=loud",
+		"__minlevel=unknown",
+		"__oversize=shrink",
+		"__oversize=",
+		"__timeot=5s",
+	} {
+		t.Run(query, func(t *testing.T) {
+			_, err := NewWithOptions(nil, types.SenderOptions{}, "mock-url://host?"+query)
+			if !errors.Is(err, ErrInvalidRouterParam) {
+				t.Errorf("NewWithOptions = %v, want ErrInvalidRouterParam", err)
+			}
+		})
+	}
+}
+
+//nolint:paralleltest // Modifies shared serviceMap; cannot run in parallel.
+func TestURLParamsOverrideTimeout(t *testing.T) {
+	serviceMap["mock-block"] = func() types.Service { return &blockingService{} }
+	defer delete(serviceMap, "mock-block")
+
+	synctest.Test(t, func(t *testing.T) {
+		router, err := NewWithOptions(nil, types.SenderOptions{Timeout: time.Minute}, "mock-block://?__timeout=2s")
+		if err != nil {
+			t.Fatalf("NewWithOptions: %v", err)
+		}
+
+		start := time.Now()
+		errs := router.SendItems([]types.MessageItem{{Text: "deploying"}}, types.Params{})
+
+		if !errors.Is(errs[0], ErrServiceTimeout) {
+			t.Errorf("SendItems = %v, want ErrServiceTimeout", errs[0])
+		}
+
+		if elapsed := time.Since(start); elapsed != 2*time.Second {
+			t.Errorf("delivery timed out after %v, want the 2s URL timeout", elapsed)
+		}
+	})
+}
+
+//nolint:paralleltest // Modifies shared serviceMap; cannot run in parallel.
+func TestURLParamsSetRetries(t *testing.T) {
+	svc := &flakyService{errs: []error{&statusError{status: 503}, &statusError{status: 503}}}
+
+	serviceMap["mock-flaky"] = func() types.Service { return svc }
+	defer delete(serviceMap, "mock-flaky")
+
+	synctest.Test(t, func(t *testing.T) {
+		router, err := NewWithOptions(nil, types.SenderOptions{}, "mock-flaky://?__retries=2")
+		if err != nil {
+			t.Fatalf("NewWithOptions: %v", err)
+		}
+
+		if errs := router.Send("deployed", nil); errs[0] != nil {
+			t.Fatalf("Send: %v", errs[0])
+		}
+
+		if len(svc.attempts) != 3 {
+			t.Fatalf("got %d attempts, want 3", len(svc.attempts))
+		}
+
+		if wait := svc.attempts[1].Sub(svc.attempts[0]); wait != DefaultRetryBackoff {
+			t.Errorf("first retry after %v, want DefaultRetryBackoff", wait)
+		}
+	})
+}
+
+//nolint:paralleltest // Modifies shared serviceMap; cannot run in parallel.
+func TestURLParamsFilterByMinLevel(t *testing.T) {
+	svc := &recordingService{}
+	backup := &flakyService{}
+
+	serviceMap["mock-recording"] = func() types.Service { return svc }
+	serviceMap["mock-flaky"] = func() types.Service { return backup }
+
+	defer delete(serviceMap, "mock-recording")
+	defer delete(serviceMap, "mock-flaky")
+
+	router, err := NewWithOptions(nil, types.SenderOptions{}, "mock-recording://?__minlevel=warning")
+	if err != nil {
+		t.Fatalf("NewWithOptions: %v", err)
+	}
+
+	for _, level := range []types.MessageLevel{types.Info, types.Error} {
+		params := types.Params{}
+		params.SetLevel(level)
+		router.Send(level.String(), &params)
+	}
+
+	if got := svc.sent(); len(got) != 1 || got[0] != types.Error.String() {
+		t.Errorf("sent = %q, want only the error message", got)
+	}
+
+	err = router.AddGroup("oncall", GroupOptions{Mode: GroupFailover},
+		"mock-recording://?__minlevel=error", "mock-flaky://")
+	if err != nil {
+		t.Fatalf("AddGroup: %v", err)
+	}
+
+	params := types.Params{}
+	params.SetLevel(types.Warning)
+
+	result := router.SendGroup("oncall", "disk almost full", &params)
+	if result.Err != nil || len(result.DeliveredBy) != 1 || result.DeliveredBy[0] != 1 {
+		t.Errorf("SendGroup = %+v, want delivery by the second member only", result)
+	}
+}
+
+//nolint:paralleltest // Modifies shared serviceMap; cannot run in parallel.
+func TestURLParamsAreOnlyRecordedForAddedServices(t *testing.T) {
+	serviceMap["mock-url"] = func() types.Service { return &urlService{} }
+	defer delete(serviceMap, "mock-url")
+
+	router, err := NewWithOptions(nil, types.SenderOptions{}, "mock-url://host?__timeout=60s")
+	if err != nil {
+		t.Fatalf("NewWithOptions: %v", err)
+	}
+
+	var wg sync.WaitGroup
+
+	for range 8 {
+		wg.Go(func() {
+			if _, err := router.Locate("mock-url://host?__retries=2"); err != nil {
+				t.Errorf("Locate: %v", err)
+			}
+
+			router.Send("message", nil)
+		})
+	}
+
+	wg.Wait()
+
+	if got := len(router.overrides); got != 1 {
+		t.Errorf("router recorded the params of %d services, want only the added one", got)
+	}
+}
+
+func TestHasURLParams(t *testing.T) {
+	t.Parallel()
+
+	for rawURL, want := range map[string]bool{
+		"mock-url://host":                           false,
+		"mock-url://host?title=__init__":            false,
+		"mock-url://host?title=Backup&__timeout=5s": true,
+		"mock-url://host?__MinLevel=warning":        true,
+		"mock-url://host#__timeout=5s":              false,
+	} {
+		if got := HasURLParams(rawURL); got != want {
+			t.Errorf("HasURLParams(%q) = %v, want %v", rawURL, got, want)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+// Package secrets expands secret references in service URLs, so that tokens and
+// passwords do not have to be embedded in configuration files, shell history or
+// process listings.
+//
+// # References
+//
+// A reference has the form ${provider:ref} and is replaced by the secret the
+// provider resolves for ref. Built-in providers are:
+//
+//   - env: the value of an environment variable, e.g. ${env:SLACK_TOKEN}.
+//   - file: the contents of a file without trailing newlines, e.g.
+//     ${file:/run/secrets/pushover}.
+//
+// The cmd provider runs a command and uses its output without trailing newlines,
+// e.g. ${cmd:pass show pushover}. It is not registered by default, since it
+// executes whatever command the URL names; register it with
+// Register("cmd", Command()) when URLs come from a trusted source.
+//
+// A literal "${" is written as "$${". Expand inserts secrets verbatim; ExpandURL
+// percent-encodes them for the part of the URL they are inserted in, so that a
+// token containing "/", "+" or "@" cannot change how the URL is parsed.
+//
+// # Custom Providers
+//
+// Vaults and other secret stores are plugged in by registering a Resolver:
+//
+//	err := secrets.Register("vault", secrets.ResolverFunc(func(ctx context.Context, ref string) (string, error) {
+//	    return vaultClient.Read(ctx, ref)
+//	}))
+//
+// Routers expand references with ExpandURL when a service is added, before the
+// URL is parsed, if they are created with SenderOptions.ExpandSecrets. It is off
+// by default, as the env and file providers read local secrets into outbound
+// requests. Check validates references without resolving them.
+// Outbox target keys are derived from the unexpanded URL, so secrets resolved
+// this way never appear in them.
+package secrets
@@ -0,0 +1,64 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// Env returns the resolver of the env provider, which resolves a reference to the
+// value of the environment variable it names.
+//
+// Returns:
+//   - Resolver: the environment variable resolver.
+func Env() Resolver {
+	return ResolverFunc(func(_ context.Context, name string) (string, error) {
+		value, found := os.LookupEnv(name)
+		if !found {
+			return "", fmt.Errorf("%w: environment variable %q is not set", ErrSecretNotFound, name)
+		}
+
+		return value, nil
+	})
+}
+
+// File returns the resolver of the file provider, which resolves a reference to
+// the contents of the file at the path it names, without trailing newlines.
+//
+// Returns:
+//   - Resolver: the file resolver.
+func File() Resolver {
+	return ResolverFunc(func(_ context.Context, path string) (string, error) {
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("reading secret file: %w", err)
+		}
+
+		return strings.TrimRight(string(contents), "\r\n"), nil
+	})
+}
+
+// Command returns the resolver of the cmd provider, which runs the command line a
+// reference names and resolves to its output, without trailing newlines. The
+// command line is split on whitespace and run without a shell.
+//
+// Returns:
+//   - Resolver: the command resolver.
+func Command() Resolver {
+	return ResolverFunc(func(ctx context.Context, commandLine string) (string, error) {
+		args := strings.Fields(commandLine)
+		if len(args) == 0 {
+			return "", fmt.Errorf("%w: empty command", ErrInvalidReference)
+		}
+
+		//nolint:gosec // Running the referenced command is the purpose of the cmd provider.
+		output, err := exec.CommandContext(ctx, args[0], args[1:]...).Output()
+		if err != nil {
+			return "", fmt.Errorf("running secret command %q: %w", args[0], err)
+		}
+
+		return strings.TrimRight(string(output), "\r\n"), nil
+	})
+}
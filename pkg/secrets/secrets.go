@@ -0,0 +1,280 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+var (
+	ErrInvalidProvider       = errors.New("invalid secret provider name")
+	ErrNilResolver           = errors.New("secret resolver must not be nil")
+	ErrUnknownProvider       = errors.New("unknown secret provider")
+	ErrUnterminatedReference = errors.New("unterminated secret reference")
+	ErrInvalidReference      = errors.New("invalid secret reference")
+	ErrSecretNotFound        = errors.New("secret not found")
+)
+
+// Resolver resolves secret references for a provider.
+type Resolver interface {
+	// Resolve returns the secret identified by ref.
+	Resolve(ctx context.Context, ref string) (string, error)
+}
+
+// ResolverFunc adapts a function to the Resolver interface.
+type ResolverFunc func(ctx context.Context, ref string) (string, error)
+
+// Resolve calls f(ctx, ref).
+func (f ResolverFunc) Resolve(ctx context.Context, ref string) (string, error) {
+	return f(ctx, ref)
+}
+
+var (
+	// providerPattern matches valid provider names.
+	providerPattern = regexp.MustCompile(`^[a-z][a-z0-9_-]*$`)
+	// referencePattern matches a secret reference.
+	referencePattern = regexp.MustCompile(`\$\{[^}]*\}`)
+)
+
+var (
+	providersMu sync.RWMutex
+	providers   = map[string]Resolver{
+		"env":  Env(),
+		"file": File(),
+	}
+)
+
+// Register registers resolver for the provider name, replacing any resolver
+// previously registered for it, including built-in providers.
+//
+// Parameters:
+//   - provider: the lowercase provider name used in references, e.g. "vault".
+//   - resolver: the resolver of the provider's references.
+//
+// Returns:
+//   - error: an error if the provider name is invalid or resolver is nil.
+func Register(provider string, resolver Resolver) error {
+	if !providerPattern.MatchString(provider) {
+		return fmt.Errorf("%w: %q", ErrInvalidProvider, provider)
+	}
+
+	if resolver == nil {
+		return fmt.Errorf("%w: %q", ErrNilResolver, provider)
+	}
+
+	providersMu.Lock()
+	defer providersMu.Unlock()
+
+	providers[provider] = resolver
+
+	return nil
+}
+
+// Unregister removes the resolver registered for the provider name.
+//
+// Parameters:
+//   - provider: the provider name to remove.
+//
+// Returns:
+//   - bool: true if a resolver was registered for the provider.
+func Unregister(provider string) bool {
+	providersMu.Lock()
+	defer providersMu.Unlock()
+
+	_, ok := providers[provider]
+	delete(providers, provider)
+
+	return ok
+}
+
+// Providers returns the sorted names of the registered providers.
+//
+// Returns:
+//   - []string: the provider names.
+func Providers() []string {
+	providersMu.RLock()
+
+	names := make([]string, 0, len(providers))
+	for name := range providers {
+		names = append(names, name)
+	}
+
+	providersMu.RUnlock()
+
+	sort.Strings(names)
+
+	return names
+}
+
+// Expand replaces the ${provider:ref} references in s with the secrets they
+// resolve to, and "$${" with a literal "${".
+//
+// Parameters:
+//   - ctx: the context passed to the resolvers.
+//   - s: the string to expand, typically a service URL.
+//
+// Returns:
+//   - string: the expanded string.
+//   - error: an error if a reference is malformed, names an unknown provider or
+//     cannot be resolved. The error never contains a resolved secret.
+func Expand(ctx context.Context, s string) (string, error) {
+	return expand(s, func(reference, _, _ string) (string, error) {
+		return resolve(ctx, reference)
+	})
+}
+
+// ExpandURL is like Expand for a URL, escaping every secret for the part of the
+// URL it is inserted in, so that a secret containing delimiters such as '/', '+'
+// or '@' cannot change how the URL is parsed. Secrets in the userinfo, path,
+// query and fragment are percent-encoded; secrets in the scheme and host are
+// inserted verbatim.
+//
+// Parameters:
+//   - ctx: the context passed to the resolvers.
+//   - rawURL: the URL to expand.
+//
+// Returns:
+//   - string: the expanded URL.
+//   - error: an error if a reference is malformed, names an unknown provider or
+//     cannot be resolved. The error never contains a resolved secret.
+func ExpandURL(ctx context.Context, rawURL string) (string, error) {
+	return expand(rawURL, func(reference, before, after string) (string, error) {
+		secret, err := resolve(ctx, reference)
+		if err != nil {
+			return "", err
+		}
+
+		return escapeURLPart(secret, before, after), nil
+	})
+}
+
+// escapeURLPart escapes secret for the part of a URL it is inserted in, between
+// the expanded text before it and the unexpanded text after it.
+func escapeURLPart(secret, before, after string) string {
+	_, rest, found := strings.Cut(before, "://")
+
+	switch {
+	case !found:
+		return secret
+	case strings.ContainsAny(rest, "?#"):
+		return url.QueryEscape(secret)
+	case strings.Contains(rest, "/"):
+		return url.PathEscape(secret)
+	case strings.Contains(rest, "@"):
+		return secret
+	}
+
+	authority := referencePattern.ReplaceAllString(after, "")
+	if end := strings.IndexAny(authority, "/?#"); end >= 0 {
+		authority = authority[:end]
+	}
+
+	if !strings.Contains(authority, "@") {
+		return secret
+	}
+
+	// url.QueryEscape encodes a space as '+', which is literal in the userinfo.
+	return strings.ReplaceAll(url.QueryEscape(secret), "+", "%20")
+}
+
+// Check validates the ${provider:ref} references in s without resolving them,
+// so that no command is run and no file is read.
+//
+// Parameters:
+//   - s: the string to check, typically a service URL.
+//
+// Returns:
+//   - bool: true if s contains references.
+//   - error: an error if a reference is malformed or names an unknown provider.
+func Check(s string) (bool, error) {
+	found := false
+
+	_, err := expand(s, func(reference, _, _ string) (string, error) {
+		found = true
+		_, _, err := lookup(reference)
+
+		return "", err
+	})
+
+	return found, err
+}
+
+// expand replaces the references in s with the result of resolve, and "$${"
+// with a literal "${". resolve is passed the expanded text before the reference
+// and the unexpanded text after it.
+func expand(s string, resolve func(reference, before, after string) (string, error)) (string, error) {
+	if !strings.Contains(s, "${") {
+		return s, nil
+	}
+
+	var expanded strings.Builder
+
+	for {
+		start := strings.Index(s, "${")
+		if start < 0 {
+			expanded.WriteString(s)
+
+			return expanded.String(), nil
+		}
+
+		if start > 0 && s[start-1] == '$' {
+			expanded.WriteString(s[:start-1] + "${")
+			s = s[start+2:]
+
+			continue
+		}
+
+		expanded.WriteString(s[:start])
+
+		end := strings.IndexByte(s[start:], '}')
+		if end < 0 {
+			return "", ErrUnterminatedReference
+		}
+
+		secret, err := resolve(s[start+2:start+end], expanded.String(), s[start+end+1:])
+		if err != nil {
+			return "", err
+		}
+
+		expanded.WriteString(secret)
+		s = s[start+end+1:]
+	}
+}
+
+// resolve returns the secret of a reference of the form provider:ref.
+func resolve(ctx context.Context, reference string) (string, error) {
+	resolver, ref, err := lookup(reference)
+	if err != nil {
+		return "", err
+	}
+
+	secret, err := resolver.Resolve(ctx, ref)
+	if err != nil {
+		return "", fmt.Errorf("resolving ${%s}: %w", reference, err)
+	}
+
+	return secret, nil
+}
+
+// lookup returns the resolver and the ref of a reference of the form provider:ref.
+func lookup(reference string) (Resolver, string, error) {
+	provider, ref, found := strings.Cut(reference, ":")
+	if !found || ref == "" {
+		return nil, "", fmt.Errorf("%w: ${%s}", ErrInvalidReference, reference)
+	}
+
+	providersMu.RLock()
+	resolver, ok := providers[provider]
+	providersMu.RUnlock()
+
+	if !ok {
+		return nil, "", fmt.Errorf("%w: %q", ErrUnknownProvider, provider)
+	}
+
+	return resolver, ref, nil
+}
@@ -15,7 +15,7 @@ type Config struct {
 	standard.EnumlessConfig
 
 	WebhookID  string `url:"host"`
-	Token      string `url:"user"`
+	Token      string `url:"user" sensitive:"true"`
 	Title      string `           default:""         key:"title"`
 	Username   string `           default:""         key:"username"         desc:"Override the webhook default username"`
 	Avatar     string `           default:""         key:"avatar,avatarurl" desc:"Override the webhook default avatar with specified URL"`
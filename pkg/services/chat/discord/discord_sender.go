@@ -18,6 +18,7 @@ import (
 	"time"
 
 	"github.com/nicholas-fedor/shoutrrr/pkg/types"
+	"github.com/nicholas-fedor/shoutrrr/pkg/util/jsonclient"
 )
 
 // HTTPClient defines the interface for HTTP operations.
@@ -211,6 +212,10 @@ func executeWithTransportRetry(
 
 		maps.Copy(newReq.Header, req.Header)
 
+		if err := types.WaitRateLimit(newReq.Context(), newReq.URL.Hostname()); err != nil {
+			return nil, fmt.Errorf("waiting for rate limit: %w", err)
+		}
+
 		res, err := httpClient.Do(newReq)
 		if err != nil {
 			if isTransientError(err) && transportAttempt < maxTransportRetries {
@@ -234,6 +239,10 @@ func executeWithTransportRetry(
 			return nil, fmt.Errorf("making HTTP POST request: %w", err)
 		}
 
+		if res != nil {
+			types.ReportHTTPStatus(newReq.Context(), res.StatusCode)
+		}
+
 		return res, nil
 	}
 
@@ -255,7 +264,7 @@ func handleServerError(
 	if attempt >= maxRetries {
 		_ = res.Body.Close()
 
-		return ErrMaxRetries
+		return jsonclient.NewError(res, "", ErrMaxRetries)
 	}
 
 	wait := time.Duration(
@@ -268,7 +277,7 @@ func handleServerError(
 	if err := waitWithTimeout(ctx, wait, startTime, sleeper); err != nil {
 		_ = res.Body.Close()
 
-		return err
+		return jsonclient.NewError(res, "", err)
 	}
 
 	_ = res.Body.Close()
@@ -286,6 +295,9 @@ func sendWithRetry(
 ) error {
 	startTime := time.Now()
 
+	// The last response retried, whose status the error reports if retries run out.
+	var last *http.Response
+
 	for attempt := 0; attempt <= maxRetries; attempt++ {
 		// Check if we've exceeded the maximum retry timeout
 		if time.Since(startTime) > maxRetryTimeout {
@@ -329,6 +341,7 @@ func sendWithRetry(
 			}
 
 			_ = res.Body.Close()
+			last = res
 
 			continue
 		}
@@ -342,6 +355,7 @@ func sendWithRetry(
 
 		if res.StatusCode >= serverErrorStatusCode {
 			_ = res.Body.Close()
+			last = res
 
 			continue
 		}
@@ -349,7 +363,7 @@ func sendWithRetry(
 		if res.StatusCode != http.StatusNoContent && res.StatusCode != http.StatusOK {
 			_ = res.Body.Close()
 
-			return fmt.Errorf("%w: %s", ErrUnexpectedStatus, res.Status)
+			return jsonclient.NewError(res, "", fmt.Errorf("%w: %s", ErrUnexpectedStatus, res.Status))
 		}
 
 		_ = res.Body.Close()
@@ -357,6 +371,10 @@ func sendWithRetry(
 		return nil
 	}
 
+	if last != nil {
+		return jsonclient.NewError(last, "", ErrMaxRetries)
+	}
+
 	return ErrMaxRetries
 }
 
@@ -402,7 +420,7 @@ func handleRateLimitResponse(
 			if err := waitWithTimeout(ctx, wait, startTime, sleeper); err != nil {
 				_ = res.Body.Close()
 
-				return err
+				return jsonclient.NewError(res, "", err)
 			}
 
 			_ = res.Body.Close()
@@ -417,7 +435,7 @@ func handleRateLimitResponse(
 	if err := waitWithTimeout(ctx, wait, startTime, sleeper); err != nil {
 		_ = res.Body.Close()
 
-		return err
+		return jsonclient.NewError(res, "", err)
 	}
 
 	_ = res.Body.Close()
@@ -745,8 +745,11 @@ func TestHandleServerError(t *testing.T) {
 			err := handleServerError(context.Background(), resp, tt.attempt, startTime, sleeper)
 
 			if tt.expectedError {
-				require.Error(t, err)
-				assert.Equal(t, ErrMaxRetries, err)
+				require.ErrorIs(t, err, ErrMaxRetries)
+
+				var statusErr types.HTTPStatusError
+				require.ErrorAs(t, err, &statusErr)
+				assert.Equal(t, tt.statusCode, statusErr.HTTPStatus())
 			} else {
 				require.NoError(t, err)
 			}
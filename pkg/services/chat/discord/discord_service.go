@@ -5,7 +5,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/url"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/nicholas-fedor/shoutrrr/pkg/format"
 	"github.com/nicholas-fedor/shoutrrr/pkg/services/standard"
@@ -37,11 +39,30 @@ var limits = types.MessageLimit{
 	ChunkCount:     ChunkCount,
 }
 
+// rateLimit is the Discord webhook rate limit of 5 requests per 2 seconds.
+var rateLimit = types.RateLimit{Requests: 5, Interval: 2 * time.Second}
+
 // GetID provides the identifier for this service.
 func (s *Service) GetID() string {
 	return Scheme
 }
 
+// MessageLimit returns the payload limits of the Discord webhook API.
+func (s *Service) MessageLimit() types.MessageLimit {
+	return limits
+}
+
+// Markup returns the markup Discord messages are rendered to when the sender
+// declares the body format.
+func (s *Service) Markup() string {
+	return format.MarkupMarkdown
+}
+
+// RateLimit returns the request rate limit of a Discord webhook.
+func (s *Service) RateLimit() types.RateLimit {
+	return rateLimit
+}
+
 // Initialize configures the service with a URL and logger.
 func (s *Service) Initialize(serviceURL *url.URL, logger types.StdLogger) error {
 	s.SetLogger(logger)
@@ -68,6 +89,11 @@ func (s *Service) Initialize(serviceURL *url.URL, logger types.StdLogger) error
 
 // Send delivers a notification message to Discord.
 func (s *Service) Send(message string, params *types.Params) error {
+	return s.SendContext(context.Background(), message, params)
+}
+
+// SendContext delivers a notification message to Discord, aborting when ctx is done.
+func (s *Service) SendContext(ctx context.Context, message string, params *types.Params) error {
 	if message == "" {
 		return ErrEmptyMessage
 	}
@@ -76,7 +102,7 @@ func (s *Service) Send(message string, params *types.Params) error {
 
 	if s.Config.JSON {
 		postURL := CreatePostURLFromConfig(s.Config)
-		if err := s.doSend([]byte(message), postURL); err != nil {
+		if err := s.doSend(ctx, []byte(message), postURL); err != nil {
 			return fmt.Errorf("sending JSON message: %w", err)
 		}
 	} else {
@@ -85,9 +111,16 @@ func (s *Service) Send(message string, params *types.Params) error {
 			return fmt.Errorf("updating config from params: %w", err)
 		}
 
+		message, _, err := format.RenderBody(message, params, s.Markup())
+		if err != nil {
+			return fmt.Errorf("rendering message: %w", err)
+		}
+
 		batches := CreateItemsFromPlain(message, config.SplitLines)
+		setItemsLevel(batches, params)
+
 		for _, batch := range batches {
-			if err := s.sendItems(batch, params); err != nil {
+			if err := s.sendItems(ctx, batch, params); err != nil {
 				s.Log(err)
 
 				if firstErr == nil {
@@ -106,7 +139,22 @@ func (s *Service) Send(message string, params *types.Params) error {
 
 // SendItems delivers message items with enhanced metadata and formatting to Discord.
 func (s *Service) SendItems(items []types.MessageItem, params *types.Params) error {
-	return s.sendItems(items, params)
+	items, err := s.renderItems(items, params)
+	if err != nil {
+		return err
+	}
+
+	return s.sendItems(context.Background(), items, params)
+}
+
+// SendItemsContext delivers message items to Discord, aborting when ctx is done.
+func (s *Service) SendItemsContext(ctx context.Context, items []types.MessageItem, params types.Params) error {
+	items, err := s.renderItems(items, &params)
+	if err != nil {
+		return err
+	}
+
+	return s.sendItems(ctx, items, &params)
 }
 
 // SetHTTPClient sets a custom HTTP client for the service.
@@ -115,13 +163,11 @@ func (s *Service) SetHTTPClient(client types.HTTPClient) {
 }
 
 // doSend executes an HTTP POST request to deliver the payload to Discord.
-func (s *Service) doSend(payload []byte, postURL string) error {
+func (s *Service) doSend(ctx context.Context, payload []byte, postURL string) error {
 	if err := validateDiscordWebhookURL(postURL); err != nil {
 		return err
 	}
 
-	ctx := context.Background()
-
 	preparer := &JSONRequestPreparer{payload: payload}
 
 	return sendWithRetry(ctx, preparer, postURL, s.HTTPClient, s.Sleeper)
@@ -129,6 +175,7 @@ func (s *Service) doSend(payload []byte, postURL string) error {
 
 // doSendMultipart executes an HTTP POST request with multipart/form-data to deliver payload and files to Discord.
 func (s *Service) doSendMultipart(
+	ctx context.Context,
 	payload *WebhookPayload,
 	files []types.File,
 	postURL string,
@@ -137,8 +184,6 @@ func (s *Service) doSendMultipart(
 		return err
 	}
 
-	ctx := context.Background()
-
 	preparer := &MultipartRequestPreparer{
 		payload: payload,
 		files:   files,
@@ -147,7 +192,7 @@ func (s *Service) doSendMultipart(
 	return sendWithRetry(ctx, preparer, postURL, s.HTTPClient, s.Sleeper)
 }
 
-func (s *Service) sendItems(items []types.MessageItem, params *types.Params) error {
+func (s *Service) sendItems(ctx context.Context, items []types.MessageItem, params *types.Params) error {
 	config := *s.Config
 	if err := s.pkr.UpdateConfigFromParams(&config, params); err != nil {
 		return fmt.Errorf("updating config from params: %w", err)
@@ -183,7 +228,7 @@ func (s *Service) sendItems(items []types.MessageItem, params *types.Params) err
 	hasFiles := len(files) > 0
 
 	if hasFiles {
-		return s.doSendMultipart(&payload, files, postURL)
+		return s.doSendMultipart(ctx, &payload, files, postURL)
 	}
 
 	payloadBytes, err := json.Marshal(payload)
@@ -191,7 +236,51 @@ func (s *Service) sendItems(items []types.MessageItem, params *types.Params) err
 		return fmt.Errorf("marshaling payload to JSON: %w", err)
 	}
 
-	return s.doSend(payloadBytes, postURL)
+	return s.doSend(ctx, payloadBytes, postURL)
+}
+
+// renderItems returns a copy of the items with their text rendered to markdown,
+// if the sender declared the body format.
+func (s *Service) renderItems(items []types.MessageItem, params *types.Params) ([]types.MessageItem, error) {
+	if params == nil {
+		return items, nil
+	}
+
+	if _, found := (*params)[types.FormatKey]; !found {
+		return items, nil
+	}
+
+	rendered := slices.Clone(items)
+
+	for i := range rendered {
+		text, _, err := format.RenderBody(rendered[i].Text, params, s.Markup())
+		if err != nil {
+			return nil, fmt.Errorf("rendering message: %w", err)
+		}
+
+		rendered[i].Text = text
+	}
+
+	return rendered, nil
+}
+
+// setItemsLevel sets the level of the plain message items to the level param, so
+// that they are colored with the configured level colors.
+func setItemsLevel(batches [][]types.MessageItem, params *types.Params) {
+	if params == nil {
+		return
+	}
+
+	level, ok := params.Level()
+	if !ok {
+		return
+	}
+
+	for _, batch := range batches {
+		for i := range batch {
+			batch[i].Level = level
+		}
+	}
 }
 
 // CreateItemsFromPlain converts plain text into MessageItems suitable for Discord's webhook payload.
@@ -1,6 +1,7 @@
 package discord
 
 import (
+	"context"
 	"errors"
 	"io"
 	"net/http"
@@ -118,6 +119,35 @@ var _ = ginkgo.Describe("Discord Service Unit Tests", func() {
 			mockClient.AssertNumberOfCalls(ginkgo.GinkgoT(), "Do", 1)
 		})
 
+		ginkgo.It("should color plain messages by the level param", func() {
+			serviceURL, _ := url.Parse("discord://token@webhook?colorError=0xff0000")
+			gomega.Expect(service.Initialize(serviceURL, &mockTypes.MockStdLogger{})).To(gomega.Succeed())
+
+			mockClient := mocks.NewMockHTTPClient(ginkgo.GinkgoT())
+			service.HTTPClient = mockClient
+
+			var body string
+
+			mockClient.On("Do", mock.Anything).Run(func(args mock.Arguments) {
+				req, ok := args.Get(0).(*http.Request)
+				gomega.Expect(ok).To(gomega.BeTrue())
+
+				data, err := io.ReadAll(req.Body)
+				gomega.Expect(err).ToNot(gomega.HaveOccurred())
+
+				body = string(data)
+			}).Return(&http.Response{
+				StatusCode: http.StatusNoContent,
+				Body:       io.NopCloser(strings.NewReader("")),
+			}, nil)
+
+			err := service.Send("Disk full", &types.Params{"level": "error"})
+
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			gomega.Expect(body).To(gomega.ContainSubstring(`"color":16711680`))
+			gomega.Expect(body).To(gomega.ContainSubstring(`"text":"Error"`))
+		})
+
 		ginkgo.It("should return error when first batch fails", func() {
 			mockClient := mocks.NewMockHTTPClient(ginkgo.GinkgoT())
 			mockLogger := &mockTypes.MockStdLogger{}
@@ -189,7 +219,7 @@ var _ = ginkgo.Describe("Discord Service Unit Tests", func() {
 			}
 			params := &types.Params{}
 
-			err := service.sendItems(items, params)
+			err := service.sendItems(context.Background(), items, params)
 
 			gomega.Expect(err).ToNot(gomega.HaveOccurred())
 			mockClient.AssertNumberOfCalls(ginkgo.GinkgoT(), "Do", 1)
@@ -214,7 +244,7 @@ var _ = ginkgo.Describe("Discord Service Unit Tests", func() {
 			}
 			params := &types.Params{}
 
-			err := service.sendItems(items, params)
+			err := service.sendItems(context.Background(), items, params)
 
 			gomega.Expect(err).ToNot(gomega.HaveOccurred())
 			mockClient.AssertNumberOfCalls(ginkgo.GinkgoT(), "Do", 1)
@@ -237,7 +267,7 @@ var _ = ginkgo.Describe("Discord Service Unit Tests", func() {
 				"username": "CustomBot",
 			}
 
-			err := service.sendItems(items, params)
+			err := service.sendItems(context.Background(), items, params)
 
 			gomega.Expect(err).ToNot(gomega.HaveOccurred())
 			// The payload should include the updated username
@@ -247,7 +277,7 @@ var _ = ginkgo.Describe("Discord Service Unit Tests", func() {
 			items := []types.MessageItem{}
 			params := &types.Params{}
 
-			err := service.sendItems(items, params)
+			err := service.sendItems(context.Background(), items, params)
 
 			gomega.Expect(err).To(gomega.HaveOccurred())
 			gomega.Expect(err).To(gomega.MatchError(ErrEmptyMessage))
@@ -264,7 +294,7 @@ var _ = ginkgo.Describe("Discord Service Unit Tests", func() {
 				"invalid_key": "value",
 			}
 
-			err := service.sendItems(items, params)
+			err := service.sendItems(context.Background(), items, params)
 
 			gomega.Expect(err).To(gomega.HaveOccurred())
 			gomega.Expect(err.Error()).To(gomega.ContainSubstring("updating config from params"))
@@ -410,7 +440,7 @@ var _ = ginkgo.Describe("Discord Service Unit Tests", func() {
 			payload := []byte(`{"content":"test"}`)
 			postURL := testWebhookURLAlt
 
-			err := service.doSend(payload, postURL)
+			err := service.doSend(context.Background(), payload, postURL)
 
 			gomega.Expect(err).ToNot(gomega.HaveOccurred())
 			mockClient.AssertNumberOfCalls(ginkgo.GinkgoT(), "Do", 1)
@@ -420,7 +450,7 @@ var _ = ginkgo.Describe("Discord Service Unit Tests", func() {
 			payload := []byte(`{"content":"test"}`)
 			postURL := ""
 
-			err := service.doSend(payload, postURL)
+			err := service.doSend(context.Background(), payload, postURL)
 
 			gomega.Expect(err).To(gomega.MatchError(ErrEmptyURL))
 		})
@@ -429,7 +459,7 @@ var _ = ginkgo.Describe("Discord Service Unit Tests", func() {
 			payload := []byte(`{"content":"test"}`)
 			postURL := "http://" + testWebhookURLAlt[8:] // Change https to http
 
-			err := service.doSend(payload, postURL)
+			err := service.doSend(context.Background(), payload, postURL)
 
 			gomega.Expect(err).To(gomega.MatchError(ErrInvalidScheme))
 		})
@@ -438,7 +468,7 @@ var _ = ginkgo.Describe("Discord Service Unit Tests", func() {
 			payload := []byte(`{"content":"test"}`)
 			postURL := "https://example.com" + testWebhookURLAlt[20:] // Replace discord.com with example.com
 
-			err := service.doSend(payload, postURL)
+			err := service.doSend(context.Background(), payload, postURL)
 
 			gomega.Expect(err).To(gomega.MatchError(ErrInvalidHost))
 		})
@@ -447,7 +477,7 @@ var _ = ginkgo.Describe("Discord Service Unit Tests", func() {
 			payload := []byte(`{"content":"test"}`)
 			postURL := "https://discord.com/api/invalid" + testWebhookURLAlt[28:] // Replace /webhooks with /invalid
 
-			err := service.doSend(payload, postURL)
+			err := service.doSend(context.Background(), payload, postURL)
 
 			gomega.Expect(err).To(gomega.MatchError(ErrInvalidURLPrefix))
 		})
@@ -456,7 +486,7 @@ var _ = ginkgo.Describe("Discord Service Unit Tests", func() {
 			payload := []byte(`{"content":"test"}`)
 			postURL := "https://discord.com/api/webhooks//"
 
-			err := service.doSend(payload, postURL)
+			err := service.doSend(context.Background(), payload, postURL)
 
 			gomega.Expect(err).To(gomega.MatchError(ErrMalformedURL))
 		})
@@ -469,7 +499,7 @@ var _ = ginkgo.Describe("Discord Service Unit Tests", func() {
 			payload := []byte(`{"content":"test"}`)
 			postURL := testWebhookURLAlt
 
-			err := service.doSend(payload, postURL)
+			err := service.doSend(context.Background(), payload, postURL)
 
 			gomega.Expect(err).To(gomega.HaveOccurred())
 		})
@@ -498,7 +528,7 @@ var _ = ginkgo.Describe("Discord Service Unit Tests", func() {
 			}
 			postURL := testWebhookURLAlt
 
-			err := service.doSendMultipart(&payload, files, postURL)
+			err := service.doSendMultipart(context.Background(), &payload, files, postURL)
 
 			gomega.Expect(err).ToNot(gomega.HaveOccurred())
 			mockClient.AssertNumberOfCalls(ginkgo.GinkgoT(), "Do", 1)
@@ -518,7 +548,7 @@ var _ = ginkgo.Describe("Discord Service Unit Tests", func() {
 			files := []types.File{}
 			postURL := testWebhookURLAlt
 
-			err := service.doSendMultipart(&payload, files, postURL)
+			err := service.doSendMultipart(context.Background(), &payload, files, postURL)
 
 			gomega.Expect(err).ToNot(gomega.HaveOccurred())
 			mockClient.AssertNumberOfCalls(ginkgo.GinkgoT(), "Do", 1)
@@ -535,7 +565,7 @@ var _ = ginkgo.Describe("Discord Service Unit Tests", func() {
 			files := []types.File{}
 			postURL := testWebhookURLAlt
 
-			err := service.doSendMultipart(&payload, files, postURL)
+			err := service.doSendMultipart(context.Background(), &payload, files, postURL)
 
 			gomega.Expect(err).To(gomega.HaveOccurred())
 		})
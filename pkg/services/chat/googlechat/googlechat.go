@@ -32,6 +32,11 @@ func (s *Service) GetID() string {
 	return Scheme
 }
 
+// MessageLimit returns the message limit of Google Chat, 4,096 characters of text.
+func (s *Service) MessageLimit() types.MessageLimit {
+	return types.MessageLimit{ChunkSize: 4096, TotalChunkSize: 4096, ChunkCount: 1}
+}
+
 // Initialize configures the service with a URL and logger.
 func (s *Service) Initialize(serviceURL *url.URL, logger types.StdLogger) error {
 	s.SetLogger(logger)
@@ -46,7 +51,12 @@ func (s *Service) Initialize(serviceURL *url.URL, logger types.StdLogger) error
 }
 
 // Send delivers a notification message to Google Chat.
-func (s *Service) Send(message string, _ *types.Params) error {
+func (s *Service) Send(message string, params *types.Params) error {
+	return s.SendContext(context.Background(), message, params)
+}
+
+// SendContext delivers a notification message to Google Chat, aborting when ctx is done.
+func (s *Service) SendContext(ctx context.Context, message string, _ *types.Params) error {
 	config := s.Config
 
 	jsonBody, err := json.Marshal(JSON{Text: message})
@@ -58,7 +68,7 @@ func (s *Service) Send(message string, _ *types.Params) error {
 	jsonBuffer := bytes.NewBuffer(jsonBody)
 
 	req, err := http.NewRequestWithContext(
-		context.Background(),
+		ctx,
 		http.MethodPost,
 		postURL.String(),
 		jsonBuffer,
@@ -69,11 +79,17 @@ func (s *Service) Send(message string, _ *types.Params) error {
 
 	req.Header.Set("Content-Type", "application/json")
 
+	if err := types.WaitRateLimit(req.Context(), req.URL.Hostname()); err != nil {
+		return fmt.Errorf("waiting for rate limit: %w", err)
+	}
+
 	resp, err := s.httpClient.Do(req)
 	if err != nil {
 		return fmt.Errorf("sending notification to Google Chat: %w", err)
 	}
 
+	types.ReportHTTPStatus(req.Context(), resp.StatusCode)
+
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
@@ -14,6 +14,7 @@ import (
 	"net/url"
 	"strconv"
 	"time"
+	"unicode/utf8"
 
 	"github.com/nicholas-fedor/shoutrrr/pkg/format"
 	"github.com/nicholas-fedor/shoutrrr/pkg/services/standard"
@@ -61,6 +62,16 @@ func (s *Service) GetID() string {
 	return Scheme
 }
 
+// MessageLimit returns the message limit of Lark. The API limits messages to
+// maxLength bytes, and a rune takes up to utf8.UTFMax bytes.
+func (s *Service) MessageLimit() types.MessageLimit {
+	return types.MessageLimit{
+		ChunkSize:      maxLength / utf8.UTFMax,
+		TotalChunkSize: maxLength / utf8.UTFMax,
+		ChunkCount:     1,
+	}
+}
+
 // Initialize configures the service with a URL and logger.
 func (s *Service) Initialize(serviceURL *url.URL, logger types.StdLogger) error {
 	s.SetLogger(logger)
@@ -72,6 +83,11 @@ func (s *Service) Initialize(serviceURL *url.URL, logger types.StdLogger) error
 
 // Send delivers a notification message to Lark.
 func (s *Service) Send(message string, params *types.Params) error {
+	return s.SendContext(context.Background(), message, params)
+}
+
+// SendContext delivers a notification message to Lark, aborting when ctx is done.
+func (s *Service) SendContext(ctx context.Context, message string, params *types.Params) error {
 	if len(message) > maxLength {
 		return ErrLargeMessage
 	}
@@ -89,7 +105,7 @@ func (s *Service) Send(message string, params *types.Params) error {
 		return ErrNoPath
 	}
 
-	return s.doSend(&config, message, params)
+	return s.doSend(ctx, &config, message, params)
 }
 
 // SetHTTPClient sets a custom HTTP client for the service.
@@ -98,7 +114,7 @@ func (s *Service) SetHTTPClient(client types.HTTPClient) {
 }
 
 // doSend sends the notification to Lark using the configured API URL.
-func (s *Service) doSend(config *Config, message string, params *types.Params) error {
+func (s *Service) doSend(ctx context.Context, config *Config, message string, params *types.Params) error {
 	if config.Host == "" {
 		return ErrMissingHost
 	}
@@ -110,7 +126,7 @@ func (s *Service) doSend(config *Config, message string, params *types.Params) e
 		return err
 	}
 
-	return s.sendRequest(postURL, payload)
+	return s.sendRequest(ctx, postURL, payload)
 }
 
 // genSign generates a signature for the request using the secret and timestamp.
@@ -236,9 +252,9 @@ func (s *Service) preparePayload(
 }
 
 // sendRequest performs the HTTP POST request to the Lark API and handles the response.
-func (s *Service) sendRequest(postURL string, payload []byte) error {
+func (s *Service) sendRequest(ctx context.Context, postURL string, payload []byte) error {
 	req, err := http.NewRequestWithContext(
-		context.Background(),
+		ctx,
 		http.MethodPost,
 		postURL,
 		bytes.NewReader(payload),
@@ -251,11 +267,17 @@ func (s *Service) sendRequest(postURL string, payload []byte) error {
 
 	client := s.httpClientOrDefault()
 
+	if err := types.WaitRateLimit(req.Context(), req.URL.Hostname()); err != nil {
+		return fmt.Errorf("waiting for rate limit: %w", err)
+	}
+
 	resp, err := client.Do(req)
 	if err != nil {
 		return fmt.Errorf("%w: making HTTP request: %w", ErrSendFailed, err)
 	}
 
+	types.ReportHTTPStatus(req.Context(), resp.StatusCode)
+
 	defer func() { _ = resp.Body.Close() }()
 
 	return s.handleResponse(resp)
@@ -37,6 +37,15 @@ type apiResLogin struct {
 type apiReqSend struct {
 	MsgType messageType `json:"msgtype"`
 	Body    string      `json:"body"`
+
+	// Format and FormattedBody carry an HTML rendering of Body, if any.
+	Format        string `json:"format,omitempty"`
+	FormattedBody string `json:"formatted_body,omitempty"`
+}
+
+// textMessage returns a request sending body as a plain text message.
+func textMessage(body string) apiReqSend {
+	return apiReqSend{MsgType: msgTypeText, Body: body}
 }
 
 // apiResRoom represents the response from joining a Matrix room.
@@ -74,6 +83,7 @@ type identifier struct {
 const (
 	// Matrix API endpoint paths.
 	apiLogin       = "/_matrix/client/v3/login"
+	apiLogout      = "/_matrix/client/v3/logout"
 	apiRoomJoin    = "/_matrix/client/v3/join/%s"
 	apiSendMessage = "/_matrix/client/v3/rooms/%s/send/m.room.message/%s"
 	apiJoinedRooms = "/_matrix/client/v3/joined_rooms"
@@ -82,6 +92,8 @@ const (
 
 	// msgTypeText is the Matrix message type for plain text messages.
 	msgTypeText messageType = "m.text"
+	// messageFormatHTML is the format of HTML formatted message bodies.
+	messageFormatHTML = "org.matrix.custom.html"
 	// flowLoginPassword is the Matrix login flow type for password authentication.
 	flowLoginPassword flowType = "m.login.password"
 	//nolint:gosec // This is a Matrix API constant, not a hardcoded credential
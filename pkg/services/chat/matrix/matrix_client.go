@@ -29,6 +29,7 @@ type HTTPClient interface {
 type client struct {
 	apiURL      url.URL
 	accessToken string
+	loggedIn    bool
 	logger      types.StdLogger
 	httpClient  HTTPClient
 }
@@ -139,6 +140,10 @@ func (c *client) apiGet(ctx context.Context, path string, response any) error {
 
 	c.setAuthorizationHeader(req)
 
+	if err := types.WaitRateLimit(req.Context(), req.URL.Hostname()); err != nil {
+		return fmt.Errorf("waiting for rate limit: %w", err)
+	}
+
 	res, err := c.httpClient.Do(req)
 	if err != nil {
 		return fmt.Errorf("executing GET request: %w", err)
@@ -212,11 +217,17 @@ func (c *client) doSingleRequest(ctx context.Context, method, path string, reque
 	req.Header.Set("Content-Type", contentType)
 	c.setAuthorizationHeader(req)
 
+	if err := types.WaitRateLimit(req.Context(), req.URL.Hostname()); err != nil {
+		return fmt.Errorf("waiting for rate limit: %w", err)
+	}
+
 	res, err := c.httpClient.Do(req)
 	if err != nil {
 		return fmt.Errorf("executing %s request: %w", method, err)
 	}
 
+	types.ReportHTTPStatus(req.Context(), res.StatusCode)
+
 	defer func() { _ = res.Body.Close() }()
 
 	body, err = io.ReadAll(res.Body)
@@ -338,6 +349,7 @@ func (c *client) loginPassword(ctx context.Context, user, password string) error
 	}
 
 	c.accessToken = response.AccessToken
+	c.loggedIn = true
 
 	tokenHint := ""
 	if len(response.AccessToken) > tokenHintLength {
@@ -377,6 +389,7 @@ func (c *client) loginToken(ctx context.Context, token string) error {
 	}
 
 	c.accessToken = response.AccessToken
+	c.loggedIn = true
 
 	tokenHint := ""
 	if len(response.AccessToken) > tokenHintLength {
@@ -391,8 +404,25 @@ func (c *client) loginToken(ctx context.Context, token string) error {
 	return nil
 }
 
+// logout ends the session created by login, invalidating its access token.
+// Access tokens supplied by the user are not logged out.
+func (c *client) logout(ctx context.Context) error {
+	if !c.loggedIn {
+		return nil
+	}
+
+	if err := c.apiPost(ctx, apiLogout, struct{}{}, &struct{}{}); err != nil {
+		return fmt.Errorf("failed to log out: %w", err)
+	}
+
+	c.accessToken = ""
+	c.loggedIn = false
+
+	return nil
+}
+
 // sendMessage sends a message to the specified rooms or all joined rooms if none are specified.
-func (c *client) sendMessage(ctx context.Context, message string, rooms []string) []error {
+func (c *client) sendMessage(ctx context.Context, message apiReqSend, rooms []string) []error {
 	if len(rooms) >= minSliceLength {
 		return c.sendToExplicitRooms(ctx, rooms, message)
 	}
@@ -401,22 +431,17 @@ func (c *client) sendMessage(ctx context.Context, message string, rooms []string
 }
 
 // sendMessageToRoom sends a message to a specific room using PUT method with transaction ID.
-func (c *client) sendMessageToRoom(ctx context.Context, message, roomID string) error {
+func (c *client) sendMessageToRoom(ctx context.Context, message apiReqSend, roomID string) error {
 	resEvent := apiResEvent{}
 	txnID := generateTransactionID()
 
 	path := fmt.Sprintf(apiSendMessage, roomID, txnID)
 
-	request := apiReqSend{
-		MsgType: msgTypeText,
-		Body:    message,
-	}
-
-	return c.apiPut(ctx, path, request, &resEvent)
+	return c.apiPut(ctx, path, message, &resEvent)
 }
 
 // sendToExplicitRooms sends a message to explicitly specified rooms and collects any errors.
-func (c *client) sendToExplicitRooms(ctx context.Context, rooms []string, message string) []error {
+func (c *client) sendToExplicitRooms(ctx context.Context, rooms []string, message apiReqSend) []error {
 	var errs []error
 
 	for _, room := range rooms {
@@ -456,7 +481,7 @@ func (c *client) sendToExplicitRooms(ctx context.Context, rooms []string, messag
 }
 
 // sendToJoinedRooms sends a message to all joined rooms and collects any errors.
-func (c *client) sendToJoinedRooms(ctx context.Context, message string) []error {
+func (c *client) sendToJoinedRooms(ctx context.Context, message apiReqSend) []error {
 	var errs []error
 
 	joinedRooms, err := c.getJoinedRooms(ctx)
@@ -13,7 +13,9 @@ import (
 
 	ginkgo "github.com/onsi/ginkgo/v2"
 
+	"github.com/nicholas-fedor/shoutrrr/pkg/format"
 	"github.com/nicholas-fedor/shoutrrr/pkg/services/chat/matrix/mocks"
+	"github.com/nicholas-fedor/shoutrrr/pkg/types"
 )
 
 // testLogger is a simple logger for testing purposes.
@@ -443,6 +445,63 @@ var _ = ginkgo.Describe("client", func() {
 		})
 	})
 
+	ginkgo.Describe("logout", func() {
+		ginkgo.It("should log out a session created by login", func() {
+			mockHTTPClient := mocks.NewMockHTTPClient(ginkgo.GinkgoT())
+			mockHTTPClient.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+				return req.Method == http.MethodPost &&
+					req.URL.Path == "/_matrix/client/v3/logout" &&
+					req.Header.Get("Authorization") == "Bearer mytoken123"
+			})).Return(&http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(bytes.NewReader([]byte(`{}`))),
+			}, nil).Once()
+
+			c := &client{
+				apiURL: url.URL{
+					Scheme: "https",
+					Host:   "matrix.example.com",
+				},
+				accessToken: "mytoken123",
+				loggedIn:    true,
+				httpClient:  mockHTTPClient,
+				logger:      &testLogger{},
+			}
+
+			gomega.Expect(c.logout(context.Background())).To(gomega.Succeed())
+			gomega.Expect(c.accessToken).To(gomega.BeEmpty())
+			gomega.Expect(c.logout(context.Background())).To(gomega.Succeed())
+		})
+
+		ginkgo.It("should keep a user-supplied access token", func() {
+			mockHTTPClient := mocks.NewMockHTTPClient(ginkgo.GinkgoT())
+
+			c := &client{httpClient: mockHTTPClient, logger: &testLogger{}}
+			c.useToken("usertoken")
+
+			gomega.Expect(c.logout(context.Background())).To(gomega.Succeed())
+			gomega.Expect(c.accessToken).To(gomega.Equal("usertoken"))
+		})
+
+		ginkgo.It("should return error when API call fails", func() {
+			mockHTTPClient := mocks.NewMockHTTPClient(ginkgo.GinkgoT())
+			mockHTTPClient.On("Do", mock.AnythingOfType("*http.Request")).Return(nil, errors.New("mock error"))
+
+			c := &client{
+				apiURL: url.URL{
+					Scheme: "https",
+					Host:   "matrix.example.com",
+				},
+				loggedIn:   true,
+				httpClient: mockHTTPClient,
+				logger:     &testLogger{},
+			}
+
+			err := c.logout(context.Background())
+			gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("failed to log out")))
+		})
+	})
+
 	ginkgo.Describe("loginToken", func() {
 		ginkgo.It("should return error when API call fails", func() {
 			mockHTTPClient := mocks.NewMockHTTPClient(ginkgo.GinkgoT())
@@ -497,7 +556,7 @@ var _ = ginkgo.Describe("client", func() {
 				logger:     &testLogger{},
 			}
 
-			errs := c.sendMessage(context.Background(), "test message", nil)
+			errs := c.sendMessage(context.Background(), textMessage("test message"), nil)
 			gomega.Expect(errs).To(gomega.HaveLen(1))
 		})
 
@@ -514,7 +573,7 @@ var _ = ginkgo.Describe("client", func() {
 				logger:     &testLogger{},
 			}
 
-			errs := c.sendMessage(context.Background(), "test message", []string{"#room:matrix.example.com"})
+			errs := c.sendMessage(context.Background(), textMessage("test message"), []string{"#room:matrix.example.com"})
 			gomega.Expect(errs).To(gomega.HaveLen(1))
 		})
 	})
@@ -533,7 +592,7 @@ var _ = ginkgo.Describe("client", func() {
 				logger:     &testLogger{},
 			}
 
-			err := c.sendMessageToRoom(context.Background(), "test message", "!room:matrix.example.com")
+			err := c.sendMessageToRoom(context.Background(), textMessage("test message"), "!room:matrix.example.com")
 			gomega.Expect(err).To(gomega.HaveOccurred())
 		})
 	})
@@ -552,7 +611,7 @@ var _ = ginkgo.Describe("client", func() {
 				logger:     &testLogger{},
 			}
 
-			errs := c.sendToExplicitRooms(context.Background(), []string{"#room:matrix.example.com"}, "test message")
+			errs := c.sendToExplicitRooms(context.Background(), []string{"#room:matrix.example.com"}, textMessage("test message"))
 			gomega.Expect(errs).To(gomega.HaveLen(1))
 		})
 
@@ -578,7 +637,7 @@ var _ = ginkgo.Describe("client", func() {
 				logger:     &testLogger{},
 			}
 
-			errs := c.sendToExplicitRooms(context.Background(), []string{"#room:matrix.example.com"}, "test message")
+			errs := c.sendToExplicitRooms(context.Background(), []string{"#room:matrix.example.com"}, textMessage("test message"))
 			gomega.Expect(errs).To(gomega.BeEmpty())
 		})
 
@@ -601,7 +660,7 @@ var _ = ginkgo.Describe("client", func() {
 				logger:     &testLogger{},
 			}
 
-			errs := c.sendToExplicitRooms(context.Background(), []string{"#room:matrix.example.com"}, "test message")
+			errs := c.sendToExplicitRooms(context.Background(), []string{"#room:matrix.example.com"}, textMessage("test message"))
 			gomega.Expect(errs).To(gomega.HaveLen(1))
 		})
 
@@ -622,7 +681,7 @@ var _ = ginkgo.Describe("client", func() {
 				logger:     &testLogger{},
 			}
 
-			errs := c.sendToExplicitRooms(context.Background(), []string{"!roomid:matrix.example.com"}, "test message")
+			errs := c.sendToExplicitRooms(context.Background(), []string{"!roomid:matrix.example.com"}, textMessage("test message"))
 			gomega.Expect(errs).To(gomega.BeEmpty())
 			mockHTTPClient.AssertNumberOfCalls(ginkgo.GinkgoT(), "Do", 1)
 		})
@@ -654,7 +713,7 @@ var _ = ginkgo.Describe("client", func() {
 				logger:     &testLogger{},
 			}
 
-			errs := c.sendToExplicitRooms(context.Background(), []string{"#room:matrix.example.com", "!otherroom:matrix.example.com"}, "test message")
+			errs := c.sendToExplicitRooms(context.Background(), []string{"#room:matrix.example.com", "!otherroom:matrix.example.com"}, textMessage("test message"))
 			gomega.Expect(errs).To(gomega.BeEmpty())
 			mockHTTPClient.AssertNumberOfCalls(ginkgo.GinkgoT(), "Do", 3)
 		})
@@ -668,7 +727,7 @@ var _ = ginkgo.Describe("client", func() {
 				logger: &testLogger{},
 			}
 
-			errs := c.sendToExplicitRooms(context.Background(), []string{""}, "test message")
+			errs := c.sendToExplicitRooms(context.Background(), []string{""}, textMessage("test message"))
 			gomega.Expect(errs).To(gomega.HaveLen(1))
 			gomega.Expect(errors.Is(errs[0], ErrEmptyRoom)).To(gomega.BeTrue())
 		})
@@ -682,7 +741,7 @@ var _ = ginkgo.Describe("client", func() {
 				logger: &testLogger{},
 			}
 
-			errs := c.sendToExplicitRooms(context.Background(), []string{"   "}, "test message")
+			errs := c.sendToExplicitRooms(context.Background(), []string{"   "}, textMessage("test message"))
 			gomega.Expect(errs).To(gomega.HaveLen(1))
 			gomega.Expect(errors.Is(errs[0], ErrEmptyRoom)).To(gomega.BeTrue())
 		})
@@ -703,7 +762,7 @@ var _ = ginkgo.Describe("client", func() {
 				logger:     &testLogger{},
 			}
 
-			errs := c.sendToExplicitRooms(context.Background(), []string{"  !roomid:matrix.example.com  "}, "test message")
+			errs := c.sendToExplicitRooms(context.Background(), []string{"  !roomid:matrix.example.com  "}, textMessage("test message"))
 			gomega.Expect(errs).To(gomega.BeEmpty())
 		})
 	})
@@ -722,7 +781,7 @@ var _ = ginkgo.Describe("client", func() {
 				logger:     &testLogger{},
 			}
 
-			errs := c.sendToJoinedRooms(context.Background(), "test message")
+			errs := c.sendToJoinedRooms(context.Background(), textMessage("test message"))
 			gomega.Expect(errs).To(gomega.HaveLen(1))
 		})
 
@@ -748,7 +807,7 @@ var _ = ginkgo.Describe("client", func() {
 				logger:     &testLogger{},
 			}
 
-			errs := c.sendToJoinedRooms(context.Background(), "test message")
+			errs := c.sendToJoinedRooms(context.Background(), textMessage("test message"))
 			gomega.Expect(errs).To(gomega.BeEmpty())
 		})
 
@@ -771,7 +830,7 @@ var _ = ginkgo.Describe("client", func() {
 				logger:     &testLogger{},
 			}
 
-			errs := c.sendToJoinedRooms(context.Background(), "test message")
+			errs := c.sendToJoinedRooms(context.Background(), textMessage("test message"))
 			gomega.Expect(errs).To(gomega.HaveLen(1))
 		})
 	})
@@ -911,6 +970,30 @@ var _ = ginkgo.Describe("client", func() {
 		})
 	})
 
+	ginkgo.Describe("createRequest", func() {
+		ginkgo.It("should send plain text without a format param", func() {
+			request, err := createRequest("**Hello**", "", nil)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(request).To(gomega.Equal(textMessage("**Hello**")))
+		})
+
+		ginkgo.It("should send markdown as HTML with a plain text body", func() {
+			params := &types.Params{"format": "markdown"}
+
+			request, err := createRequest("Disk **full** on `db-1`", "Alert & Co", params)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(request.Body).To(gomega.Equal("Alert & Co\n\nDisk full on db-1"))
+			gomega.Expect(request.Format).To(gomega.Equal("org.matrix.custom.html"))
+			gomega.Expect(request.FormattedBody).To(gomega.Equal(
+				"<strong>Alert &amp; Co</strong><br><br>Disk <strong>full</strong> on <code>db-1</code>"))
+		})
+
+		ginkgo.It("should reject an unknown format", func() {
+			_, err := createRequest("Hello", "", &types.Params{"format": "rtf"})
+			gomega.Expect(err).To(gomega.MatchError(format.ErrUnknownMarkup))
+		})
+	})
+
 	// Tests for setAuthorizationHeader
 	ginkgo.Describe("setAuthorizationHeader", func() {
 		ginkgo.It("should set Authorization header when accessToken is non-empty", func() {
@@ -1260,7 +1343,7 @@ var _ = ginkgo.Describe("client", func() {
 				logger:     &testLogger{},
 			}
 
-			errs := c.sendToJoinedRooms(context.Background(), "test message")
+			errs := c.sendToJoinedRooms(context.Background(), textMessage("test message"))
 			gomega.Expect(errs).To(gomega.HaveLen(1))
 		})
 
@@ -1292,7 +1375,7 @@ var _ = ginkgo.Describe("client", func() {
 			}
 
 			errs := c.sendToExplicitRooms(context.Background(),
-				[]string{"#room1:matrix.example.com", "#room2:matrix.example.com"}, "test message")
+				[]string{"#room1:matrix.example.com", "#room2:matrix.example.com"}, textMessage("test message"))
 			gomega.Expect(errs).To(gomega.HaveLen(1))
 		})
 	})
@@ -15,7 +15,7 @@ type Config struct {
 
 	User string `desc:"Username or empty when using access token" optional:"" url:"user"`
 
-	Password   string   `desc:"Password or access token"                 url:"password"`
+	Password   string   `desc:"Password or access token"                 url:"password" sensitive:"true"`
 	DisableTLS bool     `                                                               default:"No" key:"disableTLS"`
 	Host       string   `                                                url:"host"`
 	Rooms      []string `desc:"Room aliases, or with ! prefix, room IDs"                             key:"rooms,room" optional:""`
@@ -3,6 +3,7 @@ package matrix
 import (
 	"context"
 	"fmt"
+	"html"
 	"net/http"
 	"net/url"
 	"strings"
@@ -30,6 +31,18 @@ func (s *Service) GetID() string {
 	return Scheme
 }
 
+// MessageLimit returns the message limit of Matrix. Events are limited to 64 KiB
+// and may carry the message twice, as body and formatted_body.
+func (s *Service) MessageLimit() types.MessageLimit {
+	return types.MessageLimit{ChunkSize: 8192, TotalChunkSize: 8192, ChunkCount: 1}
+}
+
+// Markup returns the markup Matrix messages are rendered to when the sender
+// declares the body format. They are sent as formatted_body with a plain text body.
+func (s *Service) Markup() string {
+	return format.MarkupHTML
+}
+
 // Initialize configures the service with a URL and logger.
 func (s *Service) Initialize(serviceURL *url.URL, logger types.StdLogger) error {
 	s.SetLogger(logger)
@@ -74,6 +87,11 @@ func (s *Service) Send(message string, params *types.Params) error {
 	return s.SendWithContext(context.Background(), message, params)
 }
 
+// SendContext delivers a notification message to Matrix rooms, aborting when ctx is done.
+func (s *Service) SendContext(ctx context.Context, message string, params *types.Params) error {
+	return s.SendWithContext(ctx, message, params)
+}
+
 // SendWithContext delivers a notification message to Matrix rooms with the provided context.
 func (s *Service) SendWithContext(ctx context.Context, message string, params *types.Params) error {
 	if s.client == nil {
@@ -87,10 +105,12 @@ func (s *Service) SendWithContext(ctx context.Context, message string, params *t
 		return fmt.Errorf("updating config from params: %w", err)
 	}
 
-	// Create message with title if provided
-	fullMessage := createMessage(message, cfg.Title)
+	request, err := createRequest(message, cfg.Title, params)
+	if err != nil {
+		return err
+	}
 
-	sendErrors := s.client.sendMessage(ctx, fullMessage, cfg.Rooms)
+	sendErrors := s.client.sendMessage(ctx, request, cfg.Rooms)
 	if len(sendErrors) > 0 {
 		for _, err := range sendErrors {
 			s.Logf("error sending message: %v", err)
@@ -106,6 +126,21 @@ func (s *Service) SendWithContext(ctx context.Context, message string, params *t
 	return nil
 }
 
+// Close ends the Matrix session the service logged in with, if any.
+func (s *Service) Close() error {
+	return s.CloseContext(context.Background())
+}
+
+// CloseContext ends the Matrix session the service logged in with, aborting when
+// ctx is done. Sessions using an access token given in the URL are kept.
+func (s *Service) CloseContext(ctx context.Context) error {
+	if s.client == nil {
+		return nil
+	}
+
+	return s.client.logout(ctx)
+}
+
 // SetHTTPClient sets a custom HTTP client for the service (propagated to internal client).
 func (s *Service) SetHTTPClient(client types.HTTPClient) {
 	s.httpClient = client
@@ -119,6 +154,32 @@ func (s *Service) SetHTTPClient(client types.HTTPClient) {
 	}
 }
 
+// createRequest creates the message request, with the title prepended if provided.
+// If the sender declared the body format, the message is sent as HTML with a plain
+// text fallback.
+func createRequest(message, title string, params *types.Params) (apiReqSend, error) {
+	formatted, rendered, err := format.RenderBody(message, params, format.MarkupHTML)
+	if err != nil {
+		return apiReqSend{}, fmt.Errorf("rendering message: %w", err)
+	}
+
+	if !rendered {
+		return textMessage(createMessage(message, title)), nil
+	}
+
+	plain, _, _ := format.RenderBody(message, params, format.MarkupText)
+
+	request := textMessage(createMessage(plain, title))
+	request.Format = messageFormatHTML
+	request.FormattedBody = formatted
+
+	if trimmedTitle := strings.TrimSpace(title); trimmedTitle != "" {
+		request.FormattedBody = "<strong>" + html.EscapeString(trimmedTitle) + "</strong><br><br>" + formatted
+	}
+
+	return request, nil
+}
+
 // createMessage creates the full message body by prepending the title if provided.
 // Format: If title is "Alert" and message is "Hello", output is "Alert\n\nHello".
 func createMessage(message, title string) string {
@@ -31,6 +31,11 @@ func (s *Service) GetID() string {
 	return Scheme
 }
 
+// MessageLimit returns the message limit of Mattermost posts, 16,383 characters.
+func (s *Service) MessageLimit() types.MessageLimit {
+	return types.MessageLimit{ChunkSize: 16383, TotalChunkSize: 16383, ChunkCount: 1}
+}
+
 // Initialize configures the service with a URL and logger.
 func (s *Service) Initialize(serviceURL *url.URL, logger types.StdLogger) error {
 	s.SetLogger(logger)
@@ -61,6 +66,11 @@ func (s *Service) Initialize(serviceURL *url.URL, logger types.StdLogger) error
 
 // Send delivers a notification message to Mattermost.
 func (s *Service) Send(message string, params *types.Params) error {
+	return s.SendContext(context.Background(), message, params)
+}
+
+// SendContext delivers a notification message to Mattermost, aborting when ctx is done.
+func (s *Service) SendContext(ctx context.Context, message string, params *types.Params) error {
 	config := s.Config
 	serviceURL := buildURL(config)
 
@@ -74,7 +84,7 @@ func (s *Service) Send(message string, params *types.Params) error {
 	}
 
 	ctx, cancel := context.WithTimeout(
-		context.Background(),
+		ctx,
 		defaultHTTPTimeout,
 	)
 	defer cancel()
@@ -102,11 +112,17 @@ func (s *Service) Send(message string, params *types.Params) error {
 		client = &http.Client{Transport: transport}
 	}
 
+	if err := types.WaitRateLimit(req.Context(), req.URL.Hostname()); err != nil {
+		return fmt.Errorf("waiting for rate limit: %w", err)
+	}
+
 	res, err := client.Do(req)
 	if err != nil {
 		return fmt.Errorf("executing POST request to Mattermost API: %w", err)
 	}
 
+	types.ReportHTTPStatus(req.Context(), res.StatusCode)
+
 	defer func() { _ = res.Body.Close() }()
 
 	if res.StatusCode != http.StatusOK {
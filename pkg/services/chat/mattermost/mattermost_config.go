@@ -19,7 +19,7 @@ type Config struct {
 	Title      string `desc:"Notification title, optionally set by the sender"                                              default:""   key:"title"`
 	Channel    string `desc:"Override webhook channel"                                          optional:"" url:"path2"`
 	Host       string `desc:"Mattermost server host"                                                        url:"host,port"`
-	Token      string `desc:"Webhook token"                                                                 url:"path1"`
+	Token      string `desc:"Webhook token"                                                                 url:"path1" sensitive:"true"`
 	DisableTLS bool   `                                                                                                     default:"No" key:"disabletls"`
 }
 
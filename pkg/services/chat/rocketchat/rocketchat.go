@@ -33,6 +33,11 @@ func (s *Service) GetID() string {
 	return Scheme
 }
 
+// MessageLimit returns the default message limit of Rocket.Chat, 5,000 characters.
+func (s *Service) MessageLimit() types.MessageLimit {
+	return types.MessageLimit{ChunkSize: 5000, TotalChunkSize: 5000, ChunkCount: 1}
+}
+
 // Initialize configures the service with a URL and logger.
 //
 // Params:
@@ -67,6 +72,11 @@ func (s *Service) Initialize(serviceURL *url.URL, logger types.StdLogger) error
 // Returns:
 //   - error: An error if sending fails, nil on success
 func (s *Service) Send(message string, params *types.Params) error {
+	return s.SendContext(context.Background(), message, params)
+}
+
+// SendContext delivers a notification message to Rocket.Chat, aborting when ctx is done.
+func (s *Service) SendContext(ctx context.Context, message string, params *types.Params) error {
 	var res *http.Response
 
 	var err error
@@ -80,7 +90,7 @@ func (s *Service) Send(message string, params *types.Params) error {
 	}
 
 	ctx, cancel := context.WithTimeout(
-		context.Background(),
+		ctx,
 		defaultHTTPTimeout,
 	)
 	defer cancel()
@@ -97,6 +107,10 @@ func (s *Service) Send(message string, params *types.Params) error {
 
 	req.Header.Set("Content-Type", "application/json")
 
+	if err := types.WaitRateLimit(req.Context(), req.URL.Hostname()); err != nil {
+		return fmt.Errorf("waiting for rate limit: %w", err)
+	}
+
 	res, err = s.httpClientOrDefault().Do(req)
 	if err != nil {
 		return fmt.Errorf(
@@ -107,6 +121,8 @@ func (s *Service) Send(message string, params *types.Params) error {
 		)
 	}
 
+	types.ReportHTTPStatus(req.Context(), res.StatusCode)
+
 	defer func() { _ = res.Body.Close() }()
 
 	if res.StatusCode != http.StatusOK {
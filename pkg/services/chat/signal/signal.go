@@ -37,6 +37,11 @@ func (s *Service) GetID() string {
 	return Scheme
 }
 
+// MessageLimit returns the message limit of Signal, 2,000 characters.
+func (s *Service) MessageLimit() types.MessageLimit {
+	return types.MessageLimit{ChunkSize: 2000, TotalChunkSize: 2000, ChunkCount: 1}
+}
+
 // Initialize configures the service with a URL and logger.
 //
 // Parameters:
@@ -66,6 +71,19 @@ func (s *Service) Initialize(serviceURL *url.URL, logger types.StdLogger) error
 // Returns:
 //   - error: if the send operation fails, nil otherwise
 func (s *Service) Send(message string, params *types.Params) error {
+	return s.SendContext(context.Background(), message, params)
+}
+
+// SendContext delivers a notification message to Signal recipients, aborting when ctx is done.
+//
+// Parameters:
+//   - ctx: the context bounding the API request
+//   - message: the message text to send
+//   - params: optional parameters (e.g., attachments)
+//
+// Returns:
+//   - error: if the send operation fails, nil otherwise
+func (s *Service) SendContext(ctx context.Context, message string, params *types.Params) error {
 	config := *s.Config
 
 	// Separate config params from message params (like attachments)
@@ -94,7 +112,7 @@ func (s *Service) Send(message string, params *types.Params) error {
 		}
 	}
 
-	return s.sendMessage(message, &config, messageParams)
+	return s.sendMessage(ctx, message, &config, messageParams)
 }
 
 // SetHTTPClient sets a custom HTTP client for the service.
@@ -160,6 +178,7 @@ func (s *Service) createPayload(
 // createRequest builds the HTTP request for the Signal API.
 //
 // Parameters:
+//   - ctx: the parent context for the request
 //   - config: the service configuration
 //   - payload: the payload to send (passed as pointer for efficiency)
 //
@@ -168,6 +187,7 @@ func (s *Service) createPayload(
 //   - context.CancelFunc: a function to cancel the request context
 //   - error: if request creation fails, nil otherwise
 func (s *Service) createRequest(
+	ctx context.Context,
 	config *Config,
 	payload *sendMessagePayload,
 ) (*http.Request, context.CancelFunc, error) {
@@ -179,7 +199,7 @@ func (s *Service) createRequest(
 	}
 
 	ctx, cancel := context.WithTimeout(
-		context.Background(),
+		ctx,
 		defaultHTTPTimeout,
 	)
 
@@ -226,20 +246,21 @@ func (s *Service) parseResponse(resp *http.Response) {
 // sendMessage sends a message to all configured recipients.
 //
 // Parameters:
+//   - ctx: the context bounding the API request
 //   - message: the message text to send
 //   - config: the service configuration
 //   - params: optional parameters (e.g., attachments)
 //
 // Returns:
 //   - error: if sending fails, nil otherwise
-func (s *Service) sendMessage(message string, config *Config, params *types.Params) error {
+func (s *Service) sendMessage(ctx context.Context, message string, config *Config, params *types.Params) error {
 	if len(config.Recipients) == 0 {
 		return ErrNoRecipients
 	}
 
 	payload := s.createPayload(message, config, params)
 
-	req, cancel, err := s.createRequest(config, &payload)
+	req, cancel, err := s.createRequest(ctx, config, &payload)
 	if err != nil {
 		return err
 	}
@@ -258,11 +279,17 @@ func (s *Service) sendMessage(message string, config *Config, params *types.Para
 func (s *Service) sendRequest(req *http.Request) error {
 	client := s.httpClientOrDefault()
 
+	if err := types.WaitRateLimit(req.Context(), req.URL.Hostname()); err != nil {
+		return fmt.Errorf("waiting for rate limit: %w", err)
+	}
+
 	resp, err := client.Do(req)
 	if err != nil {
 		return fmt.Errorf("sending HTTP request: %w", err)
 	}
 
+	types.ReportHTTPStatus(req.Context(), resp.StatusCode)
+
 	defer func() { _ = resp.Body.Close() }()
 
 	// Check response status
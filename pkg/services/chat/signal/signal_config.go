@@ -21,7 +21,7 @@ type Config struct {
 	Port       int      `default:"8080"      desc:"Signal REST API server port"                key:"port"`
 	User       string   `                    desc:"Username for HTTP Basic Auth"               key:"user"`
 	Password   string   `                    desc:"Password for HTTP Basic Auth"               key:"password"      sensitive:"true"`
-	Token      string   `                    desc:"API token for Bearer authentication"        key:"token,apikey"`
+	Token      string   `                    desc:"API token for Bearer authentication"        key:"token,apikey" sensitive:"true"`
 	Source     string   `                    desc:"Source phone number (with country code)"    key:"source"`
 	Recipients []string `                    desc:"Recipient phone numbers or group IDs"       key:"recipients,to"`
 	DisableTLS bool     `default:"No"        desc:"Disable TLS for Signal REST API connection" key:"disabletls"`
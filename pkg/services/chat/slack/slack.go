@@ -37,6 +37,24 @@ func (s *Service) GetID() string {
 	return Scheme
 }
 
+// MessageLimit returns the message limit of Slack, which truncates message text
+// longer than 40,000 characters.
+func (s *Service) MessageLimit() types.MessageLimit {
+	return types.MessageLimit{ChunkSize: 40000, TotalChunkSize: 40000, ChunkCount: 1}
+}
+
+// Markup returns the markup Slack messages are rendered to when the sender
+// declares the body format.
+func (s *Service) Markup() string {
+	return format.MarkupSlack
+}
+
+// RateLimit returns the request rate limit of Slack, which allows about one message
+// per second to a webhook or channel.
+func (s *Service) RateLimit() types.RateLimit {
+	return types.RateLimit{Requests: 1, Interval: time.Second}
+}
+
 // Initialize configures the service with a URL and logger.
 func (s *Service) Initialize(serviceURL *url.URL, logger types.StdLogger) error {
 	s.SetLogger(logger)
@@ -54,19 +72,28 @@ func (s *Service) Initialize(serviceURL *url.URL, logger types.StdLogger) error
 
 // Send delivers a notification message to Slack.
 func (s *Service) Send(message string, params *types.Params) error {
+	return s.SendContext(context.Background(), message, params)
+}
+
+// SendContext delivers a notification message to Slack, aborting when ctx is done.
+func (s *Service) SendContext(ctx context.Context, message string, params *types.Params) error {
 	config := s.Config
 
 	if err := s.pkr.UpdateConfigFromParams(config, params); err != nil {
 		return fmt.Errorf("updating config from params: %w", err)
 	}
 
+	message, _, err := format.RenderBody(message, params, s.Markup())
+	if err != nil {
+		return fmt.Errorf("rendering message: %w", err)
+	}
+
 	payload := CreateJSONPayload(config, message)
 
-	var err error
 	if config.Token.IsAPIToken() {
-		err = s.sendAPI(config, payload)
+		err = s.sendAPI(ctx, config, payload)
 	} else {
-		err = s.sendWebhook(config, payload)
+		err = s.sendWebhook(ctx, config, payload)
 	}
 
 	if err != nil {
@@ -91,12 +118,19 @@ func (s *Service) httpClientOrDefault() types.HTTPClient {
 }
 
 // sendAPI sends a notification using the Slack API.
-func (s *Service) sendAPI(config *Config, payload any) error {
+func (s *Service) sendAPI(ctx context.Context, config *Config, payload any) error {
 	response := APIResponse{}
 	jsonClient := jsonclient.NewWithHTTPClient(s.httpClientOrDefault())
 	jsonClient.Headers().Set("Authorization", config.Token.Authorization())
 
-	if err := jsonClient.Post(apiPostMessage, payload, &response); err != nil {
+	var err error
+	if contextClient, ok := jsonClient.(jsonclient.ContextClient); ok {
+		err = contextClient.PostContext(ctx, apiPostMessage, payload, &response)
+	} else {
+		err = jsonClient.Post(apiPostMessage, payload, &response)
+	}
+
+	if err != nil {
 		return fmt.Errorf("posting to Slack API: %w", err)
 	}
 
@@ -112,17 +146,19 @@ func (s *Service) sendAPI(config *Config, payload any) error {
 		s.Logf("Slack API warning: %q", response.Warning)
 	}
 
+	types.ReportMessageID(ctx, response.TS)
+
 	return nil
 }
 
 // sendWebhook sends a notification using a Slack webhook.
-func (s *Service) sendWebhook(config *Config, payload any) error {
+func (s *Service) sendWebhook(ctx context.Context, config *Config, payload any) error {
 	payloadBytes, err := json.Marshal(payload)
 	if err != nil {
 		return fmt.Errorf("failed to marshal payload: %w", err)
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), defaultHTTPTimeout)
+	ctx, cancel := context.WithTimeout(ctx, defaultHTTPTimeout)
 	defer cancel()
 
 	req, err := http.NewRequestWithContext(
@@ -139,11 +175,17 @@ func (s *Service) sendWebhook(config *Config, payload any) error {
 
 	client := s.httpClientOrDefault()
 
+	if err := types.WaitRateLimit(req.Context(), req.URL.Hostname()); err != nil {
+		return fmt.Errorf("waiting for rate limit: %w", err)
+	}
+
 	res, err := client.Do(req)
 	if err != nil {
 		return fmt.Errorf("failed to invoke webhook: %w", err)
 	}
 
+	types.ReportHTTPStatus(req.Context(), res.StatusCode)
+
 	defer func() { _ = res.Body.Close() }()
 
 	resBytes, err := io.ReadAll(res.Body)
@@ -156,13 +198,13 @@ func (s *Service) sendWebhook(config *Config, payload any) error {
 	switch response {
 	case "":
 		if res.StatusCode != http.StatusOK {
-			return fmt.Errorf("%w: %v", ErrWebhookStatusFailure, res.Status)
+			return jsonclient.NewError(res, response, fmt.Errorf("%w: %v", ErrWebhookStatusFailure, res.Status))
 		}
 
 		fallthrough
 	case "ok":
 		return nil
 	default:
-		return fmt.Errorf("%w: %v", ErrWebhookResponseFailure, response)
+		return jsonclient.NewError(res, response, fmt.Errorf("%w: %v", ErrWebhookResponseFailure, response))
 	}
 }
@@ -48,6 +48,7 @@ type APIResponse struct {
 	Ok       bool   `json:"ok"`
 	Error    string `json:"error"`
 	Warning  string `json:"warning"`
+	TS       string `json:"ts"`
 	MetaData struct {
 		Warnings []string `json:"warnings"`
 	} `json:"response_metadata"`
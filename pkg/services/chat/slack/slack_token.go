@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/nicholas-fedor/shoutrrr/pkg/types"
+	"github.com/nicholas-fedor/shoutrrr/pkg/util"
 )
 
 // Token is a Slack API token or a Slack webhook token.
@@ -110,6 +111,16 @@ func (t *Token) SetFromProp(propValue string) error {
 	return nil
 }
 
+// Redact replaces the token secret with a placeholder, keeping its type identifier.
+// It implements the format.Redactor interface.
+func (t *Token) Redact() {
+	if len(t.raw) < TypeIdentifierLength {
+		return
+	}
+
+	t.raw = t.raw[:TypeIdentifierLength] + ":" + util.RedactedPlaceholder
+}
+
 // String returns the token in normalized format with dashes (-) as separator.
 func (t *Token) String() string {
 	return t.raw
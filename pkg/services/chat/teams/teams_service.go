@@ -13,6 +13,7 @@ import (
 	"github.com/nicholas-fedor/shoutrrr/pkg/format"
 	"github.com/nicholas-fedor/shoutrrr/pkg/services/standard"
 	"github.com/nicholas-fedor/shoutrrr/pkg/types"
+	"github.com/nicholas-fedor/shoutrrr/pkg/util/jsonclient"
 )
 
 // HTTPClient defines the interface for HTTP operations.
@@ -53,6 +54,18 @@ func (s *Service) GetID() string {
 	return Scheme
 }
 
+// MessageLimit returns the message limit of Teams. Payloads are limited to about
+// 28 KB, and a rune takes up to utf8.UTFMax bytes.
+func (s *Service) MessageLimit() types.MessageLimit {
+	return types.MessageLimit{ChunkSize: 7000, TotalChunkSize: 7000, ChunkCount: 1}
+}
+
+// Markup returns the markup Teams messages are rendered to when the sender
+// declares the body format. Adaptive Card text blocks support a subset of markdown.
+func (s *Service) Markup() string {
+	return format.MarkupMarkdown
+}
+
 // Initialize configures the service with a URL and logger.
 func (s *Service) Initialize(serviceURL *url.URL, logger types.StdLogger) error {
 	s.SetLogger(logger)
@@ -72,6 +85,11 @@ func (s *Service) Initialize(serviceURL *url.URL, logger types.StdLogger) error
 
 // Send delivers a notification message to Microsoft Teams.
 func (s *Service) Send(message string, params *types.Params) error {
+	return s.SendContext(context.Background(), message, params)
+}
+
+// SendContext delivers a notification message to Microsoft Teams, aborting when ctx is done.
+func (s *Service) SendContext(ctx context.Context, message string, params *types.Params) error {
 	if s.Config == nil {
 		return ErrMissingHost
 	}
@@ -81,7 +99,12 @@ func (s *Service) Send(message string, params *types.Params) error {
 		return fmt.Errorf("updating config from params: %w", err)
 	}
 
-	return s.doSend(&config, message)
+	message, _, err := format.RenderBody(message, params, s.Markup())
+	if err != nil {
+		return fmt.Errorf("rendering message: %w", err)
+	}
+
+	return s.doSend(ctx, &config, message)
 }
 
 // SetHTTPClient sets the HTTP client for testing purposes.
@@ -125,7 +148,7 @@ func colorToEnum(color string) string {
 }
 
 // doSend sends the notification to Teams as an Adaptive Card payload.
-func (s *Service) doSend(config *Config, message string) error {
+func (s *Service) doSend(ctx context.Context, config *Config, message string) error {
 	if config.Host == "" {
 		return ErrMissingHost
 	}
@@ -184,7 +207,7 @@ func (s *Service) doSend(config *Config, message string) error {
 		return fmt.Errorf("marshaling payload to JSON: %w", err)
 	}
 
-	res, err := s.postJSON(config.Host, jsonBytes)
+	res, err := s.postJSON(ctx, config.Host, jsonBytes)
 	if err != nil {
 		return fmt.Errorf("%w: %s", ErrSendFailed, err.Error())
 	}
@@ -192,16 +215,16 @@ func (s *Service) doSend(config *Config, message string) error {
 	defer func() { _ = res.Body.Close() }()
 
 	if res.StatusCode != http.StatusOK && res.StatusCode != http.StatusAccepted {
-		return fmt.Errorf("%w: %s", ErrSendFailedStatus, res.Status)
+		return jsonclient.NewError(res, "", fmt.Errorf("%w: %s", ErrSendFailedStatus, res.Status))
 	}
 
 	return nil
 }
 
 // postJSON performs an HTTP POST with a JSON payload.
-func (s *Service) postJSON(serviceURL string, payload []byte) (*http.Response, error) {
+func (s *Service) postJSON(ctx context.Context, serviceURL string, payload []byte) (*http.Response, error) {
 	ctx, cancel := context.WithTimeout(
-		context.Background(),
+		ctx,
 		defaultHTTPTimeout,
 	)
 	defer cancel()
@@ -218,10 +241,16 @@ func (s *Service) postJSON(serviceURL string, payload []byte) (*http.Response, e
 
 	req.Header.Set("Content-Type", "application/json")
 
+	if err := types.WaitRateLimit(req.Context(), req.URL.Hostname()); err != nil {
+		return nil, fmt.Errorf("waiting for rate limit: %w", err)
+	}
+
 	res, err := s.httpClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("making HTTP POST request: %w", err)
 	}
 
+	types.ReportHTTPStatus(req.Context(), res.StatusCode)
+
 	return res, nil
 }
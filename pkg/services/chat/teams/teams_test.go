@@ -1,6 +1,7 @@
 package teams
 
 import (
+	"context"
 	"errors"
 	"log"
 	"net/http"
@@ -203,7 +204,7 @@ var _ = ginkgo.Describe("the teams service", func() {
 			service.Config = &Config{}
 			service.SetLogger(logger)
 
-			err := service.doSend(&Config{}, "test message")
+			err := service.doSend(context.Background(), &Config{}, "test message")
 			gomega.Expect(err).To(gomega.Equal(ErrMissingHost))
 		})
 
@@ -213,7 +214,7 @@ var _ = ginkgo.Describe("the teams service", func() {
 			service.SetLogger(logger)
 
 			// No httpmock activated — this must fail at validation, not at the network layer.
-			err := service.doSend(&Config{Host: "https://example.com"}, "test message")
+			err := service.doSend(context.Background(), &Config{Host: "https://example.com"}, "test message")
 			gomega.Expect(err).To(gomega.MatchError(ErrInvalidWebhookURL))
 		})
 	})
@@ -38,6 +38,12 @@
 //	}
 //	err = service.Send("Your message here", nil)
 //
+// # Files and Photos
+//
+// SendItems uploads the files of message items to every chat, captioned with the text of
+// their item. Images are sent as photos with sendPhoto, several of them as albums with
+// sendMediaGroup, and other files as documents with sendDocument.
+//
 // # JSON Payload Creation
 //
 // The package provides createSendMessagePayload for building properly formatted Telegram
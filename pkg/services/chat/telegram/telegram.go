@@ -1,11 +1,14 @@
 package telegram
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
+	"strconv"
 	"time"
+	"unicode/utf8"
 
 	"github.com/nicholas-fedor/shoutrrr/pkg/format"
 	"github.com/nicholas-fedor/shoutrrr/pkg/services/standard"
@@ -43,6 +46,22 @@ func (s *Service) GetID() string {
 	return Scheme
 }
 
+// MessageLimit returns the message limit of Telegram, 4,096 characters.
+func (s *Service) MessageLimit() types.MessageLimit {
+	return types.MessageLimit{ChunkSize: maxlength, TotalChunkSize: maxlength, ChunkCount: 1}
+}
+
+// Markup returns the markup Telegram messages are rendered to when the sender
+// declares the body format. They are sent with the HTML parse mode.
+func (s *Service) Markup() string {
+	return format.MarkupTelegramHTML
+}
+
+// RateLimit returns the request rate limit of a Telegram bot, 30 messages per second.
+func (s *Service) RateLimit() types.RateLimit {
+	return types.RateLimit{Requests: 30, Interval: time.Second}
+}
+
 // Initialize configures the service with a URL and logger.
 func (s *Service) Initialize(serviceURL *url.URL, logger types.StdLogger) error {
 	s.SetLogger(logger)
@@ -61,16 +80,30 @@ func (s *Service) Initialize(serviceURL *url.URL, logger types.StdLogger) error
 
 // Send delivers a notification message to Telegram.
 func (s *Service) Send(message string, params *types.Params) error {
-	if len(message) > maxlength {
-		return ErrMessageTooLong
-	}
+	return s.SendContext(context.Background(), message, params)
+}
 
+// SendContext delivers a notification message to Telegram, aborting when ctx is done.
+func (s *Service) SendContext(ctx context.Context, message string, params *types.Params) error {
 	config := *s.Config
 	if err := s.pkr.UpdateConfigFromParams(&config, params); err != nil {
 		return fmt.Errorf("updating config from params: %w", err)
 	}
 
-	return s.sendMessageForChatIDs(message, &config)
+	message, rendered, err := format.RenderBody(message, params, s.Markup())
+	if err != nil {
+		return fmt.Errorf("rendering message: %w", err)
+	}
+
+	if rendered {
+		config.ParseMode = ParseModes.HTML
+	}
+
+	if utf8.RuneCountInString(message) > maxlength {
+		return ErrMessageTooLong
+	}
+
+	return s.sendMessageForChatIDs(ctx, message, &config)
 }
 
 // SetHTTPClient sets a custom HTTP client for the service.
@@ -88,9 +121,9 @@ func (s *Service) httpClientOrDefault() types.HTTPClient {
 }
 
 // sendMessageForChatIDs sends the message to all configured chat IDs.
-func (s *Service) sendMessageForChatIDs(message string, config *Config) error {
+func (s *Service) sendMessageForChatIDs(ctx context.Context, message string, config *Config) error {
 	for _, chat := range s.Config.Chats {
-		if err := s.sendMessageToAPI(message, chat, config); err != nil {
+		if err := s.sendMessageToAPI(ctx, message, chat, config); err != nil {
 			return err
 		}
 	}
@@ -99,10 +132,22 @@ func (s *Service) sendMessageForChatIDs(message string, config *Config) error {
 }
 
 // sendMessageToAPI sends a message to the Telegram API for a specific chat.
-func (s *Service) sendMessageToAPI(message, chat string, config *Config) error {
+func (s *Service) sendMessageToAPI(ctx context.Context, message, chat string, config *Config) error {
 	client := &Client{token: config.Token, httpClient: s.httpClientOrDefault()}
 	payload := createSendMessagePayload(message, chat, config)
-	_, err := client.SendMessage(&payload)
+	sent, err := client.SendMessageContext(ctx, &payload)
+	if err != nil {
+		return err
+	}
 
-	return err
+	reportMessageID(ctx, sent)
+
+	return nil
+}
+
+// reportMessageID reports the identifier of a sent message, if any.
+func reportMessageID(ctx context.Context, sent *Message) {
+	if sent != nil {
+		types.ReportMessageID(ctx, strconv.FormatInt(sent.MessageID, 10))
+	}
 }
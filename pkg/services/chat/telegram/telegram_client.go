@@ -1,11 +1,22 @@
 package telegram
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"maps"
+	"mime"
+	"mime/multipart"
 	"net/http"
+	"net/textproto"
+	"slices"
+	"strconv"
 
 	"github.com/nicholas-fedor/shoutrrr/pkg/types"
+	"github.com/nicholas-fedor/shoutrrr/pkg/util"
 	"github.com/nicholas-fedor/shoutrrr/pkg/util/jsonclient"
 )
 
@@ -15,6 +26,15 @@ type Client struct {
 	httpClient types.HTTPClient
 }
 
+// multipartFile is a file part of a multipart request.
+type multipartFile struct {
+	field string
+	file  types.File
+}
+
+// ErrMediaMismatch indicates that the files of an album do not match its media.
+var ErrMediaMismatch = errors.New("album files do not match its media")
+
 // GetBotInfo returns the bot User info.
 func (c *Client) GetBotInfo() (*User, error) {
 	response := &userResponse{}
@@ -22,7 +42,7 @@ func (c *Client) GetBotInfo() (*User, error) {
 	err := jc.Get(c.apiURL("getMe"), response)
 
 	if !response.OK {
-		return nil, GetErrorResponse(jsonclient.ErrorBody(err))
+		return nil, responseErr(err)
 	}
 
 	return &response.Result, nil
@@ -46,7 +66,7 @@ func (c *Client) GetUpdates(
 	err := jc.Post(c.apiURL("getUpdates"), request, response)
 
 	if !response.OK {
-		return nil, GetErrorResponse(jsonclient.ErrorBody(err))
+		return nil, responseErr(err)
 	}
 
 	return response.Result, nil
@@ -54,17 +74,201 @@ func (c *Client) GetUpdates(
 
 // SendMessage sends the specified Message.
 func (c *Client) SendMessage(message *SendMessagePayload) (*Message, error) {
+	return c.SendMessageContext(context.Background(), message)
+}
+
+// SendMessageContext sends the specified Message, aborting when ctx is done.
+func (c *Client) SendMessageContext(ctx context.Context, message *SendMessagePayload) (*Message, error) {
 	response := &messageResponse{}
 	jc := jsonclient.NewWithHTTPClient(c.httpClientOrDefault())
-	err := jc.Post(c.apiURL("sendMessage"), message, response)
+
+	var err error
+	if contextClient, ok := jc.(jsonclient.ContextClient); ok {
+		err = contextClient.PostContext(ctx, c.apiURL("sendMessage"), message, response)
+	} else {
+		err = jc.Post(c.apiURL("sendMessage"), message, response)
+	}
 
 	if !response.OK {
-		return nil, GetErrorResponse(jsonclient.ErrorBody(err))
+		return nil, responseErr(err)
 	}
 
 	return response.Result, nil
 }
 
+// SendDocument uploads the specified file as a document.
+func (c *Client) SendDocument(payload *SendFilePayload, file types.File) (*Message, error) {
+	return c.SendDocumentContext(context.Background(), payload, file)
+}
+
+// SendDocumentContext uploads the specified file as a document, aborting when ctx is done.
+func (c *Client) SendDocumentContext(
+	ctx context.Context,
+	payload *SendFilePayload,
+	file types.File,
+) (*Message, error) {
+	return c.sendFile(ctx, "sendDocument", "document", payload, file)
+}
+
+// SendPhoto uploads the specified image file as a photo.
+func (c *Client) SendPhoto(payload *SendFilePayload, file types.File) (*Message, error) {
+	return c.SendPhotoContext(context.Background(), payload, file)
+}
+
+// SendPhotoContext uploads the specified image file as a photo, aborting when ctx is done.
+func (c *Client) SendPhotoContext(
+	ctx context.Context,
+	payload *SendFilePayload,
+	file types.File,
+) (*Message, error) {
+	return c.sendFile(ctx, "sendPhoto", "photo", payload, file)
+}
+
+// SendMediaGroup uploads the specified image files as an album, one for each
+// InputMedia of the payload.
+func (c *Client) SendMediaGroup(payload *SendMediaGroupPayload, files []types.File) ([]Message, error) {
+	return c.SendMediaGroupContext(context.Background(), payload, files)
+}
+
+// SendMediaGroupContext uploads the specified image files as an album, one for
+// each InputMedia of the payload, aborting when ctx is done.
+func (c *Client) SendMediaGroupContext(
+	ctx context.Context,
+	payload *SendMediaGroupPayload,
+	files []types.File,
+) ([]Message, error) {
+	if len(files) != len(payload.Media) {
+		return nil, fmt.Errorf("%w: %d files for %d media", ErrMediaMismatch, len(files), len(payload.Media))
+	}
+
+	media := slices.Clone(payload.Media)
+	parts := make([]multipartFile, len(files))
+
+	for i, file := range files {
+		parts[i] = multipartFile{field: fmt.Sprintf("file%d", i), file: file}
+		media[i].Media = "attach://" + parts[i].field
+	}
+
+	encoded, err := json.Marshal(media)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling media: %w", err)
+	}
+
+	fields := map[string]string{
+		"chat_id":              payload.ID,
+		"disable_notification": strconv.FormatBool(payload.DisableNotification),
+		"media":                string(encoded),
+	}
+
+	if payload.MessageThreadID != nil {
+		fields["message_thread_id"] = strconv.Itoa(*payload.MessageThreadID)
+	}
+
+	response := &mediaGroupResponse{}
+	err = c.postMultipart(ctx, "sendMediaGroup", fields, parts, response)
+
+	if !response.OK {
+		return nil, responseErr(err)
+	}
+
+	return response.Result, nil
+}
+
+// sendFile uploads a file with a sendDocument or sendPhoto request.
+func (c *Client) sendFile(
+	ctx context.Context,
+	endpoint, field string,
+	payload *SendFilePayload,
+	file types.File,
+) (*Message, error) {
+	response := &messageResponse{}
+	err := c.postMultipart(ctx, endpoint, payload.formFields(), []multipartFile{{field: field, file: file}}, response)
+
+	if !response.OK {
+		return nil, responseErr(err)
+	}
+
+	return response.Result, nil
+}
+
+// postMultipart posts the fields and files as a multipart form and unmarshals the
+// JSON response into the provided object. Failed requests are reported like the
+// JSON requests of the client, as a jsonclient.Error.
+func (c *Client) postMultipart(
+	ctx context.Context,
+	endpoint string,
+	fields map[string]string,
+	files []multipartFile,
+	response any,
+) error {
+	var body bytes.Buffer
+
+	writer := multipart.NewWriter(&body)
+
+	for _, key := range slices.Sorted(maps.Keys(fields)) {
+		if err := writer.WriteField(key, fields[key]); err != nil {
+			return fmt.Errorf("writing %s field: %w", key, err)
+		}
+	}
+
+	for _, part := range files {
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Disposition", mime.FormatMediaType("form-data", map[string]string{
+			"name":     part.field,
+			"filename": part.file.Name,
+		}))
+		header.Set("Content-Type", http.DetectContentType(part.file.Data))
+
+		fw, err := writer.CreatePart(header)
+		if err != nil {
+			return fmt.Errorf("creating form file for %s: %w", part.file.Name, err)
+		}
+
+		if _, err := fw.Write(part.file.Data); err != nil {
+			return fmt.Errorf("writing file data for %s: %w", part.file.Name, err)
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("closing multipart writer: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.apiURL(endpoint), &body)
+	if err != nil {
+		return fmt.Errorf("creating %s request: %w", endpoint, util.RedactError(err))
+	}
+
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	if err := types.WaitRateLimit(ctx, req.URL.Hostname()); err != nil {
+		return fmt.Errorf("waiting for rate limit: %w", err)
+	}
+
+	res, err := c.httpClientOrDefault().Do(req)
+	if err != nil {
+		return fmt.Errorf("sending %s request: %w", endpoint, util.RedactError(err))
+	}
+
+	defer func() { _ = res.Body.Close() }()
+
+	types.ReportHTTPStatus(ctx, res.StatusCode)
+
+	data, err := io.ReadAll(res.Body)
+	if res.StatusCode >= jsonclient.HTTPClientErrorThreshold {
+		err = fmt.Errorf("%w: %v", jsonclient.ErrUnexpectedStatus, res.Status)
+	}
+
+	if err == nil {
+		err = json.Unmarshal(data, response)
+	}
+
+	if err != nil {
+		return jsonclient.NewError(res, string(data), err)
+	}
+
+	return nil
+}
+
 func (c *Client) apiURL(endpoint string) string {
 	return fmt.Sprintf(apiFormat, c.token, endpoint)
 }
@@ -78,6 +282,22 @@ func (c *Client) httpClientOrDefault() types.HTTPClient {
 	return &http.Client{Timeout: defaultHTTPTimeout}
 }
 
+// responseErr returns the API error of a failed request, falling back to the
+// transport or HTTP error when the response did not contain one.
+// Undecodable responses to accepted requests are not treated as failures.
+func responseErr(err error) error {
+	if apiErr := GetErrorResponse(jsonclient.ErrorBody(err)); apiErr != nil {
+		return apiErr
+	}
+
+	var jsonErr jsonclient.Error
+	if errors.As(err, &jsonErr) && jsonErr.StatusCode < jsonclient.HTTPClientErrorThreshold {
+		return nil
+	}
+
+	return err
+}
+
 // GetErrorResponse retrieves the error message from a failed request.
 func GetErrorResponse(body string) error {
 	response := &responseError{}
@@ -12,7 +12,7 @@ import (
 
 // Config holds settings for the Telegram notification service.
 type Config struct {
-	Token        string    `url:"user"`
+	Token        string    `url:"user" sensitive:"true"`
 	Preview      bool      `           default:"Yes"  desc:"If disabled, no web page preview will be displayed for URLs" key:"preview"`
 	Notification bool      `           default:"Yes"  desc:"If disabled, sends Message silently"                         key:"notification"`
 	ParseMode    parseMode `           default:"None" desc:"How the text Message should be parsed"                       key:"parsemode"`
@@ -54,10 +54,10 @@ func (c *Config) SetURL(serviceURL *url.URL) error {
 
 // getURL constructs a URL from the Config's fields using the provided resolver.
 func (c *Config) getURL(resolver types.ConfigQueryResolver) *url.URL {
-	tokenParts := strings.Split(c.Token, ":")
+	botID, secret, _ := strings.Cut(c.Token, ":")
 
 	return &url.URL{
-		User:       url.UserPassword(tokenParts[0], tokenParts[1]),
+		User:       url.UserPassword(botID, secret),
 		Host:       Scheme,
 		Scheme:     Scheme,
 		ForceQuery: true,
@@ -72,7 +72,7 @@ func (c *Config) setURL(resolver types.ConfigQueryResolver, serviceURL *url.URL)
 	token := serviceURL.User.Username() + ":" + password
 	if serviceURL.String() != "telegram://dummy@dummy.com" {
 		if !IsTokenValid(token) {
-			return fmt.Errorf("%w: %s", ErrInvalidToken, token)
+			return ErrInvalidToken
 		}
 	}
 
@@ -0,0 +1,218 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/nicholas-fedor/shoutrrr/pkg/format"
+	"github.com/nicholas-fedor/shoutrrr/pkg/types"
+)
+
+const (
+	maxCaptionLength = 1024     // maxCaptionLength is the maximum number of characters of a file caption.
+	maxPhotoSize     = 10 << 20 // maxPhotoSize is the maximum size of a photo, larger images are sent as documents.
+	maxAlbumSize     = 10       // maxAlbumSize is the maximum number of photos of a media group.
+)
+
+// photoContentTypes are the image types Telegram accepts as photos.
+var photoContentTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/webp": true,
+}
+
+// ErrCaptionTooLong indicates that the caption of a file exceeds the maximum allowed length.
+var ErrCaptionTooLong = errors.New("caption exceeds the max length")
+
+// upload is the file of a message item with its caption.
+type upload struct {
+	file    types.File
+	caption string
+}
+
+// SendItems delivers message items to Telegram, uploading their files.
+func (s *Service) SendItems(items []types.MessageItem, params types.Params) error {
+	return s.SendItemsContext(context.Background(), items, params)
+}
+
+// SendItemsContext delivers message items to Telegram, uploading their files and
+// aborting when ctx is done. The text of items without a file is sent as one
+// message. Images are sent as photos, several of them as an album, and other
+// files as documents, each captioned with the text of its item.
+func (s *Service) SendItemsContext(ctx context.Context, items []types.MessageItem, params types.Params) error {
+	config := *s.Config
+	if err := s.pkr.UpdateConfigFromParams(&config, &params); err != nil {
+		return fmt.Errorf("updating config from params: %w", err)
+	}
+
+	var (
+		texts             []string
+		photos, documents []upload
+	)
+
+	for _, item := range items {
+		text, rendered, err := format.RenderBody(item.Text, &params, s.Markup())
+		if err != nil {
+			return fmt.Errorf("rendering message: %w", err)
+		}
+
+		if rendered {
+			config.ParseMode = ParseModes.HTML
+		}
+
+		switch {
+		case item.File == nil:
+			if text != "" {
+				texts = append(texts, text)
+			}
+		case isPhoto(item.File):
+			photos = append(photos, upload{file: *item.File, caption: text})
+		default:
+			documents = append(documents, upload{file: *item.File, caption: text})
+		}
+	}
+
+	message := strings.Join(texts, "\n")
+	if utf8.RuneCountInString(message) > maxlength {
+		return ErrMessageTooLong
+	}
+
+	if err := checkCaptions(message, photos, documents, config); err != nil {
+		return err
+	}
+
+	for _, chat := range s.Config.Chats {
+		if err := s.sendItemsToChat(ctx, message, photos, documents, chat, config); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// isPhoto reports whether file is an image Telegram accepts as a photo.
+func isPhoto(file *types.File) bool {
+	return len(file.Data) <= maxPhotoSize && photoContentTypes[http.DetectContentType(file.Data)]
+}
+
+// checkCaptions returns ErrCaptionTooLong if the caption of an upload exceeds
+// maxCaptionLength once formatted, including the title that sendItemsToChat
+// prepends to the first upload if there is no message.
+func checkCaptions(message string, photos, documents []upload, config Config) error {
+	untitled := config
+	untitled.Title = ""
+
+	for i, file := range slices.Concat(photos, documents) {
+		formatting := &untitled
+		if i == 0 && message == "" {
+			formatting = &config
+		}
+
+		payload := createSendFilePayload(file.caption, "", formatting)
+		if utf8.RuneCountInString(payload.Caption) > maxCaptionLength {
+			return fmt.Errorf("%w: %q", ErrCaptionTooLong, file.file.Name)
+		}
+	}
+
+	return nil
+}
+
+// sendItemsToChat sends the message, then the photos and then the documents to
+// a chat. The title is only prepended to the first of them.
+func (s *Service) sendItemsToChat(
+	ctx context.Context,
+	message string,
+	photos, documents []upload,
+	chat string,
+	config Config,
+) error {
+	client := &Client{token: config.Token, httpClient: s.httpClientOrDefault()}
+
+	if message != "" {
+		if err := s.sendMessageToAPI(ctx, message, chat, &config); err != nil {
+			return err
+		}
+
+		config.Title = ""
+	}
+
+	for album := range slices.Chunk(photos, maxAlbumSize) {
+		if err := sendPhotos(ctx, client, album, chat, &config); err != nil {
+			return err
+		}
+
+		config.Title = ""
+	}
+
+	for _, document := range documents {
+		payload := createSendFilePayload(document.caption, chat, &config)
+
+		sent, err := client.SendDocumentContext(ctx, &payload, document.file)
+		if err != nil {
+			return err
+		}
+
+		reportMessageID(ctx, sent)
+
+		config.Title = ""
+	}
+
+	return nil
+}
+
+// sendPhotos sends a single photo, or several photos as an album.
+func sendPhotos(ctx context.Context, client *Client, photos []upload, chat string, config *Config) error {
+	if len(photos) == 1 {
+		payload := createSendFilePayload(photos[0].caption, chat, config)
+
+		sent, err := client.SendPhotoContext(ctx, &payload, photos[0].file)
+		if err != nil {
+			return err
+		}
+
+		reportMessageID(ctx, sent)
+
+		return nil
+	}
+
+	files := make([]types.File, len(photos))
+	media := make([]InputMedia, len(photos))
+
+	// Only the first caption gets the title.
+	untitled := *config
+	untitled.Title = ""
+
+	var payload SendFilePayload
+
+	for i, photo := range photos {
+		if i == 0 {
+			payload = createSendFilePayload(photo.caption, chat, config)
+		} else {
+			payload = createSendFilePayload(photo.caption, chat, &untitled)
+		}
+
+		files[i] = photo.file
+		media[i] = InputMedia{Type: "photo", Caption: payload.Caption, ParseMode: payload.ParseMode}
+	}
+
+	sent, err := client.SendMediaGroupContext(ctx, &SendMediaGroupPayload{
+		ID:                  payload.ID,
+		MessageThreadID:     payload.MessageThreadID,
+		DisableNotification: payload.DisableNotification,
+		Media:               media,
+	}, files)
+	if err != nil {
+		return err
+	}
+
+	if len(sent) > 0 {
+		reportMessageID(ctx, &sent[len(sent)-1])
+	}
+
+	return nil
+}
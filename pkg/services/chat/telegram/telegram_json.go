@@ -5,6 +5,7 @@ import (
 	"html"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // SendMessagePayload is the notification payload for the telegram notification service.
@@ -21,6 +22,32 @@ type SendMessagePayload struct {
 	MessageID           int64        `json:"message_id,omitempty"`
 }
 
+// SendFilePayload is the payload of a file uploaded with sendDocument or sendPhoto.
+type SendFilePayload struct {
+	ID                  string `json:"chat_id"`
+	MessageThreadID     *int   `json:"message_thread_id,omitempty"`
+	Caption             string `json:"caption,omitempty"`
+	ParseMode           string `json:"parse_mode,omitempty"`
+	DisableNotification bool   `json:"disable_notification"`
+}
+
+// SendMediaGroupPayload is the payload of an album of photos uploaded with sendMediaGroup.
+type SendMediaGroupPayload struct {
+	ID                  string       `json:"chat_id"`
+	MessageThreadID     *int         `json:"message_thread_id,omitempty"`
+	DisableNotification bool         `json:"disable_notification"`
+	Media               []InputMedia `json:"media"`
+}
+
+// InputMedia is a photo of an album. Its Media refers to the uploaded file and
+// is set by the Client.
+type InputMedia struct {
+	Type      string `json:"type"`
+	Media     string `json:"media"`
+	Caption   string `json:"caption,omitempty"`
+	ParseMode string `json:"parse_mode,omitempty"`
+}
+
 // Message represents one chat message.
 type Message struct {
 	MessageID int64  `json:"message_id"`
@@ -34,10 +61,22 @@ type messageResponse struct {
 	Result *Message `json:"result"`
 }
 
+type mediaGroupResponse struct {
+	OK     bool      `json:"ok"`
+	Result []Message `json:"result"`
+}
+
 type responseError struct {
-	OK          bool   `json:"ok"`
-	ErrorCode   int    `json:"error_code"`
-	Description string `json:"description"`
+	OK          bool                `json:"ok"`
+	ErrorCode   int                 `json:"error_code"`
+	Description string              `json:"description"`
+	Parameters  *responseParameters `json:"parameters,omitempty"`
+}
+
+// responseParameters contains information about why a request was unsuccessful.
+type responseParameters struct {
+	// RetryAfter is the number of seconds left to wait before the request can be repeated.
+	RetryAfter int `json:"retry_after,omitempty"`
 }
 
 type userResponse struct {
@@ -190,6 +229,20 @@ func (e *responseError) Error() string {
 	return e.Description
 }
 
+// HTTPStatus returns the error code reported by the Bot API, which mirrors the HTTP status.
+func (e *responseError) HTTPStatus() int {
+	return e.ErrorCode
+}
+
+// RetryAfter returns the flood control delay reported by the Bot API, if any.
+func (e *responseError) RetryAfter() (time.Duration, bool) {
+	if e.Parameters == nil || e.Parameters.RetryAfter <= 0 {
+		return 0, false
+	}
+
+	return time.Duration(e.Parameters.RetryAfter) * time.Second, true
+}
+
 // Name returns the name of the channel based on its type.
 func (c *Chat) Name() string {
 	if c.Type == "private" || c.Type == "channel" && c.Username != "" {
@@ -229,9 +282,45 @@ func createSendMessagePayload(message, channel string, config *Config) SendMessa
 	}
 
 	// only HTML parse mode is supported for titles
-	if parseMode == ParseModes.HTML {
+	if parseMode == ParseModes.HTML && config.Title != "" {
 		payload.Text = fmt.Sprintf("<b>%v</b>\n%v", html.EscapeString(config.Title), message)
 	}
 
 	return payload
 }
+
+// createSendFilePayload creates the payload of a file with its caption, formatted
+// like the text of a message.
+func createSendFilePayload(caption, channel string, config *Config) SendFilePayload {
+	message := createSendMessagePayload(caption, channel, config)
+
+	return SendFilePayload{
+		ID:                  message.ID,
+		MessageThreadID:     message.MessageThreadID,
+		Caption:             message.Text,
+		ParseMode:           message.ParseMode,
+		DisableNotification: message.DisableNotification,
+	}
+}
+
+// formFields returns the payload as the fields of a multipart form.
+func (p *SendFilePayload) formFields() map[string]string {
+	fields := map[string]string{
+		"chat_id":              p.ID,
+		"disable_notification": strconv.FormatBool(p.DisableNotification),
+	}
+
+	if p.MessageThreadID != nil {
+		fields["message_thread_id"] = strconv.Itoa(*p.MessageThreadID)
+	}
+
+	if p.Caption != "" {
+		fields["caption"] = p.Caption
+	}
+
+	if p.ParseMode != "" {
+		fields["parse_mode"] = p.ParseMode
+	}
+
+	return fields
+}
@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"net/url"
 	"time"
+	"unicode/utf8"
 
 	"github.com/nicholas-fedor/shoutrrr/pkg/format"
 	"github.com/nicholas-fedor/shoutrrr/pkg/services/standard"
@@ -47,6 +48,16 @@ func (s *Service) GetID() string {
 	return Scheme
 }
 
+// MessageLimit returns the message limit of WeCom. The API limits messages to
+// maxLength bytes, and a rune takes up to utf8.UTFMax bytes.
+func (s *Service) MessageLimit() types.MessageLimit {
+	return types.MessageLimit{
+		ChunkSize:      maxLength / utf8.UTFMax,
+		TotalChunkSize: maxLength / utf8.UTFMax,
+		ChunkCount:     1,
+	}
+}
+
 // Initialize configures the service with a URL and logger.
 func (s *Service) Initialize(serviceURL *url.URL, logger types.StdLogger) error {
 	s.SetLogger(logger)
@@ -58,6 +69,11 @@ func (s *Service) Initialize(serviceURL *url.URL, logger types.StdLogger) error
 
 // Send delivers a notification message to WeCom.
 func (s *Service) Send(message string, params *types.Params) error {
+	return s.SendContext(context.Background(), message, params)
+}
+
+// SendContext delivers a notification message to WeCom, aborting when ctx is done.
+func (s *Service) SendContext(ctx context.Context, message string, params *types.Params) error {
 	if len(message) > maxLength {
 		return ErrLargeMessage
 	}
@@ -71,7 +87,7 @@ func (s *Service) Send(message string, params *types.Params) error {
 		return ErrKeyRequired
 	}
 
-	return s.doSend(config, message, params)
+	return s.doSend(ctx, config, message, params)
 }
 
 // SetHTTPClient sets a custom HTTP client for the service.
@@ -80,7 +96,7 @@ func (s *Service) SetHTTPClient(client types.HTTPClient) {
 }
 
 // doSend sends the notification to WeCom using the configured API URL.
-func (s *Service) doSend(config Config, message string, params *types.Params) error {
+func (s *Service) doSend(ctx context.Context, config Config, message string, params *types.Params) error {
 	postURL := fmt.Sprintf(apiURL, config.Key)
 
 	payload, err := s.preparePayload(message, config, params)
@@ -88,7 +104,7 @@ func (s *Service) doSend(config Config, message string, params *types.Params) er
 		return err
 	}
 
-	return s.sendRequest(postURL, payload)
+	return s.sendRequest(ctx, postURL, payload)
 }
 
 // getRequestBody constructs the request body for the WeCom API.
@@ -175,9 +191,9 @@ func (s *Service) preparePayload(
 }
 
 // sendRequest performs the HTTP POST request to the WeCom API and handles the response.
-func (s *Service) sendRequest(postURL string, payload []byte) error {
+func (s *Service) sendRequest(ctx context.Context, postURL string, payload []byte) error {
 	req, err := http.NewRequestWithContext(
-		context.Background(),
+		ctx,
 		http.MethodPost,
 		postURL,
 		bytes.NewReader(payload),
@@ -190,11 +206,17 @@ func (s *Service) sendRequest(postURL string, payload []byte) error {
 
 	client := s.httpClientOrDefault()
 
+	if err := types.WaitRateLimit(req.Context(), req.URL.Hostname()); err != nil {
+		return fmt.Errorf("waiting for rate limit: %w", err)
+	}
+
 	resp, err := client.Do(req)
 	if err != nil {
 		return fmt.Errorf("%w: making HTTP request: %w", ErrSendFailed, err)
 	}
 
+	types.ReportHTTPStatus(req.Context(), resp.StatusCode)
+
 	defer func() { _ = resp.Body.Close() }()
 
 	return s.handleResponse(resp)
@@ -12,6 +12,7 @@ import (
 	"strings"
 	"sync"
 	"time"
+	"unicode/utf8"
 
 	"github.com/nicholas-fedor/shoutrrr/pkg/format"
 	"github.com/nicholas-fedor/shoutrrr/pkg/services/standard"
@@ -108,6 +109,16 @@ func (s *Service) GetID() string {
 	return Scheme
 }
 
+// MessageLimit returns the default message limit of Zulip servers. The service
+// limits messages to contentMaxSize bytes, and a rune takes up to utf8.UTFMax bytes.
+func (s *Service) MessageLimit() types.MessageLimit {
+	return types.MessageLimit{
+		ChunkSize:      contentMaxSize / utf8.UTFMax,
+		TotalChunkSize: contentMaxSize / utf8.UTFMax,
+		ChunkCount:     1,
+	}
+}
+
 // Initialize configures the service with a URL and logger.
 func (s *Service) Initialize(serviceURL *url.URL, logger types.StdLogger) error {
 	s.SetLogger(logger)
@@ -137,6 +148,11 @@ func (s *Service) Send(message string, params *types.Params) error {
 	return s.SendWithContext(context.Background(), message, params)
 }
 
+// SendContext delivers a notification message to Zulip, aborting when ctx is done.
+func (s *Service) SendContext(ctx context.Context, message string, params *types.Params) error {
+	return s.SendWithContext(ctx, message, params)
+}
+
 // SendWithContext delivers a notification message to Zulip with context support.
 func (s *Service) SendWithContext(ctx context.Context, message string, params *types.Params) error {
 	// Clone the config to avoid modifying the original for this send operation.
@@ -228,11 +244,17 @@ func (s *Service) doSend(ctx context.Context, config *Config, message string) er
 
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
+	if err := types.WaitRateLimit(req.Context(), req.URL.Hostname()); err != nil {
+		return fmt.Errorf("waiting for rate limit: %w", err)
+	}
+
 	res, err := s.HTTPClient.Do(req)
 	if err != nil {
 		return fmt.Errorf("making HTTP POST request: %w", err)
 	}
 
+	types.ReportHTTPStatus(req.Context(), res.StatusCode)
+
 	defer func() { _ = res.Body.Close() }()
 
 	if res.StatusCode != http.StatusOK {
@@ -32,6 +32,16 @@ type Service struct {
 	propKeyResolver   format.PropKeyResolver
 }
 
+// mailBody holds the message of an email.
+type mailBody struct {
+	// text is the message, written as the plain part and, if html is empty, as the HTML part.
+	text string
+	// html is the HTML part rendered from a declared body format.
+	html string
+	// attachments are the files sent with the message.
+	attachments []attachment
+}
+
 const (
 	contentHTML                 = "text/html; charset=\"UTF-8\""
 	contentPlain                = "text/plain; charset=\"UTF-8\""
@@ -55,6 +65,17 @@ func (s *Service) GetID() string {
 	return Scheme
 }
 
+// MessageLimit returns the message limit of email, which has none.
+func (s *Service) MessageLimit() types.MessageLimit {
+	return types.MessageLimit{}
+}
+
+// Markup returns the markup of the HTML part, which messages declaring their
+// body format are sent with.
+func (s *Service) Markup() string {
+	return format.MarkupHTML
+}
+
 // Initialize loads ServiceConfig from serviceURL and sets logger for this Service.
 func (s *Service) Initialize(serviceURL *url.URL, logger types.StdLogger) error {
 	s.SetLogger(logger)
@@ -91,16 +112,63 @@ func (s *Service) Initialize(serviceURL *url.URL, logger types.StdLogger) error
 
 // Send sends a notification message to email recipients.
 func (s *Service) Send(message string, params *types.Params) error {
+	return s.send(context.Background(), message, nil, params)
+}
+
+// SendItems sends the text of the message items to email recipients, attaching
+// their files.
+func (s *Service) SendItems(items []types.MessageItem, params types.Params) error {
+	return s.SendItemsContext(context.Background(), items, params)
+}
+
+// SendItemsContext sends the text of the message items to email recipients,
+// attaching their files. The connection to the server is bounded by ctx and the
+// configured timeout.
+func (s *Service) SendItemsContext(ctx context.Context, items []types.MessageItem, params types.Params) error {
+	texts := make([]string, 0, len(items))
+	files := make([]*types.File, 0, len(items))
+
+	for _, item := range items {
+		if item.Text != "" {
+			texts = append(texts, item.Text)
+		}
+
+		if item.File != nil {
+			files = append(files, item.File)
+		}
+	}
+
+	return s.send(ctx, strings.Join(texts, "\n"), newAttachments(files), &params)
+}
+
+// send sends a message with its attachments to email recipients.
+func (s *Service) send(
+	ctx context.Context,
+	message string,
+	attachments []attachment,
+	params *types.Params,
+) error {
 	config := s.Config.Clone()
 	if err := s.propKeyResolver.UpdateConfigFromParams(&config, params); err != nil {
 		return fail(FailApplySendParams, err)
 	}
 
+	body, err := renderMailBody(message, params)
+	if err != nil {
+		return fail(FailApplySendParams, err)
+	}
+
+	body.attachments = attachments
+
+	if body.html != "" {
+		config.UseHTML = true
+	}
+
 	if config.SkipTLSVerify {
 		s.Log("Warning: TLS verification is disabled, making connections insecure")
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), config.Timeout)
+	ctx, cancel := context.WithTimeout(ctx, config.Timeout)
 	defer cancel()
 
 	client, err := getClientConnection(ctx, s.Config)
@@ -108,7 +176,23 @@ func (s *Service) Send(message string, params *types.Params) error {
 		return fail(FailGetSMTPClient, err)
 	}
 
-	return s.doSend(client, message, &config)
+	return s.doSend(client, body, &config)
+}
+
+// renderMailBody renders the plain and HTML parts of a message declaring its body
+// format. Other messages are sent as is.
+func renderMailBody(message string, params *types.Params) (mailBody, error) {
+	text, declared, err := format.RenderBody(message, params, format.MarkupText)
+	if err != nil || !declared {
+		return mailBody{text: message}, err
+	}
+
+	html, _, err := format.RenderBody(message, params, format.MarkupHTML)
+	if err != nil {
+		return mailBody{}, err
+	}
+
+	return mailBody{text: text, html: html}, nil
 }
 
 // getClientConnection establishes a connection to the SMTP server using the provided configuration.
@@ -147,7 +231,7 @@ func getClientConnection(ctx context.Context, config *Config) (*smtp.Client, err
 }
 
 // doSend sends an email message using the provided SMTP client and configuration.
-func (s *Service) doSend(client *smtp.Client, message string, config *Config) failure {
+func (s *Service) doSend(client *smtp.Client, body mailBody, config *Config) failure {
 	config.FixEmailTags()
 
 	clientHost := s.resolveClientHost(config)
@@ -156,7 +240,7 @@ func (s *Service) doSend(client *smtp.Client, message string, config *Config) fa
 		return fail(FailHandshake, err)
 	}
 
-	if config.UseHTML {
+	if config.UseHTML || len(body.attachments) > 0 {
 		b := make([]byte, boundaryByteLen)
 		if _, err := rand.Read(b); err != nil {
 			return fail(FailUnknown, err) // Fallback error for rare case
@@ -198,7 +282,7 @@ func (s *Service) doSend(client *smtp.Client, message string, config *Config) fa
 	var errs []error
 
 	for _, toAddress := range config.ToAddresses {
-		if err := s.sendToRecipient(client, toAddress, config, message); err != nil {
+		if err := s.sendToRecipient(client, toAddress, config, body); err != nil {
 			errs = append(errs, fail(FailSendRecipient, err, toAddress))
 			s.Logf("Failed to send to %q: %v", toAddress, err)
 
@@ -259,16 +343,9 @@ func (s *Service) getAuth(config *Config) (smtp.Auth, failure) {
 }
 
 // getHeaders constructs email headers for the SMTP message.
-func (s *Service) getHeaders(toAddress, subject string) map[string]string {
+func (s *Service) getHeaders(toAddress, subject, contentType string) map[string]string {
 	conf := s.Config
 
-	var contentType string
-	if conf.UseHTML {
-		contentType = fmt.Sprintf(contentMultipart, s.multipartBoundary)
-	} else {
-		contentType = contentPlain
-	}
-
 	return map[string]string{
 		"Subject":      subject,
 		"Date":         time.Now().Format(time.RFC1123Z),
@@ -300,7 +377,7 @@ func (s *Service) sendToRecipient(
 	client *smtp.Client,
 	toAddress string,
 	config *Config,
-	message string,
+	body mailBody,
 ) failure {
 	// Set the sender and recipient first
 	if err := client.Mail(config.FromAddress); err != nil {
@@ -317,19 +394,13 @@ func (s *Service) sendToRecipient(
 		return fail(FailOpenDataStream, err)
 	}
 
-	if err := writeHeaders(writeCloser, s.getHeaders(toAddress, config.Subject)); err != nil {
+	contentType := s.messageContentType(body, config.UseHTML)
+	if err := writeHeaders(writeCloser, s.getHeaders(toAddress, config.Subject, contentType)); err != nil {
 		return err
 	}
 
-	var ferr failure
-	if config.UseHTML {
-		ferr = s.writeMultipartMessage(writeCloser, message)
-	} else {
-		ferr = s.writeMessagePart(writeCloser, message, "plain")
-	}
-
-	if ferr != nil {
-		return ferr
+	if err := s.writeMessage(writeCloser, body, config.UseHTML); err != nil {
+		return err
 	}
 
 	if err = writeCloser.Close(); err != nil {
@@ -367,7 +438,7 @@ func (s *Service) writeMessagePart(
 }
 
 // writeMultipartMessage writes a multipart email message to the provided writer.
-func (s *Service) writeMultipartMessage(writeCloser io.WriteCloser, message string) failure {
+func (s *Service) writeMultipartMessage(writeCloser io.WriteCloser, body mailBody) failure {
 	if err := writeMultipartHeader(
 		writeCloser,
 		s.multipartBoundary,
@@ -376,7 +447,7 @@ func (s *Service) writeMultipartMessage(writeCloser io.WriteCloser, message stri
 		return fail(FailPlainHeader, err)
 	}
 
-	if err := s.writeMessagePart(writeCloser, message, "plain"); err != nil {
+	if err := s.writeMessagePart(writeCloser, body.text, "plain"); err != nil {
 		return err
 	}
 
@@ -388,7 +459,7 @@ func (s *Service) writeMultipartMessage(writeCloser io.WriteCloser, message stri
 		return fail(FailHTMLHeader, err)
 	}
 
-	if err := s.writeMessagePart(writeCloser, message, "HTML"); err != nil {
+	if err := s.writeHTMLPart(writeCloser, body); err != nil {
 		return err
 	}
 
@@ -399,6 +470,24 @@ func (s *Service) writeMultipartMessage(writeCloser io.WriteCloser, message stri
 	return nil
 }
 
+// writeHTMLPart writes the HTML part of a multipart email message. A rendered HTML
+// body is written as is, unless there is an HTML template.
+func (s *Service) writeHTMLPart(writeCloser io.WriteCloser, body mailBody) failure {
+	if body.html == "" {
+		return s.writeMessagePart(writeCloser, body.text, "HTML")
+	}
+
+	if _, found := s.GetTemplate("HTML"); found {
+		return s.writeMessagePart(writeCloser, body.html, "HTML")
+	}
+
+	if _, err := fmt.Fprint(writeCloser, body.html); err != nil {
+		return fail(FailMessageRaw, err)
+	}
+
+	return nil
+}
+
 // writeMultipartHeader writes a multipart boundary header to the provided writer.
 func writeMultipartHeader(writeCloser io.WriteCloser, boundary, contentType string) error {
 	suffix := "\n"
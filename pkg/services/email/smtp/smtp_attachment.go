@@ -0,0 +1,284 @@
+package smtp
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/nicholas-fedor/shoutrrr/pkg/types"
+)
+
+const (
+	contentMixed          = "multipart/mixed; boundary=%s"
+	contentRelated        = "multipart/related; boundary=%s"
+	contentOctetStream    = "application/octet-stream"
+	mixedBoundaryPrefix   = "mixed_"
+	relatedBoundaryPrefix = "related_"
+	base64LineLength      = 76 // base64LineLength is the maximum line length of base64 encoded MIME parts (RFC 2045).
+)
+
+// attachment is a file sent with an email message.
+type attachment struct {
+	name        string
+	contentType string
+	contentID   string
+	data        []byte
+}
+
+// newAttachments creates the attachments of the files of message items, giving
+// each a distinct content ID. Files with the same name are told apart by a
+// counter, e.g. logo.png and logo-2.png.
+//
+// Parameters:
+//   - files: the files to attach.
+//
+// Returns:
+//   - []attachment: the attachments.
+func newAttachments(files []*types.File) []attachment {
+	attachments := make([]attachment, len(files))
+	used := make(map[string]bool, len(files))
+
+	for i, file := range files {
+		attachments[i] = newAttachment(file, i)
+
+		id := attachments[i].contentID
+		ext := filepath.Ext(id)
+
+		for n := 2; used[id]; n++ {
+			id = fmt.Sprintf("%s-%d%s", strings.TrimSuffix(attachments[i].contentID, ext), n, ext)
+		}
+
+		used[id] = true
+		attachments[i].contentID = id
+	}
+
+	return attachments
+}
+
+// newAttachment creates an attachment from the file of a message item, detecting
+// its content type from the file name or, failing that, from its contents.
+//
+// Parameters:
+//   - file: the file to attach.
+//   - index: the index of the attachment, naming files without a name.
+//
+// Returns:
+//   - attachment: the attachment.
+func newAttachment(file *types.File, index int) attachment {
+	name := filepath.Base(file.Name)
+	if file.Name == "" {
+		name = fmt.Sprintf("attachment%d", index+1)
+	}
+
+	contentType := mime.TypeByExtension(filepath.Ext(name))
+	if contentType == "" {
+		contentType = http.DetectContentType(file.Data)
+	}
+
+	return attachment{name: name, contentType: contentType, contentID: contentID(name), data: file.Data}
+}
+
+// contentID returns the content ID of a file name, with the characters other
+// than ASCII letters, digits, '.', '-' and '_' replaced by '_' so that it is a
+// valid Content-ID header value.
+func contentID(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '-', r == '_':
+			return r
+		default:
+			return '_'
+		}
+	}, name)
+}
+
+// splitAttachments splits the attachments of body into the ones its HTML part
+// references by content ID, e.g. <img src="cid:logo.png">, and the others.
+//
+// Parameters:
+//   - body: the message body.
+//   - useHTML: whether the message has an HTML part.
+//
+// Returns:
+//   - []attachment: the attachments displayed inline.
+//   - []attachment: the attachments displayed as files.
+func splitAttachments(body mailBody, useHTML bool) ([]attachment, []attachment) {
+	if !useHTML {
+		return nil, body.attachments
+	}
+
+	html := body.html
+	if html == "" {
+		html = body.text
+	}
+
+	var inline, attached []attachment
+
+	for _, file := range body.attachments {
+		if referencesContentID(html, file.contentID) {
+			inline = append(inline, file)
+		} else {
+			attached = append(attached, file)
+		}
+	}
+
+	return inline, attached
+}
+
+// referencesContentID reports whether html references the content ID as a
+// whole attribute or CSS url() value, e.g. "cid:logo.png" but not "cid:logo.png.bak".
+func referencesContentID(html, id string) bool {
+	ref := "cid:" + id
+
+	for {
+		index := strings.Index(html, ref)
+		if index < 0 {
+			return false
+		}
+
+		html = html[index+len(ref):]
+		if html != "" && strings.ContainsRune(`"')`, rune(html[0])) {
+			return true
+		}
+	}
+}
+
+// messageContentType returns the content type of an email message.
+func (s *Service) messageContentType(body mailBody, useHTML bool) string {
+	inline, attached := splitAttachments(body, useHTML)
+	if len(attached) > 0 {
+		return fmt.Sprintf(contentMixed, mixedBoundaryPrefix+s.multipartBoundary)
+	}
+
+	return s.bodyContentType(inline, useHTML)
+}
+
+// bodyContentType returns the content type of the message part of an email,
+// which includes the attachments displayed inline.
+func (s *Service) bodyContentType(inline []attachment, useHTML bool) string {
+	switch {
+	case len(inline) > 0:
+		return fmt.Sprintf(contentRelated, relatedBoundaryPrefix+s.multipartBoundary)
+	case useHTML:
+		return fmt.Sprintf(contentMultipart, s.multipartBoundary)
+	default:
+		return contentPlain
+	}
+}
+
+// writeMessage writes the contents of an email message with its attachments,
+// matching the content type returned by messageContentType.
+func (s *Service) writeMessage(writeCloser io.WriteCloser, body mailBody, useHTML bool) failure {
+	inline, attached := splitAttachments(body, useHTML)
+	if len(attached) == 0 {
+		return s.writeRelatedMessage(writeCloser, body, inline, useHTML)
+	}
+
+	boundary := mixedBoundaryPrefix + s.multipartBoundary
+	if err := writeMultipartHeader(writeCloser, boundary, s.bodyContentType(inline, useHTML)); err != nil {
+		return fail(FailWriteAttachment, err)
+	}
+
+	if err := s.writeRelatedMessage(writeCloser, body, inline, useHTML); err != nil {
+		return err
+	}
+
+	for _, file := range attached {
+		if err := writeAttachment(writeCloser, boundary, file, false); err != nil {
+			return fail(FailWriteAttachment, err)
+		}
+	}
+
+	if err := writeMultipartHeader(writeCloser, boundary, ""); err != nil {
+		return fail(FailMultiEndHeader, err)
+	}
+
+	return nil
+}
+
+// writeRelatedMessage writes the message part of an email, followed by the
+// attachments it displays inline if there are any.
+func (s *Service) writeRelatedMessage(
+	writeCloser io.WriteCloser,
+	body mailBody,
+	inline []attachment,
+	useHTML bool,
+) failure {
+	if !useHTML {
+		return s.writeMessagePart(writeCloser, body.text, "plain")
+	}
+
+	if len(inline) == 0 {
+		return s.writeMultipartMessage(writeCloser, body)
+	}
+
+	boundary := relatedBoundaryPrefix + s.multipartBoundary
+	if err := writeMultipartHeader(writeCloser, boundary, fmt.Sprintf(contentMultipart, s.multipartBoundary)); err != nil {
+		return fail(FailWriteAttachment, err)
+	}
+
+	if err := s.writeMultipartMessage(writeCloser, body); err != nil {
+		return err
+	}
+
+	for _, file := range inline {
+		if err := writeAttachment(writeCloser, boundary, file, true); err != nil {
+			return fail(FailWriteAttachment, err)
+		}
+	}
+
+	if err := writeMultipartHeader(writeCloser, boundary, ""); err != nil {
+		return fail(FailMultiEndHeader, err)
+	}
+
+	return nil
+}
+
+// writeAttachment writes a base64 encoded attachment part of a multipart email
+// message. Inline attachments are identified by their content ID.
+func writeAttachment(writer io.Writer, boundary string, file attachment, inline bool) error {
+	mediaType, typeParams, err := mime.ParseMediaType(file.contentType)
+	if err != nil {
+		mediaType, typeParams = contentOctetStream, map[string]string{}
+	}
+
+	typeParams["name"] = file.name
+
+	disposition := "attachment"
+	if inline {
+		disposition = "inline"
+	}
+
+	headers := fmt.Sprintf(
+		"\n\n--%s\nContent-Type: %s\nContent-Transfer-Encoding: base64\nContent-Disposition: %s\n",
+		boundary,
+		mime.FormatMediaType(mediaType, typeParams),
+		mime.FormatMediaType(disposition, map[string]string{"filename": file.name}),
+	)
+	if inline {
+		headers += fmt.Sprintf("Content-ID: <%s>\n", file.contentID)
+	}
+
+	if _, err := fmt.Fprint(writer, headers+"\n"); err != nil {
+		return fmt.Errorf("writing attachment headers: %w", err)
+	}
+
+	encoded := base64.StdEncoding.EncodeToString(file.data)
+	for len(encoded) > base64LineLength {
+		if _, err := fmt.Fprint(writer, encoded[:base64LineLength]+"\n"); err != nil {
+			return fmt.Errorf("writing attachment data: %w", err)
+		}
+
+		encoded = encoded[base64LineLength:]
+	}
+
+	if _, err := fmt.Fprint(writer, encoded); err != nil {
+		return fmt.Errorf("writing attachment data: %w", err)
+	}
+
+	return nil
+}
@@ -17,7 +17,7 @@ import (
 type Config struct {
 	Host            string        `desc:"SMTP server hostname or IP address"                     url:"Host"`
 	Username        string        `desc:"SMTP server username"                                   url:"User" default:""`
-	Password        string        `desc:"SMTP server password or hash (for OAuth2)"              url:"Pass" default:""`
+	Password        string        `desc:"SMTP server password or hash (for OAuth2)"              url:"Pass" default:"" sensitive:"true"`
 	Port            uint16        `desc:"SMTP server port, common ones are 25, 465, 587 or 2525" url:"Port" default:"25"`
 	FromAddress     string        `desc:"E-mail address that the mail are sent from"                                                        key:"fromaddress,from"`
 	FromName        string        `desc:"Name of the sender"                                                                                key:"fromname"             optional:"yes"`
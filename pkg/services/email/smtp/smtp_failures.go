@@ -53,6 +53,8 @@ const (
 	FailApplySendParams
 	// FailHandshake is returned when the initial HELLO handshake returned an error.
 	FailHandshake
+	// FailWriteAttachment is returned when an attachment or its multipart header could not be written.
+	FailWriteAttachment
 )
 
 // fail creates an SMTP-specific failure with a descriptive message and ID,
@@ -101,6 +103,8 @@ func fail(failureID failures.FailureID, err error, args ...any) failure {
 		msg = "error applying params to send config"
 	case FailHandshake:
 		msg = "server did not accept the handshake"
+	case FailWriteAttachment:
+		msg = "error writing attachment"
 	// case FailUnknown:
 	default:
 		msg = "an unknown error occurred"
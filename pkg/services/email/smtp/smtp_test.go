@@ -10,6 +10,7 @@ import (
 	"net/url"
 	"os"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 	"unsafe"
@@ -190,6 +191,142 @@ var _ = ginkgo.Describe("the SMTP service", func() {
 					To(matchFailure(FailApplySendParams))
 			})
 		})
+		ginkgo.When("an unknown body format is declared", func() {
+			ginkgo.It("should fail to send messages", func() {
+				service := Service{Config: &Config{}}
+				gomega.Expect(service.Send("test message", &types.Params{"format": "rtf"})).
+					To(matchFailure(FailApplySendParams))
+			})
+		})
+	})
+
+	ginkgo.When("the message declares its body format", func() {
+		ginkgo.It("should render the plain and HTML parts", func() {
+			body, err := renderMailBody("**Disk** at [90%](https://example.com)", &types.Params{"format": "markdown"})
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			gomega.Expect(body.text).To(gomega.Equal("Disk at 90% (https://example.com)"))
+			gomega.Expect(body.html).
+				To(gomega.Equal(`<strong>Disk</strong> at <a href="https://example.com">90%</a>`))
+		})
+		ginkgo.It("should write the rendered HTML part without a pre tag", func() {
+			writer := &bufferCloser{}
+			service := Service{}
+			err := service.writeMultipartMessage(writer, mailBody{text: "Disk", html: "<b>Disk</b>"})
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			gomega.Expect(writer.String()).To(gomega.ContainSubstring(contentHTML + "\n\n<b>Disk</b>"))
+			gomega.Expect(writer.String()).ToNot(gomega.ContainSubstring("<pre>"))
+		})
+		ginkgo.It("should send messages without a declared format as is", func() {
+			body, err := renderMailBody("**Disk**", nil)
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			gomega.Expect(body).To(gomega.Equal(mailBody{text: "**Disk**"}))
+		})
+	})
+
+	ginkgo.When("the message has attachments", func() {
+		png := []byte("\x89PNG\r\n\x1a\n")
+
+		ginkgo.It("should detect the content type of the files", func() {
+			gomega.Expect(newAttachment(&types.File{Name: "dir/logo.png", Data: png}, 0)).
+				To(gomega.Equal(attachment{name: "logo.png", contentType: "image/png", contentID: "logo.png", data: png}))
+			gomega.Expect(newAttachment(&types.File{Name: "report", Data: []byte("%PDF-1.7")}, 0).contentType).
+				To(gomega.Equal("application/pdf"))
+			gomega.Expect(newAttachment(&types.File{Data: png}, 1).name).To(gomega.Equal("attachment2"))
+		})
+		ginkgo.It("should give them distinct and valid content IDs", func() {
+			attachments := newAttachments([]*types.File{
+				{Name: "logo.png", Data: png},
+				{Name: "a/logo.png", Data: png},
+				{Name: "my logo>\r\nBcc: x.png", Data: png},
+			})
+
+			gomega.Expect(attachments[0].contentID).To(gomega.Equal("logo.png"))
+			gomega.Expect(attachments[1].contentID).To(gomega.Equal("logo-2.png"))
+			gomega.Expect(attachments[2].contentID).To(gomega.Equal("my_logo___Bcc__x.png"))
+		})
+		ginkgo.It("should write them as base64 encoded parts of a mixed message", func() {
+			writer := &bufferCloser{}
+			service := Service{multipartBoundary: "b"}
+			body := mailBody{
+				text:        "Disk full",
+				attachments: []attachment{{name: "df.txt", contentType: contentPlain, data: bytes.Repeat([]byte("x"), 60)}},
+			}
+
+			gomega.Expect(service.messageContentType(body, false)).To(gomega.Equal("multipart/mixed; boundary=mixed_b"))
+			gomega.Expect(service.writeMessage(writer, body, false)).To(gomega.Succeed())
+			gomega.Expect(writer.String()).To(gomega.Equal(
+				"\n\n--mixed_b\nContent-Type: " + contentPlain + "\n\nDisk full" +
+					"\n\n--mixed_b\nContent-Type: text/plain; charset=UTF-8; name=df.txt\n" +
+					"Content-Transfer-Encoding: base64\nContent-Disposition: attachment; filename=df.txt\n\n" +
+					strings.Repeat("eHh4", 19) + "\n" + "eHh4" +
+					"\n\n--mixed_b--",
+			))
+		})
+		ginkgo.It("should display the files referenced by content ID inline", func() {
+			writer := &bufferCloser{}
+			service := Service{multipartBoundary: "b"}
+			body := mailBody{
+				text: "Disk full",
+				html: `<img src="cid:logo.png">`,
+				attachments: []attachment{
+					{name: "logo.png", contentType: "image/png", contentID: "logo.png", data: png},
+					{name: "df.txt", contentType: contentPlain, contentID: "df.txt", data: []byte("x")},
+				},
+			}
+
+			gomega.Expect(service.messageContentType(body, true)).To(gomega.Equal("multipart/mixed; boundary=mixed_b"))
+			gomega.Expect(service.writeMessage(writer, body, true)).To(gomega.Succeed())
+
+			output := writer.String()
+			gomega.Expect(output).
+				To(gomega.ContainSubstring("\n\n--mixed_b\nContent-Type: multipart/related; boundary=related_b\n\n"))
+			gomega.Expect(output).
+				To(gomega.ContainSubstring("\n\n--related_b\nContent-Type: multipart/alternative; boundary=b\n\n"))
+			gomega.Expect(output).To(gomega.ContainSubstring(
+				"\n\n--related_b\nContent-Type: image/png; name=logo.png\nContent-Transfer-Encoding: base64\n" +
+					"Content-Disposition: inline; filename=logo.png\nContent-ID: <logo.png>\n\n",
+			))
+			gomega.Expect(output).To(gomega.ContainSubstring("Content-Disposition: attachment; filename=df.txt"))
+
+			body.attachments = body.attachments[:1]
+			gomega.Expect(service.messageContentType(body, true)).
+				To(gomega.Equal("multipart/related; boundary=related_b"))
+		})
+		ginkgo.It("should only match whole content ID references", func() {
+			gomega.Expect(referencesContentID(`<img src="cid:logo.png">`, "logo.png")).To(gomega.BeTrue())
+			gomega.Expect(referencesContentID(`<img src='cid:logo.png'>`, "logo.png")).To(gomega.BeTrue())
+			gomega.Expect(referencesContentID(`<div style="background: url(cid:logo.png)">`, "logo.png")).To(gomega.BeTrue())
+			gomega.Expect(referencesContentID(`<img src="cid:logo.png.bak"> cid:logo.png`, "logo.png")).To(gomega.BeFalse())
+			gomega.Expect(referencesContentID(`<img src="cid:logo.png.bak"><img src="cid:logo.png">`, "logo.png")).
+				To(gomega.BeTrue())
+		})
+		ginkgo.It("should fail when writing an attachment", func() {
+			body := mailBody{attachments: []attachment{{name: "df.txt", contentType: contentPlain}}}
+			err := (&Service{}).writeMessage(testutils.CreateFailWriter(3), body, false)
+			gomega.Expect(err).To(matchFailure(FailWriteAttachment))
+		})
+		ginkgo.It("should send them to every recipient", func() {
+			serviceURL := testutils.URLMust(BaseNoAuthURL)
+			gomega.Expect(service.Initialize(serviceURL, logger)).To(gomega.Succeed())
+
+			textCon, tcfaker := testutils.CreateTextConFaker([]string{
+				"250 mx.example.com at your service",
+				"250 Sender OK",
+				"250 Receiver OK",
+				"354 Go ahead",
+				"250 Data OK",
+				"221 OK",
+			}, "\r\n")
+			client := &smtp.Client{Text: textCon}
+			body := mailBody{text: "Disk full", attachments: []attachment{{name: "logo.png", contentType: "image/png", data: png}}}
+
+			gomega.Expect(service.doSend(client, body, service.Config)).To(gomega.Succeed())
+
+			received := tcfaker.GetClientSentences()
+			gomega.Expect(received).To(gomega.ContainElement(gomega.HavePrefix("Content-Type: multipart/mixed; boundary=mixed_")))
+			gomega.Expect(received).To(gomega.ContainElement("Content-Disposition: attachment; filename=logo.png"))
+			gomega.Expect(received).To(gomega.ContainElement("iVBORw0KGgo="))
+		})
 	})
 
 	ginkgo.When("the underlying stream stops working", func() {
@@ -204,31 +341,31 @@ var _ = ginkgo.Describe("the SMTP service", func() {
 		})
 		ginkgo.It("should fail when writing multipart plain header", func() {
 			writer := testutils.CreateFailWriter(1)
-			err := service.writeMultipartMessage(writer, message)
+			err := service.writeMultipartMessage(writer, mailBody{text: message})
 			gomega.Expect(err).To(gomega.HaveOccurred())
 			gomega.Expect(err).To(matchFailure(FailPlainHeader))
 		})
 		ginkgo.It("should fail when writing multipart plain message", func() {
 			writer := testutils.CreateFailWriter(2)
-			err := service.writeMultipartMessage(writer, message)
+			err := service.writeMultipartMessage(writer, mailBody{text: message})
 			gomega.Expect(err).To(gomega.HaveOccurred())
 			gomega.Expect(err).To(matchFailure(FailMessageRaw))
 		})
 		ginkgo.It("should fail when writing multipart HTML header", func() {
 			writer := testutils.CreateFailWriter(4)
-			err := service.writeMultipartMessage(writer, message)
+			err := service.writeMultipartMessage(writer, mailBody{text: message})
 			gomega.Expect(err).To(gomega.HaveOccurred())
 			gomega.Expect(err).To(matchFailure(FailHTMLHeader))
 		})
 		ginkgo.It("should fail when writing multipart HTML message", func() {
 			writer := testutils.CreateFailWriter(5)
-			err := service.writeMultipartMessage(writer, message)
+			err := service.writeMultipartMessage(writer, mailBody{text: message})
 			gomega.Expect(err).To(gomega.HaveOccurred())
 			gomega.Expect(err).To(matchFailure(FailMessageRaw))
 		})
 		ginkgo.It("should fail when writing multipart end header", func() {
 			writer := testutils.CreateFailWriter(6)
-			err := service.writeMultipartMessage(writer, message)
+			err := service.writeMultipartMessage(writer, mailBody{text: message})
 			gomega.Expect(err).To(gomega.HaveOccurred())
 			gomega.Expect(err).To(matchFailure(FailMultiEndHeader))
 		})
@@ -602,7 +739,7 @@ var _ = ginkgo.Describe("the SMTP service", func() {
 				fakeTLSEnabled(client, serviceURL.Hostname())
 
 				config := localService.Config
-				err = localService.doSend(client, "Test message", config)
+				err = localService.doSend(client, mailBody{text: "Test message"}, config)
 				gomega.Expect(err).ToNot(gomega.HaveOccurred())
 				gomega.Expect(buf.String()).
 					To(gomega.ContainSubstring("Warning: Ignoring session closure error (delivery succeeded)"))
@@ -639,7 +776,7 @@ var _ = ginkgo.Describe("the SMTP service", func() {
 				cr.Set(reflect.ValueOf(&mockConn{}))
 
 				config := localService.Config
-				err = localService.doSend(client, "Test message", config)
+				err = localService.doSend(client, mailBody{text: "Test message"}, config)
 				gomega.Expect(err).ToNot(gomega.HaveOccurred())
 				gomega.Expect(buf.String()).
 					To(gomega.ContainSubstring("Warning: Failed to close SMTP client connection: mock close error"))
@@ -683,7 +820,7 @@ var _ = ginkgo.Describe("the SMTP service", func() {
 
 				config := service.Config
 				config.ToAddresses = []string{"rec1@example.com", "rec2@example.com"}
-				err = service.doSend(client, "Test message", config)
+				err = service.doSend(client, mailBody{text: "Test message"}, config)
 				gomega.Expect(err).To(gomega.HaveOccurred())
 				gomega.Expect(err).To(matchFailure(FailSendRecipient))
 				gomega.Expect(err.Error()).
@@ -835,7 +972,7 @@ func testIntegration(
 	textCon, tcfaker := testutils.CreateTextConFaker(responses, "\r\n")
 	client := &smtp.Client{Text: textCon}
 	fakeTLSEnabled(client, serviceURL.Hostname())
-	ferr := service.doSend(client, "Test message", service.Config)
+	ferr := service.doSend(client, mailBody{text: "Test message"}, service.Config)
 
 	received := tcfaker.GetClientSentences()
 	for _, expected := range expectRec {
@@ -872,7 +1009,7 @@ func testSendRecipient(testURL string, responses []string) failures.Failure {
 
 	config := &Config{}
 	message := "message body"
-	ferr := service.sendToRecipient(client, "r@example.com", config, message)
+	ferr := service.sendToRecipient(client, "r@example.com", config, mailBody{text: message})
 
 	logger.Printf("\n%s", tcfaker.GetConversation(false))
 
@@ -882,3 +1019,12 @@ func testSendRecipient(testURL string, responses []string) failures.Failure {
 
 	return nil
 }
+
+// bufferCloser is an in-memory io.WriteCloser.
+type bufferCloser struct {
+	bytes.Buffer
+}
+
+func (b *bufferCloser) Close() error {
+	return nil
+}
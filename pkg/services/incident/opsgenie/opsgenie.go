@@ -41,6 +41,25 @@ func (s *Service) GetID() string {
 	return Scheme
 }
 
+// MessageLimit returns the message limit of OpsGenie alerts, whose description
+// holds up to 15,000 characters.
+func (s *Service) MessageLimit() types.MessageLimit {
+	return types.MessageLimit{ChunkSize: 15000, TotalChunkSize: 15000, ChunkCount: 1}
+}
+
+// LevelMapping maps message levels to OpsGenie alert priorities.
+func (s *Service) LevelMapping() types.LevelMapping {
+	return types.LevelMapping{
+		Param: "priority",
+		Values: map[types.MessageLevel]string{
+			types.Debug:   "P5",
+			types.Info:    "P4",
+			types.Warning: "P3",
+			types.Error:   "P2",
+		},
+	}
+}
+
 // Initialize configures the service with a URL and logger.
 func (s *Service) Initialize(serviceURL *url.URL, logger types.StdLogger) error {
 	s.SetLogger(logger)
@@ -53,6 +72,11 @@ func (s *Service) Initialize(serviceURL *url.URL, logger types.StdLogger) error
 // Send delivers a notification message to OpsGenie.
 // See: https://docs.opsgenie.com/docs/alert-api#create-alert
 func (s *Service) Send(message string, params *types.Params) error {
+	return s.SendContext(context.Background(), message, params)
+}
+
+// SendContext delivers a notification message to OpsGenie, aborting when ctx is done.
+func (s *Service) SendContext(ctx context.Context, message string, params *types.Params) error {
 	config := s.Config
 	serviceURL := fmt.Sprintf(
 		alertEndpointTemplate,
@@ -65,7 +89,7 @@ func (s *Service) Send(message string, params *types.Params) error {
 		return err
 	}
 
-	return s.sendAlert(serviceURL, config.APIKey, &payload)
+	return s.sendAlert(ctx, serviceURL, config.APIKey, &payload)
 }
 
 // SetHTTPClient sets a custom HTTP client for the service.
@@ -127,7 +151,11 @@ func (s *Service) newAlertPayload(
 }
 
 // sendAlert sends an alert to OpsGenie using the specified URL and API key.
-func (s *Service) sendAlert(serviceURL, apiKey string, payload *AlertPayload) error {
+func (s *Service) sendAlert(
+	ctx context.Context,
+	serviceURL, apiKey string,
+	payload *AlertPayload,
+) error {
 	jsonBody, err := json.Marshal(payload)
 	if err != nil {
 		return fmt.Errorf("marshaling alert payload to JSON: %w", err)
@@ -136,7 +164,7 @@ func (s *Service) sendAlert(serviceURL, apiKey string, payload *AlertPayload) er
 	jsonBuffer := bytes.NewBuffer(jsonBody)
 
 	ctx, cancel := context.WithTimeout(
-		context.Background(),
+		ctx,
 		defaultHTTPTimeout,
 	)
 	defer cancel()
@@ -159,11 +187,17 @@ func (s *Service) sendAlert(serviceURL, apiKey string, payload *AlertPayload) er
 		client = &http.Client{}
 	}
 
+	if err := types.WaitRateLimit(req.Context(), req.URL.Hostname()); err != nil {
+		return fmt.Errorf("waiting for rate limit: %w", err)
+	}
+
 	resp, err := client.Do(req)
 	if err != nil {
 		return fmt.Errorf("failed to send notification to OpsGenie: %w", err)
 	}
 
+	types.ReportHTTPStatus(req.Context(), resp.StatusCode)
+
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode < http.StatusOK || resp.StatusCode > httpSuccessMax {
@@ -12,7 +12,7 @@ import (
 
 // Config holds the configuration for the OpsGenie service.
 type Config struct {
-	APIKey      string            `desc:"The OpsGenie API key"                                                                                   url:"path"`
+	APIKey      string            `desc:"The OpsGenie API key"                                                                                   url:"path" sensitive:"true"`
 	Host        string            `desc:"The OpsGenie API host. Use 'api.eu.opsgenie.com' for EU instances"                                      url:"host" default:"api.opsgenie.com"`
 	Port        uint16            `desc:"The OpsGenie API port."                                                                                 url:"port" default:"443"`
 	Alias       string            `desc:"Client-defined identifier of the alert"                                                                                                       key:"alias"       optional:"true"`
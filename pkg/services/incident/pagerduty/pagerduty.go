@@ -39,6 +39,29 @@ func (s *Service) GetID() string {
 	return Scheme
 }
 
+// MessageLimit returns the message limit of PagerDuty events, whose summary holds
+// up to maxMessageLength characters.
+func (s *Service) MessageLimit() types.MessageLimit {
+	return types.MessageLimit{
+		ChunkSize:      maxMessageLength,
+		TotalChunkSize: maxMessageLength,
+		ChunkCount:     1,
+	}
+}
+
+// LevelMapping maps message levels to PagerDuty event severities.
+func (s *Service) LevelMapping() types.LevelMapping {
+	return types.LevelMapping{
+		Param: "severity",
+		Values: map[types.MessageLevel]string{
+			types.Debug:   "info",
+			types.Info:    "info",
+			types.Warning: "warning",
+			types.Error:   "error",
+		},
+	}
+}
+
 // Initialize loads ServiceConfig from serviceURL and sets logger for this Service.
 func (s *Service) Initialize(serviceURL *url.URL, logger types.StdLogger) error {
 	s.SetLogger(logger)
@@ -69,6 +92,11 @@ func (s *Service) Send(message string, params *types.Params) error {
 	return s.SendWithContext(context.Background(), message, params)
 }
 
+// SendContext sends a notification message to PagerDuty, aborting when ctx is done.
+func (s *Service) SendContext(ctx context.Context, message string, params *types.Params) error {
+	return s.SendWithContext(ctx, message, params)
+}
+
 // SendWithContext sends a notification message to PagerDuty with context support
 // See: https://developer.pagerduty.com/docs/events-api-v2-overview
 func (s *Service) SendWithContext(
@@ -209,11 +237,17 @@ func (s *Service) sendAlert(ctx context.Context, endpoint string, payload *Event
 	}
 
 	// Send the HTTP request to PagerDuty
+	if err := types.WaitRateLimit(req.Context(), req.URL.Hostname()); err != nil {
+		return fmt.Errorf("waiting for rate limit: %w", err)
+	}
+
 	resp, err := s.httpClient.Do(req)
 	if err != nil {
 		return fmt.Errorf("failed to send notification to PagerDuty: %w", err)
 	}
 
+	types.ReportHTTPStatus(req.Context(), resp.StatusCode)
+
 	defer func() { _ = resp.Body.Close() }()
 
 	// Check if the response status indicates success (2xx)
@@ -12,7 +12,7 @@ import (
 
 // Config holds the configuration for the PagerDuty service.
 type Config struct {
-	IntegrationKey string `desc:"The PagerDuty API integration key"                                                            url:"path"`
+	IntegrationKey string `desc:"The PagerDuty API integration key"                                                            url:"path" sensitive:"true"`
 	Host           string `desc:"The PagerDuty API host."                                                                      url:"host" default:"events.pagerduty.com"`
 	Port           uint16 `desc:"The PagerDuty API port."                                                                      url:"port" default:"443"`
 	Severity       string `desc:"The perceived severity of the status the event (critical, error, warning, or info); required"            default:"error"                key:"severity"`
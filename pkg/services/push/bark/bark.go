@@ -77,6 +77,12 @@ func (s *Service) GetID() string {
 	return Scheme
 }
 
+// MessageLimit returns the message limit of Bark. APNs payloads are limited to
+// 4 KB, including the title, and a rune takes up to utf8.UTFMax bytes.
+func (s *Service) MessageLimit() types.MessageLimit {
+	return types.MessageLimit{ChunkSize: 768, TotalChunkSize: 768, ChunkCount: 1}
+}
+
 // Initialize sets up the Bark service with configuration from the provided URL.
 //
 // Parameters:
@@ -110,13 +116,26 @@ func (s *Service) Initialize(serviceURL *url.URL, logger types.StdLogger) error
 // Returns:
 //   - An error if the notification fails to send.
 func (s *Service) Send(message string, params *types.Params) error {
+	return s.SendContext(context.Background(), message, params)
+}
+
+// SendContext delivers a notification message to the Bark server, aborting when ctx is done.
+//
+// Parameters:
+//   - ctx: The context bounding the API request.
+//   - message: The notification body text to send.
+//   - params: Additional parameters for notification customization.
+//
+// Returns:
+//   - An error if the notification fails to send.
+func (s *Service) SendContext(ctx context.Context, message string, params *types.Params) error {
 	config := s.Config
 
 	if err := s.pkr.UpdateConfigFromParams(config, params); err != nil {
 		return fmt.Errorf("%w: %w", ErrUpdateParamsFailed, err)
 	}
 
-	if err := s.sendAPI(config, message); err != nil {
+	if err := s.sendAPI(ctx, config, message); err != nil {
 		return fmt.Errorf("failed to send bark notification: %w", err)
 	}
 
@@ -154,16 +173,13 @@ func (s *Service) SetHTTPClient(client types.HTTPClient) {
 // This method handles JSON serialization, HTTP request creation, and response parsing.
 //
 // Parameters:
+//   - ctx: The context bounding the API request.
 //   - config: The Bark service configuration containing API settings.
 //   - message: The notification body text to send.
 //
 // Returns:
 //   - An error if the API request fails.
-func (s *Service) sendAPI(config *Config, message string) error {
-	// Use background context for the request - services can add context support
-	// by wrapping this method or using a custom HTTP client
-	ctx := context.Background()
-
+func (s *Service) sendAPI(ctx context.Context, config *Config, message string) error {
 	response := APIResponse{}
 	request := PushPayload{
 		Body:      message,
@@ -192,6 +208,10 @@ func (s *Service) sendAPI(config *Config, message string) error {
 
 	httpReq.Header.Set("Content-Type", "application/json")
 
+	if err := types.WaitRateLimit(httpReq.Context(), httpReq.URL.Hostname()); err != nil {
+		return fmt.Errorf("waiting for rate limit: %w", err)
+	}
+
 	httpResp, err := s.HTTPClient.Do(httpReq)
 	if err != nil {
 		var jsonErr jsonclient.Error
@@ -204,6 +224,8 @@ func (s *Service) sendAPI(config *Config, message string) error {
 		return fmt.Errorf("%w: %w", ErrFailedAPIRequest, err)
 	}
 
+	types.ReportHTTPStatus(httpReq.Context(), httpResp.StatusCode)
+
 	defer func() { _ = httpResp.Body.Close() }()
 
 	if err := json.NewDecoder(httpResp.Body).Decode(&response); err != nil {
@@ -1,6 +1,7 @@
 package gotify
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -37,6 +38,24 @@ func (s *Service) GetID() string {
 	return Scheme
 }
 
+// MessageLimit returns the message limit of Gotify, which has none.
+func (s *Service) MessageLimit() types.MessageLimit {
+	return types.MessageLimit{}
+}
+
+// LevelMapping maps message levels to Gotify priorities.
+func (s *Service) LevelMapping() types.LevelMapping {
+	return types.LevelMapping{
+		Param: "priority",
+		Values: map[types.MessageLevel]string{
+			types.Debug:   "2",
+			types.Info:    "5",
+			types.Warning: "7",
+			types.Error:   "9",
+		},
+	}
+}
+
 // Initialize configures the service with a URL and logger.
 // This method sets up the entire service infrastructure including configuration parsing,
 // HTTP client creation with appropriate TLS settings, and logging capabilities.
@@ -86,6 +105,11 @@ func (s *Service) Initialize(serviceURL *url.URL, logger types.StdLogger) error
 //
 // Returns: error if sending fails or validation fails, nil on successful delivery.
 func (s *Service) Send(message string, params *types.Params) error {
+	return s.SendContext(context.Background(), message, params)
+}
+
+// SendContext delivers a notification message to Gotify, aborting when ctx is done.
+func (s *Service) SendContext(ctx context.Context, message string, params *types.Params) error {
 	if err := s.validateInputs(message, params); err != nil {
 		return fmt.Errorf("input validation failed: %w", err)
 	}
@@ -102,7 +126,7 @@ func (s *Service) Send(message string, params *types.Params) error {
 		return fmt.Errorf("failed to build request: %w", err)
 	}
 
-	return s.sendRequest(postURL, request, headers)
+	return s.sendRequest(ctx, postURL, request, headers)
 }
 
 // SetHTTPClient allows external injection of a custom HTTP client (for router propagation).
@@ -208,17 +232,20 @@ func (s *Service) processConfig(params *types.Params) (Config, map[string]any, e
 // This function executes the actual HTTP POST request to the Gotify API endpoint,
 // handling both successful responses and error conditions with appropriate error wrapping.
 // Parameters:
+//   - ctx: Context bounding the request
 //   - postURL: The complete API endpoint URL to send the request to
 //   - request: The JSON payload to send in the request body
 //   - headers: Optional headers to set on the request
 //
 // Returns: error if the request fails or server returns an error, nil on success.
 func (s *Service) sendRequest(
+	ctx context.Context,
 	postURL string,
 	request *MessageRequest,
 	headers http.Header,
 ) error {
 	if err := s.sender.SendRequest(
+		ctx,
 		s.httpClient,
 		postURL,
 		request,
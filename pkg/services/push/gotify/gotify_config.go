@@ -19,7 +19,7 @@ import (
 type Config struct {
 	standard.EnumlessConfig // Embeds standard configuration functionality without enum handling
 
-	Token              string         `desc:"Application token"                     required:"" url:"path2"`                                                                          // Gotify application token for authentication (must be 15 chars starting with 'A')
+	Token              string         `desc:"Application token"                     required:"" url:"path2" sensitive:"true"`                                                         // Gotify application token for authentication (must be 15 chars starting with 'A')
 	Host               string         `desc:"Server hostname (and optionally port)" required:"" url:"host,port"`                                                                      // Gotify server hostname and optional port number
 	Path               string         `desc:"Server subpath"                                    url:"path1"     optional:""`                                                          // Optional subpath for Gotify installation (e.g., "/gotify")
 	Priority           int            `                                                                                     default:"0"                     key:"priority"`           // Notification priority level (-2 to 10, where higher numbers are more important; negative values have special meanings in some clients)
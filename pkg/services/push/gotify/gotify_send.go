@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 
 	"github.com/nicholas-fedor/shoutrrr/pkg/types"
 	"github.com/nicholas-fedor/shoutrrr/pkg/util/jsonclient"
@@ -14,7 +15,7 @@ import (
 
 // Sender handles HTTP request execution and response processing.
 type Sender interface {
-	SendRequest(client types.HTTPClient, url string, request *MessageRequest, headers http.Header) error
+	SendRequest(ctx context.Context, client types.HTTPClient, url string, request *MessageRequest, headers http.Header) error
 }
 
 // DefaultSender provides the default implementation of Sender.
@@ -24,6 +25,7 @@ type DefaultSender struct{}
 // This function executes the actual HTTP POST request to the Gotify API endpoint,
 // handling both successful responses and error conditions with appropriate error wrapping.
 // Parameters:
+//   - ctx: Context bounding the request
 //   - client: HTTP client to use for the request
 //   - url: The complete API endpoint URL to send the request to
 //   - request: The JSON payload to send in the request body
@@ -31,6 +33,7 @@ type DefaultSender struct{}
 //
 // Returns: error if the request fails or server returns an error, nil on success.
 func (s *DefaultSender) SendRequest(
+	ctx context.Context,
 	client types.HTTPClient,
 	url string,
 	request *MessageRequest,
@@ -45,7 +48,12 @@ func (s *DefaultSender) SendRequest(
 		// Use JSON client for standard requests - this will handle error extraction
 		jsonClient := jsonclient.NewWithHTTPClient(client)
 
-		err = jsonClient.Post(url, request, response)
+		if contextClient, ok := jsonClient.(jsonclient.ContextClient); ok {
+			err = contextClient.PostContext(ctx, url, request, response)
+		} else {
+			err = jsonClient.Post(url, request, response)
+		}
+
 		if err != nil {
 			// Try to extract structured error
 			errorRes := &responseError{}
@@ -56,11 +64,13 @@ func (s *DefaultSender) SendRequest(
 			return fmt.Errorf("%s: %w", ErrSendFailed.Error(), err)
 		}
 
+		types.ReportMessageID(ctx, strconv.FormatUint(response.ID, 10))
+
 		return nil
 	}
 
 	// Use direct HTTP client when custom headers are needed
-	body, err := s.sendRequestWithHeaders(client, url, request, headers)
+	body, err := s.sendRequestWithHeaders(ctx, client, url, request, headers)
 	if err != nil {
 		return err
 	}
@@ -70,6 +80,8 @@ func (s *DefaultSender) SendRequest(
 		return fmt.Errorf("%s: %w", ErrParseResponse.Error(), err)
 	}
 
+	types.ReportMessageID(ctx, strconv.FormatUint(response.ID, 10))
+
 	return nil
 }
 
@@ -87,18 +99,14 @@ func (s *DefaultSender) handleResponseError(res *http.Response, body []byte) err
 			// Segment based on error category for more deterministic error handling
 			if res.StatusCode >= http.StatusInternalServerError {
 				// 5xx: Server-side errors (e.g., internal server error, bad gateway)
-				return fmt.Errorf("server error (%d): %w", res.StatusCode, errorRes)
+				return jsonclient.NewError(res, string(body), fmt.Errorf("server error (%d): %w", res.StatusCode, errorRes))
 			}
 			// 4xx: Client-side errors (e.g., bad request, unauthorized, not found)
-			return fmt.Errorf("client error (%d): %w", res.StatusCode, errorRes)
+			return jsonclient.NewError(res, string(body), fmt.Errorf("client error (%d): %w", res.StatusCode, errorRes))
 		}
 
 		// Fallback when no structured error response is available
-		if res.StatusCode >= http.StatusInternalServerError {
-			return fmt.Errorf("%w: %v", ErrUnexpectedStatus, res.Status)
-		}
-
-		return fmt.Errorf("%w: %v", ErrUnexpectedStatus, res.Status)
+		return jsonclient.NewError(res, string(body), fmt.Errorf("%w: %v", ErrUnexpectedStatus, res.Status))
 	}
 
 	return nil
@@ -108,6 +116,7 @@ func (s *DefaultSender) handleResponseError(res *http.Response, body []byte) err
 // This method is used when per-request headers are needed, bypassing the jsonclient
 // to avoid modifying shared header state.
 // Parameters:
+//   - ctx: Context bounding the request
 //   - client: HTTP client to use
 //   - url: The complete API endpoint URL to send the request to
 //   - request: The JSON payload to send in the request body
@@ -115,6 +124,7 @@ func (s *DefaultSender) handleResponseError(res *http.Response, body []byte) err
 //
 // Returns: the response body as bytes if successful, or an error.
 func (s *DefaultSender) sendRequestWithHeaders(
+	ctx context.Context,
 	client types.HTTPClient,
 	url string,
 	request *MessageRequest,
@@ -126,7 +136,7 @@ func (s *DefaultSender) sendRequestWithHeaders(
 	}
 
 	req, err := http.NewRequestWithContext(
-		context.Background(),
+		ctx,
 		http.MethodPost,
 		url,
 		bytes.NewReader(body),
@@ -137,11 +147,17 @@ func (s *DefaultSender) sendRequestWithHeaders(
 
 	s.setRequestHeaders(req, headers)
 
+	if err := types.WaitRateLimit(req.Context(), req.URL.Hostname()); err != nil {
+		return nil, fmt.Errorf("waiting for rate limit: %w", err)
+	}
+
 	res, err := client.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", ErrSendRequest.Error(), err)
 	}
 
+	types.ReportHTTPStatus(req.Context(), res.StatusCode)
+
 	defer func() { _ = res.Body.Close() }()
 
 	body, err = io.ReadAll(res.Body)
@@ -5,6 +5,7 @@
 package mocks
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/nicholas-fedor/shoutrrr/pkg/services/push/gotify"
@@ -40,16 +41,16 @@ func (_m *MockSender) EXPECT() *MockSender_Expecter {
 }
 
 // SendRequest provides a mock function for the type MockSender
-func (_mock *MockSender) SendRequest(client types.HTTPClient, url string, request *gotify.MessageRequest, headers http.Header) error {
-	ret := _mock.Called(client, url, request, headers)
+func (_mock *MockSender) SendRequest(ctx context.Context, client types.HTTPClient, url string, request *gotify.MessageRequest, headers http.Header) error {
+	ret := _mock.Called(ctx, client, url, request, headers)
 
 	if len(ret) == 0 {
 		panic("no return value specified for SendRequest")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(types.HTTPClient, string, *gotify.MessageRequest, http.Header) error); ok {
-		r0 = returnFunc(client, url, request, headers)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, types.HTTPClient, string, *gotify.MessageRequest, http.Header) error); ok {
+		r0 = returnFunc(ctx, client, url, request, headers)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -62,37 +63,43 @@ type MockSender_SendRequest_Call struct {
 }
 
 // SendRequest is a helper method to define mock.On call
+//   - ctx context.Context
 //   - client types.HTTPClient
 //   - url string
 //   - request *gotify.MessageRequest
 //   - headers http.Header
-func (_e *MockSender_Expecter) SendRequest(client any, url any, request any, headers any) *MockSender_SendRequest_Call {
-	return &MockSender_SendRequest_Call{Call: _e.mock.On("SendRequest", client, url, request, headers)}
+func (_e *MockSender_Expecter) SendRequest(ctx any, client any, url any, request any, headers any) *MockSender_SendRequest_Call {
+	return &MockSender_SendRequest_Call{Call: _e.mock.On("SendRequest", ctx, client, url, request, headers)}
 }
 
-func (_c *MockSender_SendRequest_Call) Run(run func(client types.HTTPClient, url string, request *gotify.MessageRequest, headers http.Header)) *MockSender_SendRequest_Call {
+func (_c *MockSender_SendRequest_Call) Run(run func(ctx context.Context, client types.HTTPClient, url string, request *gotify.MessageRequest, headers http.Header)) *MockSender_SendRequest_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 types.HTTPClient
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(types.HTTPClient)
+			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 types.HTTPClient
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(types.HTTPClient)
 		}
-		var arg2 *gotify.MessageRequest
+		var arg2 string
 		if args[2] != nil {
-			arg2 = args[2].(*gotify.MessageRequest)
+			arg2 = args[2].(string)
 		}
-		var arg3 http.Header
+		var arg3 *gotify.MessageRequest
 		if args[3] != nil {
-			arg3 = args[3].(http.Header)
+			arg3 = args[3].(*gotify.MessageRequest)
+		}
+		var arg4 http.Header
+		if args[4] != nil {
+			arg4 = args[4].(http.Header)
 		}
 		run(
 			arg0,
 			arg1,
 			arg2,
 			arg3,
+			arg4,
 		)
 	})
 	return _c
@@ -103,7 +110,7 @@ func (_c *MockSender_SendRequest_Call) Return(err error) *MockSender_SendRequest
 	return _c
 }
 
-func (_c *MockSender_SendRequest_Call) RunAndReturn(run func(client types.HTTPClient, url string, request *gotify.MessageRequest, headers http.Header) error) *MockSender_SendRequest_Call {
+func (_c *MockSender_SendRequest_Call) RunAndReturn(run func(ctx context.Context, client types.HTTPClient, url string, request *gotify.MessageRequest, headers http.Header) error) *MockSender_SendRequest_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -40,6 +40,11 @@ func (s *Service) GetID() string {
 	return Scheme
 }
 
+// MessageLimit returns the message limit of IFTTT webhooks, which have none.
+func (s *Service) MessageLimit() types.MessageLimit {
+	return types.MessageLimit{}
+}
+
 // Initialize configures the service with a URL and logger.
 func (s *Service) Initialize(serviceURL *url.URL, logger types.StdLogger) error {
 	s.SetLogger(logger)
@@ -57,6 +62,11 @@ func (s *Service) Initialize(serviceURL *url.URL, logger types.StdLogger) error
 
 // Send delivers a notification message to an IFTTT webhook.
 func (s *Service) Send(message string, params *types.Params) error {
+	return s.SendContext(context.Background(), message, params)
+}
+
+// SendContext delivers a notification message to an IFTTT webhook, aborting when ctx is done.
+func (s *Service) SendContext(ctx context.Context, message string, params *types.Params) error {
 	config := s.Config
 	if err := s.pkr.UpdateConfigFromParams(config, params); err != nil {
 		return fmt.Errorf("updating config from params: %w", err)
@@ -69,7 +79,7 @@ func (s *Service) Send(message string, params *types.Params) error {
 
 	for _, event := range config.Events {
 		apiURL := s.createAPIURLForEvent(event)
-		if err := s.doSend(payload, apiURL); err != nil {
+		if err := s.doSend(ctx, payload, apiURL); err != nil {
 			return fmt.Errorf("%w: event %q: %w", ErrSendFailed, event, err)
 		}
 	}
@@ -88,8 +98,8 @@ func (s *Service) createAPIURLForEvent(event string) string {
 }
 
 // doSend executes an HTTP POST request to send the payload to the IFTTT webhook.
-func (s *Service) doSend(payload []byte, postURL string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+func (s *Service) doSend(ctx context.Context, payload []byte, postURL string) error {
+	ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
 	defer cancel()
 
 	req, err := http.NewRequestWithContext(
@@ -109,11 +119,17 @@ func (s *Service) doSend(payload []byte, postURL string) error {
 		client = &http.Client{Timeout: defaultTimeout}
 	}
 
+	if err := types.WaitRateLimit(req.Context(), req.URL.Hostname()); err != nil {
+		return fmt.Errorf("waiting for rate limit: %w", err)
+	}
+
 	res, err := client.Do(req)
 	if err != nil {
 		return fmt.Errorf("sending HTTP request to IFTTT webhook: %w", err)
 	}
 
+	types.ReportHTTPStatus(req.Context(), res.StatusCode)
+
 	defer func() { _ = res.Body.Close() }()
 
 	if res.StatusCode < 200 || res.StatusCode >= 300 {
@@ -14,7 +14,7 @@ import (
 type Config struct {
 	standard.EnumlessConfig
 
-	WebHookID         string   `required:"true" url:"host"`
+	WebHookID         string   `required:"true" url:"host" sensitive:"true"`
 	Events            []string `required:"true"            key:"events"`
 	Value1            string   `                           key:"value1"       optional:""`
 	Value2            string   `                           key:"value2"       optional:""`
@@ -34,6 +34,12 @@ func (s *Service) GetID() string {
 	return Scheme
 }
 
+// MessageLimit returns the message limit of Join. Pushes are limited to 4 KB, and
+// a rune takes up to utf8.UTFMax bytes.
+func (s *Service) MessageLimit() types.MessageLimit {
+	return types.MessageLimit{ChunkSize: 1024, TotalChunkSize: 1024, ChunkCount: 1}
+}
+
 // Initialize configures the service with a URL and logger.
 func (s *Service) Initialize(serviceURL *url.URL, logger types.StdLogger) error {
 	s.SetLogger(logger)
@@ -49,6 +55,11 @@ func (s *Service) Initialize(serviceURL *url.URL, logger types.StdLogger) error
 
 // Send delivers a notification message to Join devices.
 func (s *Service) Send(message string, params *types.Params) error {
+	return s.SendContext(context.Background(), message, params)
+}
+
+// SendContext delivers a notification message to Join devices, aborting when ctx is done.
+func (s *Service) SendContext(ctx context.Context, message string, params *types.Params) error {
 	config := s.Config
 
 	if params == nil {
@@ -67,7 +78,7 @@ func (s *Service) Send(message string, params *types.Params) error {
 
 	devices := strings.Join(config.Devices, ",")
 
-	return s.sendToDevices(devices, message, title, icon)
+	return s.sendToDevices(ctx, devices, message, title, icon)
 }
 
 // SetHTTPClient sets a custom HTTP client for the service.
@@ -75,7 +86,7 @@ func (s *Service) SetHTTPClient(client types.HTTPClient) {
 	s.httpClient = client
 }
 
-func (s *Service) sendToDevices(devices, message, title, icon string) error {
+func (s *Service) sendToDevices(ctx context.Context, devices, message, title, icon string) error {
 	config := s.Config
 
 	apiURL, err := url.Parse(hookURL)
@@ -98,7 +109,7 @@ func (s *Service) sendToDevices(devices, message, title, icon string) error {
 
 	apiURL.RawQuery = data.Encode()
 
-	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
 	defer cancel()
 
 	req, err := http.NewRequestWithContext(
@@ -118,11 +129,17 @@ func (s *Service) sendToDevices(devices, message, title, icon string) error {
 		client = &http.Client{Timeout: defaultTimeout}
 	}
 
+	if err := types.WaitRateLimit(req.Context(), req.URL.Hostname()); err != nil {
+		return fmt.Errorf("waiting for rate limit: %w", err)
+	}
+
 	res, err := client.Do(req)
 	if err != nil {
 		return fmt.Errorf("sending HTTP request to Join: %w", err)
 	}
 
+	types.ReportHTTPStatus(req.Context(), res.StatusCode)
+
 	defer func() { _ = res.Body.Close() }()
 
 	if res.StatusCode != http.StatusOK {
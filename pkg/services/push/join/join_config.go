@@ -10,7 +10,7 @@ import (
 
 // Config holds settings for the Join notification service.
 type Config struct {
-	APIKey  string   `url:"pass"`
+	APIKey  string   `url:"pass" sensitive:"true"`
 	Devices []string `           desc:"Comma separated list of device IDs" key:"devices"`
 	Title   string   `           desc:"If set creates a notification"      key:"title"   optional:""`
 	Icon    string   `           desc:"Icon URL"                           key:"icon"    optional:""`
@@ -85,14 +85,20 @@ const disconnectTimeout = 5
 // Returns an error if the disconnect fails, wrapped with context about the failure.
 // Returns nil on successful cleanup or if already closed without error.
 func (s *Service) Close() error {
+	return s.CloseContext(context.Background())
+}
+
+// CloseContext shuts down the MQTT service like Close, aborting the disconnect
+// when ctx is done. The 5-second disconnect timeout still applies.
+//
+// CloseContext shares its once-only cleanup with Close: whichever is called first
+// performs the shutdown and later calls of either return its result.
+func (s *Service) CloseContext(ctx context.Context) error {
 	s.closeOnce.Do(func() {
 		// Disconnect the connection manager if it was initialized.
 		if s.connectionManager != nil {
-			// Create a context with timeout for the disconnect operation.
-			ctx, cancel := context.WithTimeout(
-				context.Background(),
-				disconnectTimeout*time.Second,
-			)
+			// Bound the disconnect operation by the disconnect timeout.
+			ctx, cancel := context.WithTimeout(ctx, disconnectTimeout*time.Second)
 			defer cancel()
 
 			// Attempt to disconnect from the broker.
@@ -122,6 +128,11 @@ func (s *Service) GetID() string {
 	return Scheme
 }
 
+// MessageLimit returns the message limit of MQTT, which has none.
+func (s *Service) MessageLimit() types.MessageLimit {
+	return types.MessageLimit{}
+}
+
 // Initialize configures the MQTT service with settings from a URL and sets up logging.
 // The URL format is: mqtt://[username:password@]host[:port]/topic[?options]
 //
@@ -182,6 +193,12 @@ func (s *Service) Initialize(serviceURL *url.URL, logger types.StdLogger) error
 //
 // Returns an error if connection fails or publishing encounters an error.
 func (s *Service) Send(message string, params *types.Params) error {
+	return s.SendContext(context.Background(), message, params)
+}
+
+// SendContext publishes a notification message to the MQTT broker, aborting when ctx is done.
+// The publish timeout is applied on top of ctx.
+func (s *Service) SendContext(ctx context.Context, message string, params *types.Params) error {
 	// Apply any runtime parameter overrides to the configuration
 	if err := s.pkr.UpdateConfigFromParams(s.Config, params); err != nil {
 		return fmt.Errorf("updating config from params: %w", err)
@@ -204,7 +221,7 @@ func (s *Service) Send(message string, params *types.Params) error {
 
 	// Create a context with timeout for the publish operation
 	ctx, cancel := context.WithTimeout(
-		context.Background(),
+		ctx,
 		publishTimeout*time.Second,
 	)
 	defer cancel()
@@ -249,6 +266,10 @@ func (s *Service) Send(message string, params *types.Params) error {
 		s.Logf("Warning: Publish completed with reason code %d", resp.ReasonCode)
 	}
 
+	if resp != nil {
+		types.ReportBrokerAck(ctx, fmt.Sprintf("reason code %d", resp.ReasonCode))
+	}
+
 	// Log successful publication for debugging and monitoring
 	s.Logf("Successfully published message to topic %q", s.Config.Topic)
 
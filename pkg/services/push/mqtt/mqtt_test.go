@@ -386,6 +386,24 @@ var _ = ginkgo.Describe("Service", func() {
 			err := service.Close()
 			gomega.Expect(err).NotTo(gomega.HaveOccurred())
 		})
+
+		ginkgo.It("should share the once-only cleanup with CloseContext", func() {
+			service.connectionManager = nil
+			service.closeOnce = sync.Once{} // Reset
+
+			_ = service.getCancel()
+
+			err := service.CloseContext(context.Background())
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(service.ctx.Err()).To(gomega.MatchError(context.Canceled))
+
+			gomega.Expect(service.Close()).To(gomega.Succeed())
+		})
+
+		ginkgo.It("should satisfy types.ContextCloser", func() {
+			var closer types.ContextCloser = service
+			gomega.Expect(closer).NotTo(gomega.BeNil())
+		})
 	})
 
 	ginkgo.Describe("Service Constants", func() {
@@ -1,6 +1,7 @@
 package ntfy
 
 import (
+	"context"
 	"crypto/tls"
 	"encoding/base64"
 	"fmt"
@@ -34,6 +35,25 @@ func (s *Service) GetID() string {
 	return Scheme
 }
 
+// MessageLimit returns the message limit of ntfy, which turns messages longer
+// than 4,096 bytes into attachments. A rune takes up to utf8.UTFMax bytes.
+func (s *Service) MessageLimit() types.MessageLimit {
+	return types.MessageLimit{ChunkSize: 1024, TotalChunkSize: 1024, ChunkCount: 1}
+}
+
+// LevelMapping maps message levels to ntfy priorities.
+func (s *Service) LevelMapping() types.LevelMapping {
+	return types.LevelMapping{
+		Param: "priority",
+		Values: map[types.MessageLevel]string{
+			types.Debug:   "low",
+			types.Info:    "default",
+			types.Warning: "high",
+			types.Error:   "max",
+		},
+	}
+}
+
 // Initialize configures the service with a URL and logger.
 func (s *Service) Initialize(serviceURL *url.URL, logger types.StdLogger) error {
 	s.SetLogger(logger)
@@ -86,6 +106,11 @@ func (s *Service) Initialize(serviceURL *url.URL, logger types.StdLogger) error
 
 // Send delivers a notification message to ntfy.
 func (s *Service) Send(message string, params *types.Params) error {
+	return s.SendContext(context.Background(), message, params)
+}
+
+// SendContext delivers a notification message to ntfy, aborting when ctx is done.
+func (s *Service) SendContext(ctx context.Context, message string, params *types.Params) error {
 	config := s.Config
 
 	// Update config with runtime parameters
@@ -94,7 +119,7 @@ func (s *Service) Send(message string, params *types.Params) error {
 	}
 
 	// Execute the API request to send the notification
-	if err := s.sendAPI(config, message); err != nil {
+	if err := s.sendAPI(ctx, config, message); err != nil {
 		return fmt.Errorf("failed to send ntfy notification: %w", err)
 	}
 
@@ -110,7 +135,7 @@ func (s *Service) SetHTTPClient(client types.HTTPClient) {
 }
 
 // sendAPI sends a notification to the ntfy API.
-func (s *Service) sendAPI(config *Config, message string) error {
+func (s *Service) sendAPI(ctx context.Context, config *Config, message string) error {
 	response := apiResponseError{}
 	request := message
 
@@ -150,8 +175,15 @@ func (s *Service) sendAPI(config *Config, message string) error {
 		)
 	}
 
-	// Send the HTTP request
-	if err := s.client.Post(config.GetAPIURL(), request, &response); err != nil {
+	// Send the HTTP request, bound to ctx if the client supports it
+	var err error
+	if contextClient, ok := s.client.(jsonclient.ContextClient); ok {
+		err = contextClient.PostContext(ctx, config.GetAPIURL(), request, &response)
+	} else {
+		err = s.client.Post(config.GetAPIURL(), request, &response)
+	}
+
+	if err != nil {
 		s.Logf("NTFY API request failed with error: %v", err)
 		// Attempt to parse structured error response from API
 		if s.client.ErrorResponse(err, &response) {
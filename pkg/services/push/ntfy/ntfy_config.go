@@ -15,7 +15,7 @@ type Config struct {
 	Title                  string   `default:""        desc:"Message title"                                                                                    key:"title"`
 	Host                   string   `default:"ntfy.sh" desc:"Server hostname and port"                                                                                                      url:"host"`
 	Topic                  string   `                  desc:"Target topic name"                                                                                                             url:"path"     required:""`
-	Password               string   `                  desc:"Auth password"                                                                                                                 url:"password"             optional:""`
+	Password               string   `                  desc:"Auth password"                                                                                                                 url:"password"             optional:"" sensitive:"true"`
 	Username               string   `                  desc:"Auth username"                                                                                                                 url:"user"                 optional:""`
 	Scheme                 string   `default:"https"   desc:"Server protocol, http or https"                                                                   key:"scheme"`
 	Tags                   []string `                  desc:"List of tags that may or not map to emojis"                                                       key:"tags"                                              optional:""`
@@ -1,6 +1,7 @@
 package ntfy
 
 import (
+	"context"
 	"io"
 	"net/http"
 
@@ -193,7 +194,7 @@ var _ = ginkgo.Describe("Service", func() {
 			mockJSON.On("Post", mock.Anything, mock.Anything, mock.Anything).
 				Return(nil)
 
-			err := service.sendAPI(service.Config, "hello")
+			err := service.sendAPI(context.Background(), service.Config, "hello")
 			gomega.Expect(err).NotTo(gomega.HaveOccurred())
 			gomega.Expect(headers.Get("Content-Type")).To(gomega.Equal("text/plain; charset=utf-8"))
 		})
@@ -204,7 +205,7 @@ var _ = ginkgo.Describe("Service", func() {
 			mockJSON.On("Post", mock.Anything, mock.Anything, mock.Anything).
 				Return(nil)
 
-			err := service.sendAPI(service.Config, "**hello**")
+			err := service.sendAPI(context.Background(), service.Config, "**hello**")
 			gomega.Expect(err).NotTo(gomega.HaveOccurred())
 			gomega.Expect(headers.Get("Content-Type")).To(gomega.Equal("text/markdown"))
 		})
@@ -215,7 +216,7 @@ var _ = ginkgo.Describe("Service", func() {
 			mockJSON.On("Post", mock.Anything, mock.Anything, mock.Anything).
 				Return(nil)
 
-			err := service.sendAPI(service.Config, "hello")
+			err := service.sendAPI(context.Background(), service.Config, "hello")
 			gomega.Expect(err).NotTo(gomega.HaveOccurred())
 			gomega.Expect(headers.Get("Content-Type")).To(gomega.Equal("text/plain; charset=utf-8"))
 		})
@@ -224,7 +225,7 @@ var _ = ginkgo.Describe("Service", func() {
 			mockJSON.On("Post", mock.Anything, mock.Anything, mock.Anything).
 				Return(nil)
 
-			err := service.sendAPI(service.Config, "hello")
+			err := service.sendAPI(context.Background(), service.Config, "hello")
 			gomega.Expect(err).NotTo(gomega.HaveOccurred())
 			gomega.Expect(headers.Get("User-Agent")).To(gomega.ContainSubstring("shoutrrr/"))
 		})
@@ -235,7 +236,7 @@ var _ = ginkgo.Describe("Service", func() {
 			mockJSON.On("Post", mock.Anything, mock.Anything, mock.Anything).
 				Return(nil)
 
-			err := service.sendAPI(service.Config, "hello")
+			err := service.sendAPI(context.Background(), service.Config, "hello")
 			gomega.Expect(err).NotTo(gomega.HaveOccurred())
 			gomega.Expect(headers.Get("Title")).To(gomega.Equal("Alert"))
 		})
@@ -246,7 +247,7 @@ var _ = ginkgo.Describe("Service", func() {
 			mockJSON.On("Post", mock.Anything, mock.Anything, mock.Anything).
 				Return(nil)
 
-			err := service.sendAPI(service.Config, "hello")
+			err := service.sendAPI(context.Background(), service.Config, "hello")
 			gomega.Expect(err).NotTo(gomega.HaveOccurred())
 			gomega.Expect(headers.Get("Priority")).To(gomega.Equal("High"))
 		})
@@ -257,7 +258,7 @@ var _ = ginkgo.Describe("Service", func() {
 			mockJSON.On("Post", mock.Anything, mock.Anything, mock.Anything).
 				Return(nil)
 
-			err := service.sendAPI(service.Config, "hello")
+			err := service.sendAPI(context.Background(), service.Config, "hello")
 			gomega.Expect(err).NotTo(gomega.HaveOccurred())
 			gomega.Expect(headers.Get("Tags")).To(gomega.Equal("warning,skull"))
 		})
@@ -269,7 +270,7 @@ var _ = ginkgo.Describe("Service", func() {
 			mockJSON.On("Post", mock.Anything, mock.Anything, mock.Anything).
 				Return(nil)
 
-			err := service.sendAPI(service.Config, "hello")
+			err := service.sendAPI(context.Background(), service.Config, "hello")
 			gomega.Expect(err).NotTo(gomega.HaveOccurred())
 			gomega.Expect(headers.Get("Authorization")).To(gomega.HavePrefix("Basic "))
 		})
@@ -280,7 +281,7 @@ var _ = ginkgo.Describe("Service", func() {
 			mockJSON.On("Post", mock.Anything, mock.Anything, mock.Anything).
 				Return(nil)
 
-			err := service.sendAPI(service.Config, "hello")
+			err := service.sendAPI(context.Background(), service.Config, "hello")
 			gomega.Expect(err).NotTo(gomega.HaveOccurred())
 			gomega.Expect(headers.Get("Cache")).To(gomega.Equal("no"))
 		})
@@ -291,7 +292,7 @@ var _ = ginkgo.Describe("Service", func() {
 			mockJSON.On("Post", mock.Anything, mock.Anything, mock.Anything).
 				Return(nil)
 
-			err := service.sendAPI(service.Config, "hello")
+			err := service.sendAPI(context.Background(), service.Config, "hello")
 			gomega.Expect(err).NotTo(gomega.HaveOccurred())
 			gomega.Expect(headers.Get("Firebase")).To(gomega.Equal("no"))
 		})
@@ -302,7 +303,7 @@ var _ = ginkgo.Describe("Service", func() {
 			mockJSON.On("Post", mock.Anything, mock.Anything, mock.Anything).
 				Return(nil)
 
-			err := service.sendAPI(service.Config, "hello")
+			err := service.sendAPI(context.Background(), service.Config, "hello")
 			gomega.Expect(err).NotTo(gomega.HaveOccurred())
 			gomega.Expect(headers.Get("Actions")).To(gomega.Equal("view;open"))
 		})
@@ -313,7 +314,7 @@ var _ = ginkgo.Describe("Service", func() {
 			mockJSON.On("Post", mock.Anything, mock.Anything, mock.Anything).
 				Return(nil)
 
-			err := service.sendAPI(service.Config, "hello")
+			err := service.sendAPI(context.Background(), service.Config, "hello")
 			gomega.Expect(err).NotTo(gomega.HaveOccurred())
 			gomega.Expect(headers.Get("Delay")).To(gomega.Equal("2h"))
 		})
@@ -324,7 +325,7 @@ var _ = ginkgo.Describe("Service", func() {
 			mockJSON.On("Post", mock.Anything, mock.Anything, mock.Anything).
 				Return(nil)
 
-			err := service.sendAPI(service.Config, "hello")
+			err := service.sendAPI(context.Background(), service.Config, "hello")
 			gomega.Expect(err).NotTo(gomega.HaveOccurred())
 			gomega.Expect(headers.Get("Click")).To(gomega.Equal("https://example.com"))
 		})
@@ -335,7 +336,7 @@ var _ = ginkgo.Describe("Service", func() {
 			mockJSON.On("Post", mock.Anything, mock.Anything, mock.Anything).
 				Return(nil)
 
-			err := service.sendAPI(service.Config, "hello")
+			err := service.sendAPI(context.Background(), service.Config, "hello")
 			gomega.Expect(err).NotTo(gomega.HaveOccurred())
 			gomega.Expect(headers.Get("Attach")).To(gomega.Equal("https://example.com/image.png"))
 		})
@@ -346,7 +347,7 @@ var _ = ginkgo.Describe("Service", func() {
 			mockJSON.On("Post", mock.Anything, mock.Anything, mock.Anything).
 				Return(nil)
 
-			err := service.sendAPI(service.Config, "hello")
+			err := service.sendAPI(context.Background(), service.Config, "hello")
 			gomega.Expect(err).NotTo(gomega.HaveOccurred())
 			gomega.Expect(headers.Get("X-Icon")).To(gomega.Equal("https://example.com/icon.png"))
 		})
@@ -357,7 +358,7 @@ var _ = ginkgo.Describe("Service", func() {
 			mockJSON.On("Post", mock.Anything, mock.Anything, mock.Anything).
 				Return(nil)
 
-			err := service.sendAPI(service.Config, "hello")
+			err := service.sendAPI(context.Background(), service.Config, "hello")
 			gomega.Expect(err).NotTo(gomega.HaveOccurred())
 			gomega.Expect(headers.Get("Filename")).To(gomega.Equal("document.pdf"))
 		})
@@ -368,7 +369,7 @@ var _ = ginkgo.Describe("Service", func() {
 			mockJSON.On("Post", mock.Anything, mock.Anything, mock.Anything).
 				Return(nil)
 
-			err := service.sendAPI(service.Config, "hello")
+			err := service.sendAPI(context.Background(), service.Config, "hello")
 			gomega.Expect(err).NotTo(gomega.HaveOccurred())
 			gomega.Expect(headers.Get("Email")).To(gomega.Equal("user@example.com"))
 		})
@@ -377,7 +378,7 @@ var _ = ginkgo.Describe("Service", func() {
 			mockJSON.On("Post", service.Config.GetAPIURL(), "hello", mock.Anything).
 				Return(nil)
 
-			err := service.sendAPI(service.Config, "hello")
+			err := service.sendAPI(context.Background(), service.Config, "hello")
 			gomega.Expect(err).NotTo(gomega.HaveOccurred())
 		})
 
@@ -388,7 +389,7 @@ var _ = ginkgo.Describe("Service", func() {
 			mockJSON.On("Post", mock.Anything, mock.Anything, mock.Anything).
 				Return(nil)
 
-			err := service.sendAPI(service.Config, "hello")
+			err := service.sendAPI(context.Background(), service.Config, "hello")
 			gomega.Expect(err).NotTo(gomega.HaveOccurred())
 			gomega.Expect(headers.Get("Authorization")).To(gomega.HavePrefix("Basic "))
 		})
@@ -400,7 +401,7 @@ var _ = ginkgo.Describe("Service", func() {
 			mockJSON.On("Post", mock.Anything, mock.Anything, mock.Anything).
 				Return(nil)
 
-			err := service.sendAPI(service.Config, "hello")
+			err := service.sendAPI(context.Background(), service.Config, "hello")
 			gomega.Expect(err).NotTo(gomega.HaveOccurred())
 			gomega.Expect(headers.Get("Authorization")).To(gomega.HavePrefix("Basic "))
 		})
@@ -409,7 +410,7 @@ var _ = ginkgo.Describe("Service", func() {
 			mockJSON.On("Post", mock.Anything, mock.Anything, mock.Anything).
 				Return(nil)
 
-			err := service.sendAPI(service.Config, "")
+			err := service.sendAPI(context.Background(), service.Config, "")
 			gomega.Expect(err).NotTo(gomega.HaveOccurred())
 		})
 
@@ -419,7 +420,7 @@ var _ = ginkgo.Describe("Service", func() {
 			mockJSON.On("Post", mock.Anything, mock.Anything, mock.Anything).
 				Return(nil)
 
-			err := service.sendAPI(service.Config, "hello")
+			err := service.sendAPI(context.Background(), service.Config, "hello")
 			gomega.Expect(err).NotTo(gomega.HaveOccurred())
 			gomega.Expect(headers.Get("Tags")).To(gomega.Equal("warning"))
 		})
@@ -430,7 +431,7 @@ var _ = ginkgo.Describe("Service", func() {
 			mockJSON.On("ErrorResponse", mock.Anything, mock.Anything).
 				Return(false)
 
-			err := service.sendAPI(service.Config, "hello")
+			err := service.sendAPI(context.Background(), service.Config, "hello")
 			gomega.Expect(err).To(gomega.HaveOccurred())
 		})
 
@@ -453,7 +454,7 @@ var _ = ginkgo.Describe("Service", func() {
 					return true
 				})
 
-			err := service.sendAPI(service.Config, "hello")
+			err := service.sendAPI(context.Background(), service.Config, "hello")
 			gomega.Expect(err).To(gomega.HaveOccurred())
 			gomega.Expect(err.Error()).To(gomega.ContainSubstring("invalid request"))
 		})
@@ -464,7 +465,7 @@ var _ = ginkgo.Describe("Service", func() {
 			mockJSON.On("ErrorResponse", mock.Anything, mock.Anything).
 				Return(false)
 
-			err := service.sendAPI(service.Config, "hello")
+			err := service.sendAPI(context.Background(), service.Config, "hello")
 			gomega.Expect(err).To(gomega.HaveOccurred())
 			gomega.Expect(err.Error()).To(gomega.ContainSubstring("posting to ntfy API"))
 		})
@@ -1,6 +1,7 @@
 package pushbullet
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
@@ -40,6 +41,11 @@ func (s *Service) GetID() string {
 	return Scheme
 }
 
+// MessageLimit returns the message limit of Pushbullet, which has none.
+func (s *Service) MessageLimit() types.MessageLimit {
+	return types.MessageLimit{}
+}
+
 // Initialize loads ServiceConfig from serviceURL and sets logger for this Service.
 func (s *Service) Initialize(serviceURL *url.URL, logger types.StdLogger) error {
 	s.SetLogger(logger)
@@ -61,13 +67,18 @@ func (s *Service) Initialize(serviceURL *url.URL, logger types.StdLogger) error
 
 // Send a push notification via Pushbullet.
 func (s *Service) Send(message string, params *types.Params) error {
+	return s.SendContext(context.Background(), message, params)
+}
+
+// SendContext delivers a notification message to Pushbullet, aborting when ctx is done.
+func (s *Service) SendContext(ctx context.Context, message string, params *types.Params) error {
 	config := *s.Config
 	if err := s.pkr.UpdateConfigFromParams(&config, params); err != nil {
 		return fmt.Errorf("updating config from params: %w", err)
 	}
 
 	for _, target := range config.Targets {
-		if err := s.doSend(&config, target, message); err != nil {
+		if err := s.doSend(ctx, &config, target, message); err != nil {
 			return err
 		}
 	}
@@ -96,12 +107,20 @@ func (s *Service) SetHTTPClient(client types.HTTPClient) {
 }
 
 // doSend sends a push notification to a specific target and validates the response.
-func (s *Service) doSend(config *Config, target, message string) error {
+func (s *Service) doSend(ctx context.Context, config *Config, target, message string) error {
 	push := NewNotePush(message, config.Title)
 	push.SetTarget(target)
 
 	response := PushResponse{}
-	if err := s.client.Post(pushesEndpoint, push, &response); err != nil {
+
+	var err error
+	if contextClient, ok := s.client.(jsonclient.ContextClient); ok {
+		err = contextClient.PostContext(ctx, pushesEndpoint, push, &response)
+	} else {
+		err = s.client.Post(pushesEndpoint, push, &response)
+	}
+
+	if err != nil {
 		errorResponse := &ResponseError{}
 		if s.client.ErrorResponse(err, errorResponse) {
 			return fmt.Errorf("API error: %w", errorResponse)
@@ -137,6 +156,8 @@ func (s *Service) doSend(config *Config, target, message string) error {
 		return ErrPushNotActive
 	}
 
+	types.ReportMessageID(ctx, response.Iden)
+
 	return nil
 }
 
@@ -16,7 +16,7 @@ type Config struct {
 	standard.EnumlessConfig
 
 	Targets []string `url:"path"`
-	Token   string   `url:"host"`
+	Token   string   `url:"host" sensitive:"true"`
 	Title   string   `           default:"Shoutrrr notification" key:"title"`
 }
 
@@ -12,6 +12,7 @@ import (
 	"github.com/nicholas-fedor/shoutrrr/pkg/format"
 	"github.com/nicholas-fedor/shoutrrr/pkg/services/standard"
 	"github.com/nicholas-fedor/shoutrrr/pkg/types"
+	"github.com/nicholas-fedor/shoutrrr/pkg/util/jsonclient"
 )
 
 // Service provides the Pushover notification service.
@@ -36,6 +37,25 @@ func (s *Service) GetID() string {
 	return Scheme
 }
 
+// MessageLimit returns the message limit of Pushover, 1,024 characters.
+func (s *Service) MessageLimit() types.MessageLimit {
+	return types.MessageLimit{ChunkSize: 1024, TotalChunkSize: 1024, ChunkCount: 1}
+}
+
+// LevelMapping maps message levels to Pushover priorities. Emergency priority
+// is never mapped, as it requires acknowledgement by the recipient.
+func (s *Service) LevelMapping() types.LevelMapping {
+	return types.LevelMapping{
+		Param: "priority",
+		Values: map[types.MessageLevel]string{
+			types.Debug:   "-1",
+			types.Info:    "0",
+			types.Warning: "1",
+			types.Error:   "1",
+		},
+	}
+}
+
 // Initialize configures the service with a URL and logger.
 func (s *Service) Initialize(serviceURL *url.URL, logger types.StdLogger) error {
 	s.SetLogger(logger)
@@ -57,6 +77,11 @@ func (s *Service) Initialize(serviceURL *url.URL, logger types.StdLogger) error
 
 // Send delivers a notification message to Pushover.
 func (s *Service) Send(message string, params *types.Params) error {
+	return s.SendContext(context.Background(), message, params)
+}
+
+// SendContext delivers a notification message to Pushover, aborting when ctx is done.
+func (s *Service) SendContext(ctx context.Context, message string, params *types.Params) error {
 	config := s.Config
 	if err := s.pkr.UpdateConfigFromParams(config, params); err != nil {
 		return fmt.Errorf("updating config from params: %w", err)
@@ -67,7 +92,7 @@ func (s *Service) Send(message string, params *types.Params) error {
 	}
 
 	device := strings.Join(config.Devices, ",")
-	if err := s.sendToDevice(device, message, config); err != nil {
+	if err := s.sendToDevice(ctx, device, message, config); err != nil {
 		return fmt.Errorf("failed to send notifications to pushover devices: %w", err)
 	}
 
@@ -89,7 +114,7 @@ func (s *Service) httpClientOrDefault() types.HTTPClient {
 }
 
 // sendToDevice sends a notification to a specific Pushover device.
-func (s *Service) sendToDevice(device, message string, config *Config) error {
+func (s *Service) sendToDevice(ctx context.Context, device, message string, config *Config) error {
 	key, err := parseEncryptionKey(config.EncryptionKey)
 	if err != nil {
 		return err
@@ -128,7 +153,7 @@ func (s *Service) sendToDevice(device, message string, config *Config) error {
 		data.Set("priority", strconv.FormatInt(int64(config.Priority), 10))
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), defaultHTTPTimeout)
+	ctx, cancel := context.WithTimeout(ctx, defaultHTTPTimeout)
 	defer cancel()
 
 	req, err := http.NewRequestWithContext(
@@ -145,15 +170,21 @@ func (s *Service) sendToDevice(device, message string, config *Config) error {
 
 	client := s.httpClientOrDefault()
 
+	if err := types.WaitRateLimit(req.Context(), req.URL.Hostname()); err != nil {
+		return fmt.Errorf("waiting for rate limit: %w", err)
+	}
+
 	res, err := client.Do(req)
 	if err != nil {
 		return fmt.Errorf("sending request to Pushover API: %w", err)
 	}
 
+	types.ReportHTTPStatus(req.Context(), res.StatusCode)
+
 	defer func() { _ = res.Body.Close() }()
 
 	if res.StatusCode != http.StatusOK {
-		return fmt.Errorf("%w: %q, response status %q", ErrSendFailed, device, res.Status)
+		return jsonclient.NewError(res, "", fmt.Errorf("%w: %q, response status %q", ErrSendFailed, device, res.Status))
 	}
 
 	return nil
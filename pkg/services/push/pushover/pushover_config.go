@@ -10,12 +10,12 @@ import (
 
 // Config for the Pushover notification service.
 type Config struct {
-	Token         string   `desc:"API Token/Key"                                                  url:"pass"`
+	Token         string   `desc:"API Token/Key"                                                  url:"pass" sensitive:"true"`
 	User          string   `desc:"User Key"                                                       url:"host"`
 	Devices       []string `                                                                                 key:"devices"           optional:""`
 	Priority      int8     `                                                                                 key:"priority"                      default:"0"`
 	Title         string   `                                                                                 key:"title"             optional:""`
-	EncryptionKey string   `desc:"256-bit AES key as 64 hex characters for end-to-end encryption"            key:"encryptionkey,key" optional:""`
+	EncryptionKey string   `desc:"256-bit AES key as 64 hex characters for end-to-end encryption"            key:"encryptionkey,key" optional:"" sensitive:"true"`
 }
 
 const (
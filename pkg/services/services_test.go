@@ -151,6 +151,49 @@ var _ = ginkgo.Describe("services", func() {
 		}
 	})
 
+	ginkgo.Describe("level mapping", func() {
+		levelMappedURLs := map[string]string{
+			"gotify":    serviceURLs["gotify"],
+			"ntfy":      "ntfy://ntfy.sh/topic",
+			"opsgenie":  serviceURLs["opsgenie"],
+			"pagerduty": "pagerduty://events.pagerduty.com/a1b2c3d4e5f678901234567890abcdef",
+			"pushover":  serviceURLs["pushover"],
+		}
+
+		for key, serviceURL := range levelMappedURLs {
+			ginkgo.It("should accept every mapped priority of "+key, func() {
+				serviceRouter, err := router.NewWithOptions(logger, types.SenderOptions{})
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+				service, err := serviceRouter.Locate(serviceURL)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+				mapper, ok := service.(types.LevelMapper)
+				gomega.Expect(ok).To(gomega.BeTrue())
+
+				mapping := mapper.LevelMapping()
+				gomega.Expect(mapping.Values).NotTo(gomega.BeEmpty())
+
+				customClient := &http.Client{}
+				httpmock.ActivateNonDefault(customClient)
+				ginkgo.DeferCleanup(httpmock.DeactivateAndReset)
+				service.(types.HTTPClientSetter).SetHTTPClient(customClient)
+
+				response := serviceResponses[key]
+				if response == "" {
+					response = "{}"
+				}
+
+				httpmock.RegisterNoResponder(httpmock.NewStringResponder(http.StatusOK, response))
+
+				for level, value := range mapping.Values {
+					err := service.Send("test", &types.Params{mapping.Param: value})
+					gomega.Expect(err).NotTo(gomega.HaveOccurred(), "%s: %s=%s", level, mapping.Param, value)
+				}
+			})
+		}
+	})
+
 	ginkgo.Describe("custom HTTP client injection", func() {
 		ginkgo.It("should use the injected client when sending a notification", func() {
 			customClient := &http.Client{}
@@ -9,6 +9,8 @@ import (
 	"net/url"
 	"strings"
 	"time"
+
+	"github.com/nicholas-fedor/shoutrrr/pkg/types"
 )
 
 // HTTPClient defines the interface for making HTTP requests.
@@ -38,7 +40,7 @@ func DefaultHTTPClient() HTTPClient {
 }
 
 // sendToRecipient sends an SMS message to a single recipient via the Twilio API.
-func (s *Service) sendToRecipient(config *Config, toNumber, message string) error {
+func (s *Service) sendToRecipient(ctx context.Context, config *Config, toNumber, message string) error {
 	body := message
 	if config.Title != "" {
 		body = config.Title + "\n" + message
@@ -58,7 +60,7 @@ func (s *Service) sendToRecipient(config *Config, toNumber, message string) erro
 		data.Set("From", config.FromNumber)
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), defaultHTTPTimeout)
+	ctx, cancel := context.WithTimeout(ctx, defaultHTTPTimeout)
 	defer cancel()
 
 	req, err := http.NewRequestWithContext(
@@ -74,11 +76,17 @@ func (s *Service) sendToRecipient(config *Config, toNumber, message string) erro
 	req.Header.Set("Content-Type", contentType)
 	req.SetBasicAuth(config.AccountSID, config.AuthToken)
 
+	if err := types.WaitRateLimit(req.Context(), req.URL.Hostname()); err != nil {
+		return fmt.Errorf("waiting for rate limit: %w", err)
+	}
+
 	res, err := s.HTTPClient.Do(req)
 	if err != nil {
 		return fmt.Errorf("sending request to Twilio API: %w", err)
 	}
 
+	types.ReportHTTPStatus(req.Context(), res.StatusCode)
+
 	defer func() { _ = res.Body.Close() }()
 
 	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
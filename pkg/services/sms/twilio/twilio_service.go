@@ -1,9 +1,11 @@
 package twilio
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/url"
+	"time"
 
 	"github.com/nicholas-fedor/shoutrrr/pkg/format"
 	"github.com/nicholas-fedor/shoutrrr/pkg/services/standard"
@@ -24,6 +26,17 @@ func (s *Service) GetID() string {
 	return Scheme
 }
 
+// MessageLimit returns the message limit of Twilio, 1,600 characters.
+func (s *Service) MessageLimit() types.MessageLimit {
+	return types.MessageLimit{ChunkSize: 1600, TotalChunkSize: 1600, ChunkCount: 1}
+}
+
+// RateLimit returns the request rate limit of Twilio, which queues messages sent
+// faster than one per second from a long code number.
+func (s *Service) RateLimit() types.RateLimit {
+	return types.RateLimit{Requests: 1, Interval: time.Second}
+}
+
 // Initialize configures the service with a URL and logger.
 func (s *Service) Initialize(serviceURL *url.URL, logger types.StdLogger) error {
 	s.SetLogger(logger)
@@ -41,6 +54,11 @@ func (s *Service) Initialize(serviceURL *url.URL, logger types.StdLogger) error
 
 // Send delivers an SMS message via Twilio to all configured recipients.
 func (s *Service) Send(message string, params *types.Params) error {
+	return s.SendContext(context.Background(), message, params)
+}
+
+// SendContext delivers an SMS message via Twilio, aborting when ctx is done.
+func (s *Service) SendContext(ctx context.Context, message string, params *types.Params) error {
 	config := s.Config
 
 	err := s.pkr.UpdateConfigFromParams(config, params)
@@ -51,7 +69,7 @@ func (s *Service) Send(message string, params *types.Params) error {
 	var errs []error
 
 	for _, toNumber := range config.ToNumbers {
-		err := s.sendToRecipient(config, toNumber, message)
+		err := s.sendToRecipient(ctx, config, toNumber, message)
 		if err != nil {
 			errs = append(errs, fmt.Errorf("sending to %s: %w", toNumber, err))
 		}
@@ -43,6 +43,11 @@ func (s *Service) GetID() string {
 	return Scheme
 }
 
+// MessageLimit returns the message limit of generic webhooks, which have none.
+func (s *Service) MessageLimit() types.MessageLimit {
+	return types.MessageLimit{}
+}
+
 // GetPayload prepares the request payload based on the configured template.
 func (s *Service) GetPayload(config *Config, params types.Params) (io.Reader, error) {
 	switch config.Template {
@@ -115,6 +120,11 @@ func (s *Service) Initialize(serviceURL *url.URL, logger types.StdLogger) error
 
 // Send delivers a notification message to a generic webhook endpoint.
 func (s *Service) Send(message string, paramsPtr *types.Params) error {
+	return s.SendContext(context.Background(), message, paramsPtr)
+}
+
+// SendContext delivers a notification message to a generic webhook endpoint, aborting when ctx is done.
+func (s *Service) SendContext(ctx context.Context, message string, paramsPtr *types.Params) error {
 	// Create a copy of the config to avoid modifying the original
 	config := *s.Config
 
@@ -133,7 +143,7 @@ func (s *Service) Send(message string, paramsPtr *types.Params) error {
 
 	// Prepare parameters for sending
 	sendParams := createSendParams(&config, params, message)
-	if err := s.doSend(&config, sendParams); err != nil {
+	if err := s.doSend(ctx, &config, sendParams); err != nil {
 		// Execute the HTTP request to send the notification
 		return fmt.Errorf("%w: %s", ErrSendFailed, err.Error())
 	}
@@ -159,7 +169,7 @@ func (s *Service) SetHTTPClient(client types.HTTPClient) {
 }
 
 // doSend executes the HTTP request to send a notification to the webhook.
-func (s *Service) doSend(config *Config, params types.Params) error {
+func (s *Service) doSend(ctx context.Context, config *Config, params types.Params) error {
 	// Get the webhook URL as string
 	postURL := config.WebhookURL().String()
 
@@ -169,9 +179,6 @@ func (s *Service) doSend(config *Config, params types.Params) error {
 		return err
 	}
 
-	// Create background context for the request
-	ctx := context.Background()
-
 	// Create HTTP request with context
 	req, err := http.NewRequestWithContext(ctx, config.RequestMethod, postURL, payload)
 	if err != nil {
@@ -201,11 +208,17 @@ func (s *Service) doSend(config *Config, params types.Params) error {
 	}
 
 	// Send the HTTP request
+	if err := types.WaitRateLimit(req.Context(), req.URL.Hostname()); err != nil {
+		return fmt.Errorf("waiting for rate limit: %w", err)
+	}
+
 	res, err := client.Do(req)
 	if err != nil {
 		return fmt.Errorf("sending HTTP request: %w", err)
 	}
 
+	types.ReportHTTPStatus(req.Context(), res.StatusCode)
+
 	if res != nil && res.Body != nil {
 		// Read and log response body if available
 		defer func() {
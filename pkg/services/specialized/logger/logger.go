@@ -22,6 +22,11 @@ func (s *Service) GetID() string {
 	return Scheme
 }
 
+// MessageLimit returns the message limit of the logger, which has none.
+func (s *Service) MessageLimit() types.MessageLimit {
+	return types.MessageLimit{}
+}
+
 // Initialize loads ServiceConfig from serviceURL and sets logger for this Service.
 func (s *Service) Initialize(_ *url.URL, logger types.StdLogger) error {
 	s.SetLogger(logger)
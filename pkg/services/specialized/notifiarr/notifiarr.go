@@ -100,6 +100,11 @@ func (s *Service) GetID() string {
 	return Scheme
 }
 
+// MessageLimit returns the message limit of Notifiarr, which has none.
+func (s *Service) MessageLimit() types.MessageLimit {
+	return types.MessageLimit{}
+}
+
 // GetServiceURLFromCustom converts a custom webhook URL into a standard service URL.
 func (*Service) GetServiceURLFromCustom(serviceURL *url.URL) (*url.URL, error) {
 	// Copy the URL to modify
@@ -182,6 +187,11 @@ func (s *Service) ParseMentions(message string) []string {
 
 // Send delivers a notification message to Notifiarr.
 func (s *Service) Send(message string, paramsPtr *types.Params) error {
+	return s.SendContext(context.Background(), message, paramsPtr)
+}
+
+// SendContext delivers a notification message to Notifiarr, aborting when ctx is done.
+func (s *Service) SendContext(ctx context.Context, message string, paramsPtr *types.Params) error {
 	// Check for empty message
 	if message == "" {
 		return ErrEmptyMessage
@@ -226,7 +236,7 @@ func (s *Service) Send(message string, paramsPtr *types.Params) error {
 	}
 
 	// Send the notification
-	if err := s.doSend(payload); err != nil {
+	if err := s.doSend(ctx, payload); err != nil {
 		return fmt.Errorf("%w: %s", ErrSendFailed, err.Error())
 	}
 
@@ -365,13 +375,13 @@ func (s *Service) createPayload(
 
 // doSend executes the HTTP request to send a notification to Notifiarr.
 // It includes a timeout to prevent hangs and differentiates between authentication failures and other errors.
-func (s *Service) doSend(payload []byte) error {
+func (s *Service) doSend(ctx context.Context, payload []byte) error {
 	// Build the API URL with API key
 	apiURL := fmt.Sprintf("%s/%s", APIBaseURL, s.Config.APIKey)
 
 	// Create context with timeout to prevent request hangs
 	ctx, cancel := context.WithTimeout(
-		context.Background(),
+		ctx,
 		time.Duration(requestTimeout)*time.Second,
 	)
 	defer cancel()
@@ -391,11 +401,17 @@ func (s *Service) doSend(payload []byte) error {
 		client = &http.Client{}
 	}
 
+	if err := types.WaitRateLimit(req.Context(), req.URL.Hostname()); err != nil {
+		return fmt.Errorf("waiting for rate limit: %w", err)
+	}
+
 	res, err := client.Do(req)
 	if err != nil {
 		return fmt.Errorf("sending HTTP request: %w", err)
 	}
 
+	types.ReportHTTPStatus(req.Context(), res.StatusCode)
+
 	if res != nil && res.Body != nil {
 		defer func() {
 			_ = res.Body.Close()
@@ -15,7 +15,7 @@ type Config struct {
 	standard.EnumlessConfig
 
 	// The API key for Notifiarr authentication
-	APIKey string `desc:"The Notifiarr API key" key:"apikey" required:"true"`
+	APIKey string `desc:"The Notifiarr API key" key:"apikey" required:"true" sensitive:"true"`
 	// Optional name of the app/script for notifications
 	Name string `default:"Shoutrrr" desc:"Name of the app/script for notifications" key:"name" optional:""`
 	// Optional Discord channel ID for Discord notifications
@@ -0,0 +1,155 @@
+package shoutrrrtest
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/nicholas-fedor/shoutrrr/pkg/router"
+	"github.com/nicholas-fedor/shoutrrr/pkg/types"
+	"github.com/nicholas-fedor/shoutrrr/pkg/util"
+)
+
+var ErrUnsupportedService = errors.New("service is not mimicked by the test server")
+
+// fixedHostSchemes are the service schemes whose API host cannot be configured.
+// Their requests reach the server through Client.
+var fixedHostSchemes = []string{"slack", "discord", "telegram", "pushover"}
+
+// unthrottled disables the default rate limits of the services, which would
+// otherwise slow down tests sending many messages.
+var unthrottled = map[string]types.RateLimit{
+	"discord":  {},
+	"slack":    {},
+	"telegram": {},
+}
+
+// client redirects every request to the server, keeping the original Host header.
+type client struct {
+	server *Server
+}
+
+// Client returns an HTTP client sending every request to the server instead of its
+// original host, which the server uses to tell the APIs apart. Pass it as
+// SenderOptions.HTTPClient to reach the server from Slack, Discord, Telegram and
+// Pushover, whose API hosts are fixed.
+//
+// Returns:
+//   - types.HTTPClient: the client.
+func (s *Server) Client() types.HTTPClient {
+	return &client{server: s}
+}
+
+// Do sends req to the server.
+func (c *client) Do(req *http.Request) (*http.Response, error) {
+	redirected := req.Clone(req.Context())
+	redirected.URL.Scheme = "http"
+	redirected.URL.Host = c.server.Addr()
+	redirected.Host = req.URL.Host
+
+	res, err := c.server.server.Client().Do(redirected)
+	if err != nil {
+		return nil, fmt.Errorf("sending to test server: %w", err)
+	}
+
+	return res, nil
+}
+
+// URL rewrites a service URL to point at the server. The URLs of Gotify, ntfy and
+// generic webhooks are pointed at the server's address with TLS disabled. The URLs
+// of Slack, Discord, Telegram and Pushover are returned unchanged, as their
+// requests are redirected by Client.
+//
+// Parameters:
+//   - serviceURL: the service URL to rewrite.
+//
+// Returns:
+//   - string: the rewritten URL.
+//   - error: an error if the URL is invalid or its service is not mimicked.
+func (s *Server) URL(serviceURL string) (string, error) {
+	parsed, err := url.Parse(serviceURL)
+	if err != nil {
+		return "", fmt.Errorf("parsing service URL %s: %w", util.RedactURL(serviceURL), err)
+	}
+
+	scheme, _, _ := strings.Cut(parsed.Scheme, "+")
+	if slices.Contains(fixedHostSchemes, scheme) {
+		return serviceURL, nil
+	}
+
+	query := parsed.Query()
+
+	switch scheme {
+	case "gotify":
+		query.Set("disabletls", "yes")
+	case "ntfy":
+		query.Set("scheme", "http")
+	case "generic":
+		parsed.Scheme = "generic"
+		query.Set("disabletls", "yes")
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnsupportedService, parsed.Scheme)
+	}
+
+	parsed.Host = s.Addr()
+	parsed.Path = pathPrefix + scheme + "/" + strings.TrimPrefix(parsed.Path, "/")
+	parsed.RawPath = ""
+	parsed.RawQuery = query.Encode()
+
+	return parsed.String(), nil
+}
+
+// NewSender creates a router delivering to the server. The service URLs are
+// rewritten with URL, requests are sent with Client, and the default rate limits
+// of the services are disabled.
+//
+// Parameters:
+//   - serviceURLs: the service URLs to deliver to.
+//
+// Returns:
+//   - *router.ServiceRouter: the router.
+//   - error: an error if a URL cannot be rewritten or a service fails to initialize.
+func (s *Server) NewSender(serviceURLs ...string) (*router.ServiceRouter, error) {
+	return s.NewSenderWithOptions(types.SenderOptions{}, serviceURLs...)
+}
+
+// NewSenderWithOptions creates a router delivering to the server, as NewSender
+// does, with the given options. opts.HTTPClient is replaced by Client, and rate
+// limits set in opts.RateLimits are kept.
+//
+// Parameters:
+//   - opts: the sender options.
+//   - serviceURLs: the service URLs to deliver to.
+//
+// Returns:
+//   - *router.ServiceRouter: the router.
+//   - error: an error if a URL cannot be rewritten or a service fails to initialize.
+func (s *Server) NewSenderWithOptions(opts types.SenderOptions, serviceURLs ...string) (*router.ServiceRouter, error) {
+	urls := make([]string, len(serviceURLs))
+
+	for i, serviceURL := range serviceURLs {
+		rewritten, err := s.URL(serviceURL)
+		if err != nil {
+			return nil, err
+		}
+
+		urls[i] = rewritten
+	}
+
+	rateLimits := maps.Clone(unthrottled)
+	maps.Copy(rateLimits, opts.RateLimits)
+
+	opts.HTTPClient = s.Client()
+	opts.RateLimits = rateLimits
+
+	sender, err := router.NewWithOptions(nil, opts, urls...)
+	if err != nil {
+		return nil, fmt.Errorf("creating sender: %w", err)
+	}
+
+	return sender, nil
+}
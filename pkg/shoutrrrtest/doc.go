@@ -0,0 +1,43 @@
+// Package shoutrrrtest provides an in-process stand-in for the APIs of the major
+// notification services, so that applications can assert on the notifications
+// they send without a network.
+//
+// # Server
+//
+// A Server mimics the Slack webhook and chat.postMessage APIs, Discord webhooks,
+// the Telegram Bot API, Gotify, ntfy, Pushover and generic webhooks. It records
+// every Request it receives, including the notification text and title extracted
+// from the API's payload, and replies as the API would.
+//
+// Slack, Discord, Telegram and Pushover use fixed API hosts, so their requests
+// reach the server through the HTTP client returned by Client, which redirects
+// every request and keeps the original Host header to tell the APIs apart. The
+// URLs of Gotify, ntfy and generic webhooks are rewritten by URL to point at the
+// server. NewSender does both, and disables the default rate limits of the
+// services.
+//
+// # Scripting Failures
+//
+// Script queues replies to the next requests made to an API, e.g. Fail for an
+// error status or RateLimited for a 429 with a Retry-After delay. Error replies
+// carry the error body the API would send.
+//
+// # Usage
+//
+//	func TestDeployNotifies(t *testing.T) {
+//	    server := shoutrrrtest.Start(t)
+//	    server.Script(shoutrrrtest.Slack, shoutrrrtest.RateLimited(time.Second))
+//
+//	    sender, err := server.NewSender(slackURL, "ntfy://ntfy.example.com/deploys")
+//	    if err != nil {
+//	        t.Fatal(err)
+//	    }
+//
+//	    app := NewApp(sender)
+//	    app.Deploy()
+//
+//	    if got := server.Messages(shoutrrrtest.Ntfy); len(got) != 1 || got[0] != "deploy complete" {
+//	        t.Errorf("ntfy messages = %q", got)
+//	    }
+//	}
+package shoutrrrtest
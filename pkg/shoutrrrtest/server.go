@@ -0,0 +1,288 @@
+package shoutrrrtest
+
+import (
+	"bytes"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Service identifies an API mimicked by the Server.
+type Service string
+
+const (
+	// Slack is the Slack webhook and chat.postMessage API.
+	Slack Service = "slack"
+	// Discord is the Discord webhook API.
+	Discord Service = "discord"
+	// Telegram is the Telegram Bot API.
+	Telegram Service = "telegram"
+	// Gotify is the Gotify message API.
+	Gotify Service = "gotify"
+	// Ntfy is the ntfy publishing API.
+	Ntfy Service = "ntfy"
+	// Pushover is the Pushover message API.
+	Pushover Service = "pushover"
+	// Generic is any other webhook, as used by the generic service.
+	Generic Service = "generic"
+)
+
+// pathPrefix is the path prefix of the service URLs rewritten by Server.URL.
+const pathPrefix = "/shoutrrrtest/"
+
+// Request is a request received by the Server.
+type Request struct {
+	// Service is the API the request was made to.
+	Service Service
+	// Method is the HTTP method.
+	Method string
+	// Host is the host the request was addressed to, e.g. "hooks.slack.com".
+	Host string
+	// Path is the request path, without the prefix added by Server.URL.
+	Path string
+	// Query holds the query parameters.
+	Query url.Values
+	// Header holds the request headers.
+	Header http.Header
+	// Body is the raw request body.
+	Body []byte
+	// Message is the notification text extracted from the request, if found.
+	Message string
+	// Title is the notification title extracted from the request, if found.
+	Title string
+}
+
+// Reply scripts the response to a request.
+type Reply struct {
+	// Status is the HTTP status code. Zero or 2xx replies succeed with the
+	// response the API would send.
+	Status int
+	// RetryAfter, if > 0, is sent in a Retry-After header and, for APIs that
+	// report it in their error body, in the body.
+	RetryAfter time.Duration
+	// Body, if not empty, replaces the response body the API would send.
+	Body string
+}
+
+// Fail returns a reply failing with status and the error body the API would send.
+//
+// Parameters:
+//   - status: the HTTP status code, e.g. http.StatusUnauthorized.
+//
+// Returns:
+//   - Reply: the reply.
+func Fail(status int) Reply {
+	return Reply{Status: status}
+}
+
+// RateLimited returns a reply rejecting the request as rate limited.
+//
+// Parameters:
+//   - retryAfter: the delay the client is asked to wait before retrying.
+//
+// Returns:
+//   - Reply: the reply.
+func RateLimited(retryAfter time.Duration) Reply {
+	return Reply{Status: http.StatusTooManyRequests, RetryAfter: retryAfter}
+}
+
+// Server is an in-process stand-in for the APIs of the major notification services.
+// It records every request it receives and replies as the API would, or with the
+// replies scripted with Script. Server is safe for concurrent use.
+type Server struct {
+	server   *httptest.Server
+	mu       sync.Mutex
+	requests []Request
+	scripts  map[Service][]Reply
+	nextID   int
+}
+
+// NewServer starts a Server. The caller must Close it.
+//
+// Returns:
+//   - *Server: the running server.
+func NewServer() *Server {
+	s := &Server{scripts: make(map[Service][]Reply)}
+	s.server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+
+	return s
+}
+
+// Start starts a Server that is closed when the test and its subtests complete.
+//
+// Parameters:
+//   - tb: the test.
+//
+// Returns:
+//   - *Server: the running server.
+func Start(tb testing.TB) *Server {
+	s := NewServer()
+	tb.Cleanup(s.Close)
+
+	return s
+}
+
+// Close shuts down the server.
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// Addr returns the host and port the server listens on.
+//
+// Returns:
+//   - string: the address, e.g. "127.0.0.1:41234".
+func (s *Server) Addr() string {
+	return s.server.Listener.Addr().String()
+}
+
+// Script queues replies to the next requests made to service, in order. Once the
+// queue is exhausted, requests succeed again.
+//
+// Parameters:
+//   - service: the API whose requests to reply to.
+//   - replies: the replies to send.
+func (s *Server) Script(service Service, replies ...Reply) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.scripts[service] = append(s.scripts[service], replies...)
+}
+
+// Requests returns the requests received so far, optionally only those made to
+// the given services.
+//
+// Parameters:
+//   - services: the APIs to return the requests of. All if empty.
+//
+// Returns:
+//   - []Request: the requests, in the order they were received.
+func (s *Server) Requests(services ...Service) []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var requests []Request
+
+	for _, request := range s.requests {
+		if len(services) == 0 || slices.Contains(services, request.Service) {
+			requests = append(requests, request)
+		}
+	}
+
+	return requests
+}
+
+// Messages returns the notification texts received so far, optionally only those
+// sent to the given services.
+//
+// Parameters:
+//   - services: the APIs to return the messages of. All if empty.
+//
+// Returns:
+//   - []string: the messages, in the order they were received.
+func (s *Server) Messages(services ...Service) []string {
+	requests := s.Requests(services...)
+	messages := make([]string, len(requests))
+
+	for i, request := range requests {
+		messages[i] = request.Message
+	}
+
+	return messages
+}
+
+// Reset discards the received requests and the scripted replies.
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = nil
+	clear(s.scripts)
+}
+
+// serveHTTP records the request and writes the reply.
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	service, path := identify(r.Host, r.URL.Path)
+	request := Request{
+		Service: service,
+		Method:  r.Method,
+		Host:    r.Host,
+		Path:    path,
+		Query:   r.URL.Query(),
+		Header:  r.Header.Clone(),
+		Body:    body,
+	}
+	request.Message, request.Title = extract(request, r)
+
+	s.mu.Lock()
+	s.requests = append(s.requests, request)
+	s.nextID++
+	id := s.nextID
+
+	var reply Reply
+	if script := s.scripts[service]; len(script) > 0 {
+		reply, s.scripts[service] = script[0], script[1:]
+	}
+	s.mu.Unlock()
+
+	if reply.RetryAfter > 0 {
+		seconds := int((reply.RetryAfter + time.Second - 1) / time.Second)
+		w.Header().Set("Retry-After", strconv.Itoa(seconds))
+	}
+
+	status, contentType, responseBody := respond(request, reply, id)
+	if reply.Body != "" {
+		responseBody = reply.Body
+	}
+
+	if responseBody != "" {
+		w.Header().Set("Content-Type", contentType)
+	}
+
+	w.WriteHeader(status)
+	_, _ = io.WriteString(w, responseBody)
+}
+
+// identify returns the API a request was made to and its path without the prefix
+// added by Server.URL.
+func identify(host, path string) (Service, string) {
+	if rest, ok := strings.CutPrefix(path, pathPrefix); ok {
+		name, servicePath, _ := strings.Cut(rest, "/")
+
+		return Service(name), "/" + servicePath
+	}
+
+	switch strings.ToLower(stripPort(host)) {
+	case "hooks.slack.com", "slack.com":
+		return Slack, path
+	case "discord.com", "discordapp.com":
+		return Discord, path
+	case "api.telegram.org":
+		return Telegram, path
+	case "api.pushover.net":
+		return Pushover, path
+	case "ntfy.sh":
+		return Ntfy, path
+	default:
+		return Generic, path
+	}
+}
+
+// stripPort returns host without its port.
+func stripPort(host string) string {
+	if name, _, err := net.SplitHostPort(host); err == nil {
+		return name
+	}
+
+	return host
+}
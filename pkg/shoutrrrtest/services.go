@@ -0,0 +1,239 @@
+package shoutrrrtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	contentTypeJSON = "application/json"
+	contentTypeText = "text/plain; charset=utf-8"
+)
+
+// respond returns the response the API would send to the request, or the error
+// response it would send for a failing reply.
+func respond(request Request, reply Reply, id int) (int, string, string) {
+	if reply.Status >= http.StatusBadRequest {
+		contentType, body := errorBody(request, reply)
+
+		return reply.Status, contentType, body
+	}
+
+	status := reply.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+
+	switch request.Service {
+	case Slack:
+		if strings.HasPrefix(request.Path, "/api/") {
+			return status, contentTypeJSON, encode(map[string]any{"ok": true, "ts": fmt.Sprintf("%d.000100", id)})
+		}
+
+		return status, contentTypeText, "ok"
+	case Discord:
+		if reply.Status == 0 {
+			status = http.StatusNoContent
+		}
+
+		return status, "", ""
+	case Telegram:
+		return status, contentTypeJSON, encode(map[string]any{
+			"ok": true,
+			"result": map[string]any{
+				"message_id": id,
+				"text":       request.Message,
+			},
+		})
+	case Gotify:
+		return status, contentTypeJSON, encode(map[string]any{
+			"id":       id,
+			"appid":    1,
+			"message":  request.Message,
+			"title":    request.Title,
+			"priority": jsonValue(request.Body, "priority"),
+			"date":     time.Now().UTC().Format(time.RFC3339),
+		})
+	case Ntfy:
+		return status, contentTypeJSON, encode(map[string]any{
+			"id":      fmt.Sprintf("msg%d", id),
+			"time":    time.Now().Unix(),
+			"event":   "message",
+			"topic":   strings.TrimPrefix(request.Path, "/"),
+			"message": request.Message,
+		})
+	case Pushover:
+		return status, contentTypeJSON, encode(map[string]any{"status": 1, "request": fmt.Sprintf("req%d", id)})
+	default:
+		return status, "", ""
+	}
+}
+
+// errorBody returns the error response the API would send for a failing reply.
+func errorBody(request Request, reply Reply) (string, string) {
+	text := http.StatusText(reply.Status)
+	retryAfter := int((reply.RetryAfter + time.Second - 1) / time.Second)
+
+	switch request.Service {
+	case Slack:
+		code := strings.ReplaceAll(strings.ToLower(text), " ", "_")
+		if strings.HasPrefix(request.Path, "/api/") {
+			return contentTypeJSON, encode(map[string]any{"ok": false, "error": code})
+		}
+
+		return contentTypeText, code
+	case Discord:
+		body := map[string]any{"message": text, "code": 0}
+		if reply.Status == http.StatusTooManyRequests {
+			body["retry_after"] = reply.RetryAfter.Seconds()
+			body["global"] = false
+		}
+
+		return contentTypeJSON, encode(body)
+	case Telegram:
+		body := map[string]any{"ok": false, "error_code": reply.Status, "description": text}
+		if reply.RetryAfter > 0 {
+			body["description"] = fmt.Sprintf("%s: retry after %d", text, retryAfter)
+			body["parameters"] = map[string]any{"retry_after": retryAfter}
+		}
+
+		return contentTypeJSON, encode(body)
+	case Gotify:
+		return contentTypeJSON, encode(map[string]any{
+			"error":            text,
+			"errorCode":        reply.Status,
+			"errorDescription": "scripted failure",
+		})
+	case Ntfy:
+		return contentTypeJSON, encode(map[string]any{
+			"code":  reply.Status * 100, //nolint:mnd // ntfy error codes extend the HTTP status by two digits.
+			"http":  reply.Status,
+			"error": strings.ToLower(text),
+		})
+	case Pushover:
+		return contentTypeJSON, encode(map[string]any{"status": 0, "errors": []string{text}, "request": "failed"})
+	default:
+		return contentTypeText, text
+	}
+}
+
+// extract returns the notification text and title of the request.
+func extract(request Request, r *http.Request) (string, string) {
+	mediaType, _, _ := mime.ParseMediaType(request.Header.Get("Content-Type"))
+
+	switch request.Service {
+	case Slack:
+		message := jsonField(request.Body, "text")
+		if message == "" {
+			message = jsonField(request.Body, "attachments", "0", "text")
+		}
+
+		return message, jsonField(request.Body, "attachments", "0", "title")
+	case Discord:
+		body := request.Body
+		if strings.HasPrefix(mediaType, "multipart/") && r.ParseMultipartForm(maxMemory) == nil {
+			body = []byte(r.FormValue("payload_json"))
+		}
+
+		message := jsonField(body, "content")
+		if message == "" {
+			message = jsonField(body, "embeds", "0", "description")
+		}
+
+		return message, jsonField(body, "embeds", "0", "title")
+	case Telegram:
+		message := jsonField(request.Body, "text")
+		if message == "" {
+			message = jsonField(request.Body, "caption")
+		}
+
+		return message, ""
+	case Ntfy:
+		title := request.Header.Get("Title")
+		if title == "" {
+			title = request.Header.Get("X-Title")
+		}
+
+		return string(request.Body), title
+	case Pushover:
+		form := formValues(request.Body, mediaType, r)
+
+		return form.Get("message"), form.Get("title")
+	default:
+		if mediaType == contentTypeJSON || json.Valid(request.Body) {
+			return jsonField(request.Body, "message"), jsonField(request.Body, "title")
+		}
+
+		return string(request.Body), request.Query.Get("title")
+	}
+}
+
+// maxMemory is the memory used to parse multipart forms.
+const maxMemory = 32 << 20
+
+// formValues returns the fields of a form-encoded or multipart request body.
+func formValues(body []byte, mediaType string, r *http.Request) url.Values {
+	if strings.HasPrefix(mediaType, "multipart/") {
+		if err := r.ParseMultipartForm(maxMemory); err == nil {
+			return url.Values(r.MultipartForm.Value)
+		}
+
+		return nil
+	}
+
+	values, _ := url.ParseQuery(string(body))
+
+	return values
+}
+
+// jsonField returns the value at path in a JSON document formatted as text, or ""
+// if there is none. Array elements are addressed by their index.
+func jsonField(body []byte, path ...string) string {
+	switch value := jsonValue(body, path...).(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	default:
+		return encode(value)
+	}
+}
+
+// jsonValue returns the value at path in a JSON document, or nil if there is none.
+func jsonValue(body []byte, path ...string) any {
+	var value any
+	if json.Unmarshal(body, &value) != nil {
+		return nil
+	}
+
+	for _, key := range path {
+		switch node := value.(type) {
+		case map[string]any:
+			value = node[key]
+		case []any:
+			index, err := strconv.Atoi(key)
+			if err != nil || index < 0 || index >= len(node) {
+				return nil
+			}
+
+			value = node[index]
+		default:
+			return nil
+		}
+	}
+
+	return value
+}
+
+// encode returns the JSON encoding of value.
+func encode(value any) string {
+	data, _ := json.Marshal(value)
+
+	return string(data)
+}
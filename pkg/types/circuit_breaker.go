@@ -0,0 +1,19 @@
+package types
+
+import "time"
+
+// CircuitBreakerPolicy controls when ServiceRouter stops delivering to a target
+// that keeps failing.
+//
+// The zero value disables the circuit breaker. Once Threshold consecutive
+// deliveries to a target have failed, its circuit opens and further deliveries
+// fail fast. After Cooldown, one delivery is let through to probe the target;
+// its success closes the circuit, its failure opens it for another Cooldown.
+type CircuitBreakerPolicy struct {
+	// Threshold is the number of consecutive failed deliveries that opens the circuit.
+	Threshold int
+
+	// Cooldown is how long an open circuit fails fast before probing the target.
+	// Zero means one minute.
+	Cooldown time.Duration
+}
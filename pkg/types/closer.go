@@ -0,0 +1,11 @@
+package types
+
+import "context"
+
+// ContextCloser is the interface for services holding resources, such as broker
+// connections or login sessions, that must be released when the service is no
+// longer used. ServiceRouter.Close calls CloseContext on services implementing it,
+// and Close on services implementing io.Closer otherwise. ctx bounds the shutdown.
+type ContextCloser interface {
+	CloseContext(ctx context.Context) error
+}
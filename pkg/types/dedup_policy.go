@@ -0,0 +1,19 @@
+package types
+
+import "time"
+
+// DedupPolicy controls how ServiceRouter suppresses repeated messages.
+//
+// The zero value disables deduplication. Messages are identified by the
+// DedupKey param if set, or else by a hash of the message text and the values
+// of the params listed in Params.
+type DedupPolicy struct {
+	// Window is how long repeats of a message are suppressed after it was sent.
+	// When the window closes, suppressed repeats are rolled up into a single
+	// "(repeated N times)" summary.
+	Window time.Duration
+
+	// Params lists the param keys whose values are part of the message identity,
+	// e.g. "title" or "level".
+	Params []string
+}
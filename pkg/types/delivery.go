@@ -0,0 +1,136 @@
+package types
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DeliveryResult describes the outcome of delivering a message to one target.
+// It is returned by ServiceRouter.SendWithReport, one per configured service.
+type DeliveryResult struct {
+	// ServiceID is the service identifier, e.g. "slack".
+	ServiceID string
+	// URL is the service URL with credentials redacted.
+	URL string
+	// Duration is the total time spent delivering, including retries.
+	Duration time.Duration
+	// Attempts is the number of delivery attempts made.
+	Attempts int
+	// HTTPStatus is the status code of the last HTTP response, if any.
+	HTTPStatus int
+	// BrokerAck is the acknowledgement returned by a message broker (e.g. MQTT), if any.
+	BrokerAck string
+	// MessageID is the identifier the provider assigned to the message, if any.
+	MessageID string
+	// Err is the delivery error, wrapped in *TargetError, or nil on success.
+	Err error
+	// Skipped is true if the target's level filter excluded the message.
+	Skipped bool
+	// Suppressed is true if the message was a repeat suppressed by deduplication,
+	// which is not a failure: Err is nil.
+	Suppressed bool
+}
+
+// DeliveryReceipt collects the details a service reports while delivering a message.
+//
+// The router attaches a receipt to the context passed to ContextSender and
+// ContextAttachmentSender implementations. Services report into it using
+// ReportHTTPStatus, ReportBrokerAck and ReportMessageID; reports made without a
+// receipt in the context are ignored. When a service reports the same detail
+// several times (e.g. one request per recipient), the last report wins.
+type DeliveryReceipt struct {
+	mu         sync.Mutex
+	httpStatus int
+	brokerAck  string
+	messageID  string
+}
+
+// deliveryReceiptKey is the context key for the DeliveryReceipt.
+type deliveryReceiptKey struct{}
+
+// WithDeliveryReceipt returns a copy of ctx carrying the receipt.
+//
+// Parameters:
+//   - ctx: the parent context.
+//   - receipt: the receipt services should report into.
+//
+// Returns:
+//   - context.Context: the derived context.
+func WithDeliveryReceipt(ctx context.Context, receipt *DeliveryReceipt) context.Context {
+	return context.WithValue(ctx, deliveryReceiptKey{}, receipt)
+}
+
+// ReportHTTPStatus records the HTTP status code of a service API response.
+//
+// Parameters:
+//   - ctx: the context passed to the service.
+//   - status: the HTTP status code.
+func ReportHTTPStatus(ctx context.Context, status int) {
+	if receipt := receiptFromContext(ctx); receipt != nil {
+		receipt.mu.Lock()
+		receipt.httpStatus = status
+		receipt.mu.Unlock()
+	}
+}
+
+// ReportBrokerAck records the acknowledgement returned by a message broker.
+//
+// Parameters:
+//   - ctx: the context passed to the service.
+//   - ack: a description of the acknowledgement.
+func ReportBrokerAck(ctx context.Context, ack string) {
+	if receipt := receiptFromContext(ctx); receipt != nil {
+		receipt.mu.Lock()
+		receipt.brokerAck = ack
+		receipt.mu.Unlock()
+	}
+}
+
+// ReportMessageID records the identifier the provider assigned to a message.
+//
+// Parameters:
+//   - ctx: the context passed to the service.
+//   - id: the provider message ID.
+func ReportMessageID(ctx context.Context, id string) {
+	if receipt := receiptFromContext(ctx); receipt != nil {
+		receipt.mu.Lock()
+		receipt.messageID = id
+		receipt.mu.Unlock()
+	}
+}
+
+// HTTPStatus returns the last reported HTTP status code, or 0.
+func (r *DeliveryReceipt) HTTPStatus() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.httpStatus
+}
+
+// BrokerAck returns the last reported broker acknowledgement, or "".
+func (r *DeliveryReceipt) BrokerAck() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.brokerAck
+}
+
+// MessageID returns the last reported provider message ID, or "".
+func (r *DeliveryReceipt) MessageID() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.messageID
+}
+
+// receiptFromContext returns the receipt attached to ctx, or nil.
+func receiptFromContext(ctx context.Context) *DeliveryReceipt {
+	if ctx == nil {
+		return nil
+	}
+
+	receipt, _ := ctx.Value(deliveryReceiptKey{}).(*DeliveryReceipt)
+
+	return receipt
+}
@@ -23,6 +23,15 @@
 //   - ContextAttachmentSender: Opt-in interface for services that accept a
 //     context.Context in SendItemsContext for cancellation and deadline
 //     propagation on rich sends.
+//   - ContextCloser: Opt-in interface for services holding persistent connections
+//     or sessions, released by ServiceRouter.Close. Services implementing
+//     io.Closer are closed as well.
+//   - LevelMapper: Opt-in interface for services with a native priority or
+//     severity param. The router sets the param from the message level using
+//     the service's LevelMapping.
+//   - MarkupRenderer: Opt-in interface for services that render the body format
+//     declared with the format param (text, markdown or html) to their own markup.
+//     The router converts the bodies of other services to plain text.
 //   - Templater: Provides template management for message formatting.
 //   - ServiceConfig: Common interface for service configuration types.
 //   - Generator: Interface for tools that generate service configurations.
@@ -31,7 +40,15 @@
 //   - HTTPClientSetter: Implemented by services to accept a custom HTTPClient
 //     (injected by router.NewWithOptions / NewSenderWithOptions).
 //   - SenderOptions: Options for creating senders/routers, including HTTPClient
-//     and Timeout overrides.
+//     and Timeout overrides, the RetryPolicy and the Middleware chain.
+//   - Delivery / DeliverFunc / Middleware: Hooks run by the router around every
+//     delivery, able to modify, observe or veto it.
+//   - DeliveryResult / DeliveryReceipt: Per-target outcome returned by
+//     ServiceRouter.SendWithReport, and the context-carried collector services
+//     report HTTP statuses, broker acks and message IDs into.
+//   - HTTPStatusError / RetryAfterError: Implemented by service errors that carry
+//     an HTTP status code or a server-provided retry delay, used to classify
+//     failures for retries.
 //
 // # Message Types
 //
@@ -69,7 +86,18 @@
 //   - QueuedSender: Interface for senders that support message queuing.
 //   - CustomURLConfig: Interface for configurations that support custom URL
 //     resolution.
-//   - MessageLimit: Defines limits for message content.
+//   - MessageLimit: Defines limits for message content. Services declare theirs by
+//     implementing MessageLimiter.
+//   - RetryPolicy: Configures automatic retries with exponential backoff; see
+//     DefaultRetryable for the default error classification.
+//   - DedupPolicy: Configures the router's suppression of repeated messages.
+//   - OversizePolicy: Configures whether the router splits, truncates or rejects
+//     messages longer than the MessageLimit of a service.
+//   - CircuitBreakerPolicy: Configures when the router stops delivering to
+//     targets that keep failing.
+//   - RateLimit: Defines a request rate limit. Services declare the limit of their
+//     upstream API by implementing RateLimiter, and call WaitRateLimit before each
+//     HTTP request.
 //
 // The types in this package are designed to be used by both service
 // implementers and consumers of the shoutrrr library, providing a consistent
@@ -0,0 +1,98 @@
+package types
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+)
+
+// ErrorClass is a coarse classification of a delivery failure, suitable for
+// use as a metrics label.
+type ErrorClass string
+
+const (
+	// ErrorClassTimeout is a delivery that did not complete in time.
+	ErrorClassTimeout ErrorClass = "timeout"
+	// ErrorClassCanceled is a delivery aborted by its caller.
+	ErrorClassCanceled ErrorClass = "canceled"
+	// ErrorClassRateLimited is a delivery rejected by the service's rate limit.
+	ErrorClassRateLimited ErrorClass = "rate_limited"
+	// ErrorClassAuth is a delivery rejected for invalid or missing credentials.
+	ErrorClassAuth ErrorClass = "auth"
+	// ErrorClassClient is a delivery rejected as invalid by the service (4xx).
+	ErrorClassClient ErrorClass = "client"
+	// ErrorClassServer is a delivery that failed with a service error (5xx).
+	ErrorClassServer ErrorClass = "server"
+	// ErrorClassNetwork is a delivery that failed to reach the service.
+	ErrorClassNetwork ErrorClass = "network"
+	// ErrorClassCircuitOpen is a delivery failed fast by an open circuit breaker.
+	ErrorClassCircuitOpen ErrorClass = "circuit_open"
+	// ErrorClassOther is any other delivery failure.
+	ErrorClassOther ErrorClass = "other"
+)
+
+// ClassifyError returns the class of a delivery error.
+//
+// HTTP status codes take precedence over the other properties of the error:
+// 401 and 403 are auth failures, 408 a timeout, 429 and errors carrying a
+// Retry-After hint rate limiting. Circuit breaker errors are classified by the
+// router, which sets TargetError.Class.
+//
+// Parameters:
+//   - err: the error to classify.
+//
+// Returns:
+//   - ErrorClass: the class of err, or "" if err is nil.
+func ClassifyError(err error) ErrorClass {
+	if err == nil {
+		return ""
+	}
+
+	var targetErr *TargetError
+	if errors.As(err, &targetErr) && targetErr.Class != "" {
+		return targetErr.Class
+	}
+
+	var statusErr HTTPStatusError
+	if errors.As(err, &statusErr) {
+		switch status := statusErr.HTTPStatus(); {
+		case status == http.StatusUnauthorized, status == http.StatusForbidden:
+			return ErrorClassAuth
+		case status == http.StatusRequestTimeout:
+			return ErrorClassTimeout
+		case status == http.StatusTooManyRequests:
+			return ErrorClassRateLimited
+		case status >= http.StatusInternalServerError:
+			return ErrorClassServer
+		case status >= http.StatusBadRequest:
+			return ErrorClassClient
+		}
+	}
+
+	var retryAfterErr RetryAfterError
+	if errors.As(err, &retryAfterErr) {
+		if _, ok := retryAfterErr.RetryAfter(); ok {
+			return ErrorClassRateLimited
+		}
+	}
+
+	if errors.Is(err, context.Canceled) {
+		return ErrorClassCanceled
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return ErrorClassTimeout
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		if netErr.Timeout() {
+			return ErrorClassTimeout
+		}
+
+		return ErrorClassNetwork
+	}
+
+	return ErrorClassOther
+}
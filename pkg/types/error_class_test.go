@@ -0,0 +1,58 @@
+package types
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testStatusError is an error carrying an HTTP status and an optional Retry-After delay.
+type testStatusError struct {
+	status     int
+	retryAfter time.Duration
+}
+
+func (e testStatusError) Error() string { return fmt.Sprintf("status %d", e.status) }
+
+func (e testStatusError) HTTPStatus() int { return e.status }
+
+func (e testStatusError) RetryAfter() (time.Duration, bool) { return e.retryAfter, e.retryAfter > 0 }
+
+func TestClassifyError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		err      error
+		expected ErrorClass
+	}{
+		{"nil", nil, ""},
+		{"unauthorized", testStatusError{status: 401}, ErrorClassAuth},
+		{"forbidden", testStatusError{status: 403}, ErrorClassAuth},
+		{"request timeout", testStatusError{status: 408}, ErrorClassTimeout},
+		{"too many requests", testStatusError{status: 429}, ErrorClassRateLimited},
+		{"bad request", testStatusError{status: 400}, ErrorClassClient},
+		{"server error", fmt.Errorf("posting: %w", testStatusError{status: 502}), ErrorClassServer},
+		{"retry after", testStatusError{retryAfter: time.Second}, ErrorClassRateLimited},
+		{"canceled", context.Canceled, ErrorClassCanceled},
+		{"deadline", context.DeadlineExceeded, ErrorClassTimeout},
+		{"network timeout", &net.OpError{Op: "read", Err: os.ErrDeadlineExceeded}, ErrorClassTimeout},
+		{"network", &net.OpError{Op: "dial", Err: errors.New("connection refused")}, ErrorClassNetwork},
+		{"classified", &TargetError{Err: errors.New("open"), Class: ErrorClassCircuitOpen}, ErrorClassCircuitOpen},
+		{"other", errors.New("invalid config"), ErrorClassOther},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, ClassifyError(tt.err))
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package types
+
+// LevelMapping maps message levels to the values of a service's native
+// priority, severity or urgency param.
+type LevelMapping struct {
+	// Param is the name of the service param the mapped values are set to.
+	Param string
+	// Values maps message levels to param values. Levels without a value
+	// leave the param unset.
+	Values map[MessageLevel]string
+}
+
+// Value returns the param value mapped to level.
+//
+// Parameters:
+//   - level: the message level.
+//
+// Returns:
+//   - string: the mapped value.
+//   - bool: true if level has a mapped value.
+func (m LevelMapping) Value(level MessageLevel) (string, bool) {
+	value, ok := m.Values[level]
+
+	return value, ok && value != ""
+}
+
+// LevelMapper is implemented by services with a native notion of priority or
+// severity. The router uses the mapping to translate the level of a message to
+// the service's own param.
+type LevelMapper interface {
+	// LevelMapping returns the default mapping of message levels to the service's param.
+	LevelMapping() LevelMapping
+}
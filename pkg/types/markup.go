@@ -0,0 +1,9 @@
+package types
+
+// MarkupRenderer is implemented by services that render the body format declared
+// with the FormatKey param to their own markup. The router converts the bodies it
+// sends to other services to plain text.
+type MarkupRenderer interface {
+	// Markup returns the markup the service renders message bodies to, e.g. "html".
+	Markup() string
+}
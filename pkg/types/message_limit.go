@@ -1,6 +1,7 @@
 package types
 
 // MessageLimit is used for declaring the payload limits for services upstream APIs.
+// Sizes are in runes. A zero TotalChunkSize means the API sets no limit.
 type MessageLimit struct {
 	ChunkSize      int
 	TotalChunkSize int
@@ -8,3 +9,9 @@ type MessageLimit struct {
 	// Maximum number of chunks (including the last chunk for meta data)
 	ChunkCount int
 }
+
+// MessageLimiter is implemented by services that declare the payload limits of their upstream API.
+type MessageLimiter interface {
+	// MessageLimit returns the limits of a single notification.
+	MessageLimit() MessageLimit
+}
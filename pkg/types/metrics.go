@@ -0,0 +1,49 @@
+package types
+
+import "time"
+
+// DeliveryObservation describes a completed delivery of a message to one target.
+type DeliveryObservation struct {
+	// Scheme is the service scheme of the target, e.g. "slack".
+	Scheme string
+	// Duration is the total time spent delivering, including retries and
+	// middleware.
+	Duration time.Duration
+	// Attempts is the number of send attempts made. It is zero if the delivery
+	// was vetoed by middleware or failed fast by an open circuit.
+	Attempts int
+	// Bytes is the size of the message text, or the total size of the item texts.
+	Bytes int
+	// Err is the delivery error, or nil on success.
+	Err error
+	// Class is the classification of Err, or "" on success.
+	Class ErrorClass
+}
+
+// Retries returns the number of attempts made after the first one.
+//
+// Returns:
+//   - int: the number of retries.
+func (o DeliveryObservation) Retries() int {
+	return max(o.Attempts-1, 0)
+}
+
+// Metrics receives an observation of every delivery made by a router, whether it
+// succeeded or failed. Deliveries excluded by a level filter or suppressed as
+// duplicates are not observed.
+//
+// Implementations adapt observations to a metrics system and must be safe for
+// concurrent use. ObserveDelivery is called on the delivering goroutine, so it
+// should not block.
+type Metrics interface {
+	// ObserveDelivery records the outcome of a delivery.
+	ObserveDelivery(observation DeliveryObservation)
+}
+
+// MetricsFunc adapts a function to the Metrics interface.
+type MetricsFunc func(observation DeliveryObservation)
+
+// ObserveDelivery calls f(observation).
+func (f MetricsFunc) ObserveDelivery(observation DeliveryObservation) {
+	f(observation)
+}
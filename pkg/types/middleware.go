@@ -0,0 +1,29 @@
+package types
+
+import "context"
+
+// Delivery describes a single delivery of a message to one service.
+//
+// Middleware may modify Message, Items and Params before calling the next
+// handler. Params and Items are copies owned by the delivery, so changes do not
+// affect other services; the Fields and File of individual items are shared.
+type Delivery struct {
+	// Service is the service the message is delivered to.
+	Service Service
+	// Message is the message text. It is empty for deliveries made by SendItems.
+	Message string
+	// Items holds the message items for deliveries made by SendItems, or nil.
+	Items []MessageItem
+	// Params are the parameters of the delivery.
+	Params Params
+}
+
+// DeliverFunc performs a delivery and returns its result.
+type DeliverFunc func(ctx context.Context, delivery *Delivery) error
+
+// Middleware wraps a DeliverFunc to run logic around every delivery made by a router.
+//
+// A middleware can inspect and modify the delivery before calling next, observe
+// the result afterwards, or veto the delivery by returning without calling next.
+// Retries happen inside next, so a middleware sees every delivery once.
+type Middleware func(next DeliverFunc) DeliverFunc
@@ -0,0 +1,16 @@
+package types
+
+// OversizePolicy controls how ServiceRouter handles a message longer than the
+// MessageLimit of a service. The zero value passes the message to the service as is.
+type OversizePolicy string
+
+const (
+	// OversizeNone passes oversized messages to the service as is.
+	OversizeNone OversizePolicy = "none"
+	// OversizeSplit sends an oversized message as several numbered parts.
+	OversizeSplit OversizePolicy = "split"
+	// OversizeTruncate cuts an oversized message to the limit, ending it with an ellipsis.
+	OversizeTruncate OversizePolicy = "truncate"
+	// OversizeReject fails the delivery of an oversized message without sending it.
+	OversizeReject OversizePolicy = "reject"
+)
@@ -10,6 +10,12 @@ const (
 	MessageKey = "message"
 	// LevelKey is the common key for the message level prop.
 	LevelKey = "level"
+	// FormatKey is the common key for the format of the message body, "text",
+	// "markdown" or "html".
+	FormatKey = "format"
+	// DedupKey is the key for an explicit deduplication key. The router consumes it
+	// and does not pass it on to services.
+	DedupKey = "dedupkey"
 )
 
 // Level returns the MessageLevel stored under the "level" param.
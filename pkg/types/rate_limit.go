@@ -0,0 +1,73 @@
+package types
+
+import (
+	"context"
+	"time"
+)
+
+// RateLimit describes a token bucket: Requests requests are allowed per Interval,
+// with up to Burst requests sent back-to-back. The zero value means no limit.
+type RateLimit struct {
+	// Requests is the number of requests allowed per Interval.
+	Requests int
+	// Interval is the period over which Requests are allowed.
+	Interval time.Duration
+	// Burst is the maximum number of requests sent without waiting.
+	// Zero means Requests.
+	Burst int
+}
+
+// Enabled reports whether the rate limit restricts requests.
+func (l RateLimit) Enabled() bool {
+	return l.Requests > 0 && l.Interval > 0
+}
+
+// RateLimiter is implemented by services that declare the request rate limit of
+// their upstream API. The router uses it as the default limit of each target.
+type RateLimiter interface {
+	// RateLimit returns the request rate limit of a single target.
+	RateLimit() RateLimit
+}
+
+// RateLimitWaitFunc blocks until a request to host may be made, or ctx is done.
+type RateLimitWaitFunc func(ctx context.Context, host string) error
+
+// rateLimitWaitKey is the context key for the RateLimitWaitFunc.
+type rateLimitWaitKey struct{}
+
+// WithRateLimitWait returns a copy of ctx carrying the wait function services call
+// before each HTTP request.
+//
+// Parameters:
+//   - ctx: the parent context.
+//   - wait: the function throttling requests.
+//
+// Returns:
+//   - context.Context: the derived context.
+func WithRateLimitWait(ctx context.Context, wait RateLimitWaitFunc) context.Context {
+	return context.WithValue(ctx, rateLimitWaitKey{}, wait)
+}
+
+// WaitRateLimit blocks until the rate limits of the router allow a request to host.
+// Services call it before each HTTP request, so that messages sent as several
+// requests (e.g. chunks) are throttled individually. Without a wait function in
+// the context, it returns immediately.
+//
+// Parameters:
+//   - ctx: the context passed to the service.
+//   - host: the host name of the request.
+//
+// Returns:
+//   - error: the context error if ctx is done while waiting.
+func WaitRateLimit(ctx context.Context, host string) error {
+	if ctx == nil {
+		return nil
+	}
+
+	wait, _ := ctx.Value(rateLimitWaitKey{}).(RateLimitWaitFunc)
+	if wait == nil {
+		return nil
+	}
+
+	return wait(ctx, host)
+}
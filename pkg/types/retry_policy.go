@@ -0,0 +1,114 @@
+package types
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+)
+
+// RetryPolicy controls how ServiceRouter retries failed deliveries.
+//
+// The zero value disables retries, so every service gets exactly one attempt.
+// All attempts for a service, including the waits between them, share the
+// router's per-service Timeout.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts per service, including the
+	// first one. Values below 2 disable retries.
+	MaxAttempts int
+
+	// BaseBackoff is the delay before the first retry. It doubles after every
+	// further failed attempt.
+	BaseBackoff time.Duration
+
+	// MaxBackoff caps the delay between two attempts. Zero means no cap.
+	MaxBackoff time.Duration
+
+	// Jitter is the fraction (0 to 1) of each backoff delay that is randomized,
+	// spreading out retries from many senders hitting the same API.
+	Jitter float64
+
+	// Retryable reports whether a failed attempt should be retried.
+	// If nil, DefaultRetryable is used.
+	Retryable func(err error) bool
+}
+
+// HTTPStatusError is implemented by errors that carry the HTTP status code
+// returned by a service API.
+type HTTPStatusError interface {
+	error
+	HTTPStatus() int
+}
+
+// RetryAfterError is implemented by errors that carry a server-provided delay
+// before the request may be retried, e.g. from a Retry-After header.
+type RetryAfterError interface {
+	error
+	RetryAfter() (time.Duration, bool)
+}
+
+// Enabled reports whether the policy allows more than one attempt.
+//
+// Returns:
+//   - bool: true if retries are enabled.
+func (p RetryPolicy) Enabled() bool {
+	return p.MaxAttempts > 1
+}
+
+// IsRetryable reports whether err should be retried according to the policy.
+//
+// Parameters:
+//   - err: the error returned by the failed attempt.
+//
+// Returns:
+//   - bool: true if the attempt should be retried.
+func (p RetryPolicy) IsRetryable(err error) bool {
+	if p.Retryable != nil {
+		return p.Retryable(err)
+	}
+
+	return DefaultRetryable(err)
+}
+
+// DefaultRetryable is the error classifier used when RetryPolicy.Retryable is nil.
+//
+// It treats rate limiting (429), request timeouts (408, 425), server errors (5xx),
+// errors carrying a Retry-After hint and network-level failures as transient.
+// Cancellation and every other error, such as invalid configuration or
+// rejected credentials, are treated as permanent.
+//
+// Parameters:
+//   - err: the error to classify.
+//
+// Returns:
+//   - bool: true if the error is considered transient.
+func DefaultRetryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var statusErr HTTPStatusError
+	if errors.As(err, &statusErr) {
+		switch status := statusErr.HTTPStatus(); {
+		case status == http.StatusTooManyRequests,
+			status == http.StatusRequestTimeout,
+			status == http.StatusTooEarly,
+			status >= http.StatusInternalServerError:
+			return true
+		case status != 0:
+			return false
+		}
+	}
+
+	var retryAfterErr RetryAfterError
+	if errors.As(err, &retryAfterErr) {
+		if _, ok := retryAfterErr.RetryAfter(); ok {
+			return true
+		}
+	}
+
+	var netErr net.Error
+
+	return errors.As(err, &netErr) || errors.Is(err, context.DeadlineExceeded)
+}
@@ -9,6 +9,29 @@ import "time"
 // custom transports, dialers, TLS configuration, timeouts, etc.
 //
 // Timeout, if > 0, overrides the default per-service timeout.
+//
+// Retry configures automatic retries of failed deliveries. The zero value
+// disables retries.
+//
+// Dedup configures suppression of repeated messages. The zero value disables it.
+//
+// CircuitBreaker stops deliveries to targets that keep failing. The zero value
+// disables it.
+//
+// Middleware is run around every delivery, in order; the first middleware is
+// the outermost.
+//
+// Templates are registered on every service of the router, so that URLs can
+// refer to them by ID.
+//
+// RateLimits and HostRateLimits throttle requests per target and per API host,
+// and MaxConcurrent caps the number of sends in flight across all targets.
+//
+// Metrics, if non-nil, receives an observation of every delivery.
+//
+// ExpandSecrets enables the expansion of ${provider:ref} secret references in
+// service URLs. It reads environment variables and files, so only enable it for
+// URLs from trusted sources.
 type SenderOptions struct {
 	// HTTPClient is the client used for all HTTP operations.
 	// If nil, a default client with reasonable settings is used.
@@ -16,4 +39,41 @@ type SenderOptions struct {
 
 	// Timeout overrides the default operation timeout when > 0.
 	Timeout time.Duration
+
+	// Retry is the retry policy applied to every service of the router.
+	Retry RetryPolicy
+
+	// Dedup is the duplicate suppression policy of the router.
+	Dedup DedupPolicy
+
+	// Oversize is how the router handles messages longer than the MessageLimit
+	// of a service. The zero value passes them to the service as is.
+	Oversize OversizePolicy
+
+	// CircuitBreaker is the circuit breaker policy applied to every target of the router.
+	CircuitBreaker CircuitBreakerPolicy
+
+	// Middleware is the chain of hooks run around every delivery.
+	Middleware []Middleware
+
+	// Templates maps template IDs to template bodies registered on every service.
+	Templates map[string]string
+
+	// RateLimits overrides the per-target rate limit of services by scheme,
+	// e.g. "discord". A zero RateLimit disables the service's default limit.
+	RateLimits map[string]RateLimit
+
+	// HostRateLimits limits the requests to API host names, e.g. "api.telegram.org",
+	// shared by all targets of the router.
+	HostRateLimits map[string]RateLimit
+
+	// MaxConcurrent caps the number of sends in flight when > 0.
+	MaxConcurrent int
+
+	// Metrics receives the outcome of every delivery of the router.
+	Metrics Metrics
+
+	// ExpandSecrets expands the secret references of service URLs when services
+	// are added.
+	ExpandSecrets bool
 }
@@ -8,11 +8,16 @@ import "fmt"
 // per-service error positions. Callers can use errors.As to recover the
 // *TargetError and read the URL/ID of the failed target, and errors.Unwrap
 // to reach the underlying error.
+//
+// Errors returned by the router carry the Class of the failure, as determined
+// by ClassifyError.
 type TargetError struct {
 	// URL is the service URL or identifier that failed.
 	URL string
 	// Err is the underlying error from the service.
 	Err error
+	// Class is the classification of the failure, or "" if not classified.
+	Class ErrorClass
 }
 
 // Error returns the formatted error message including the target URL.
@@ -14,7 +14,8 @@
 //     empty string handling
 //
 //   - Message partitioning: PartitionMessage, MessageItemsFromLines,
-//     and Ellipsis for splitting large messages into service-compatible chunks
+//     and Ellipsis for splitting large messages into service-compatible chunks,
+//     and the markup-aware SplitMessage and TruncateMessage
 //
 //   - Logging: DiscardLogger for discarding log output
 //
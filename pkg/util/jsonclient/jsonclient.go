@@ -10,8 +10,11 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/nicholas-fedor/shoutrrr/pkg/types"
+	"github.com/nicholas-fedor/shoutrrr/pkg/util"
 )
 
 // Client defines the interface for JSON HTTP operations.
@@ -22,10 +25,19 @@ type Client interface {
 	ErrorResponse(err error, response any) bool
 }
 
+// ContextClient is the interface for clients that support context-aware requests.
+// Clients that implement this interface bind their requests to the caller's context,
+// enabling cancellation, deadline propagation and rate limit waits.
+type ContextClient interface {
+	GetContext(ctx context.Context, url string, response any) error
+	PostContext(ctx context.Context, url string, request, response any) error
+}
+
 // Error contains additional HTTP/JSON details.
 type Error struct {
 	StatusCode int
 	Body       string
+	Header     http.Header
 	err        error
 }
 
@@ -42,6 +54,10 @@ const ContentType = "application/json"
 // HTTPClientErrorThreshold specifies the status code threshold for client errors (400+).
 const HTTPClientErrorThreshold = 400
 
+// unixTimestampThreshold separates X-RateLimit-Reset values given as a relative
+// number of seconds from absolute Unix timestamps.
+const unixTimestampThreshold = 1e9
+
 // ErrUnexpectedStatus indicates an unexpected HTTP response status.
 var ErrUnexpectedStatus = errors.New("got unexpected HTTP status")
 
@@ -65,6 +81,76 @@ func (je Error) String() string {
 	return je.err.Error()
 }
 
+// Unwrap returns the underlying error.
+func (je Error) Unwrap() error {
+	return je.err
+}
+
+// HTTPStatus returns the HTTP status code of the response that caused the error.
+func (je Error) HTTPStatus() int {
+	return je.StatusCode
+}
+
+// RetryAfter returns the delay the server asked for before retrying, if any.
+func (je Error) RetryAfter() (time.Duration, bool) {
+	return ParseRetryAfter(je.Header, time.Now())
+}
+
+// NewError creates an Error from a failed HTTP response.
+//
+// It lets services that do not use Client surface the status code and
+// rate limit headers of a failed request in the same way.
+func NewError(res *http.Response, body string, err error) Error {
+	return Error{
+		StatusCode: res.StatusCode,
+		Body:       body,
+		Header:     res.Header,
+		err:        err,
+	}
+}
+
+// ParseRetryAfter extracts the retry delay from rate limit response headers.
+//
+// Retry-After is honored both as a number of seconds and as an HTTP date.
+// X-RateLimit-Reset-After is read as seconds, and X-RateLimit-Reset as a Unix
+// timestamp in seconds (small values are treated as a relative delay).
+// Delays in the past are reported as zero.
+func ParseRetryAfter(header http.Header, now time.Time) (time.Duration, bool) {
+	if header == nil {
+		return 0, false
+	}
+
+	if value := header.Get("Retry-After"); value != "" {
+		if seconds, err := strconv.ParseFloat(value, 64); err == nil {
+			return secondsToDuration(seconds), true
+		}
+
+		if date, err := http.ParseTime(value); err == nil {
+			return max(date.Sub(now), 0), true
+		}
+	}
+
+	if value := header.Get("X-RateLimit-Reset-After"); value != "" {
+		if seconds, err := strconv.ParseFloat(value, 64); err == nil {
+			return secondsToDuration(seconds), true
+		}
+	}
+
+	if value := header.Get("X-RateLimit-Reset"); value != "" {
+		if reset, err := strconv.ParseFloat(value, 64); err == nil {
+			if reset < unixTimestampThreshold {
+				return secondsToDuration(reset), true
+			}
+
+			resetTime := time.UnixMilli(int64(reset * float64(time.Second/time.Millisecond)))
+
+			return max(resetTime.Sub(now), 0), true
+		}
+	}
+
+	return 0, false
+}
+
 // ErrorBody extracts the request body from an error if it's a jsonclient.Error.
 func ErrorBody(e error) string {
 	var jsonError Error
@@ -98,7 +184,7 @@ func NewWithHTTPClient(httpClient types.HTTPClient) Client {
 // Deprecated: Create a Client with NewWithHTTPClient and call Get on it instead.
 func Get(url string, response any) error {
 	if err := DefaultClient.Get(url, response); err != nil {
-		return fmt.Errorf("getting JSON from %q: %w", url, err)
+		return fmt.Errorf("getting JSON from %q: %w", util.RedactURL(url), err)
 	}
 
 	return nil
@@ -109,7 +195,7 @@ func Get(url string, response any) error {
 // Deprecated: Create a Client with NewWithHTTPClient and call Post on it instead.
 func Post(url string, request, response any) error {
 	if err := DefaultClient.Post(url, request, response); err != nil {
-		return fmt.Errorf("posting JSON to %q: %w", url, err)
+		return fmt.Errorf("posting JSON to %q: %w", util.RedactURL(url), err)
 	}
 
 	return nil
@@ -127,22 +213,33 @@ func (c *client) ErrorResponse(err error, response any) bool {
 
 // Get fetches a URL using GET and unmarshals the response into the provided object.
 func (c *client) Get(url string, response any) error {
-	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, url, http.NoBody)
+	return c.GetContext(context.Background(), url, response)
+}
+
+// GetContext fetches a URL using GET, bound to ctx, and unmarshals the response into the provided object.
+func (c *client) GetContext(ctx context.Context, url string, response any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
 	if err != nil {
-		return fmt.Errorf("creating GET request for %q: %w", url, err)
+		return fmt.Errorf("creating GET request for %q: %w", util.RedactURL(url), util.RedactError(err))
 	}
 
 	for key, val := range c.headers {
 		req.Header.Set(key, val[0])
 	}
 
+	if err := types.WaitRateLimit(ctx, req.URL.Hostname()); err != nil {
+		return fmt.Errorf("waiting for rate limit: %w", err)
+	}
+
 	res, err := c.httpClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("executing GET request to %q: %w", url, err)
+		return fmt.Errorf("executing GET request to %q: %w", util.RedactURL(url), util.RedactError(err))
 	}
 
 	defer func() { _ = res.Body.Close() }()
 
+	types.ReportHTTPStatus(ctx, res.StatusCode)
+
 	return parseResponse(res, response)
 }
 
@@ -153,6 +250,11 @@ func (c *client) Headers() http.Header {
 
 // Post sends a request as JSON and unmarshals the response into the provided object.
 func (c *client) Post(url string, request, response any) error {
+	return c.PostContext(context.Background(), url, request, response)
+}
+
+// PostContext sends a request as JSON, bound to ctx, and unmarshals the response into the provided object.
+func (c *client) PostContext(ctx context.Context, url string, request, response any) error {
 	var err error
 
 	var body []byte
@@ -168,26 +270,32 @@ func (c *client) Post(url string, request, response any) error {
 	}
 
 	req, err := http.NewRequestWithContext(
-		context.Background(),
+		ctx,
 		http.MethodPost,
 		url,
 		bytes.NewReader(body),
 	)
 	if err != nil {
-		return fmt.Errorf("creating POST request for %q: %w", url, err)
+		return fmt.Errorf("creating POST request for %q: %w", util.RedactURL(url), util.RedactError(err))
 	}
 
 	for key, val := range c.headers {
 		req.Header.Set(key, val[0])
 	}
 
+	if err := types.WaitRateLimit(ctx, req.URL.Hostname()); err != nil {
+		return fmt.Errorf("waiting for rate limit: %w", err)
+	}
+
 	res, err := c.httpClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("sending POST request to %q: %w", url, err)
+		return fmt.Errorf("sending POST request to %q: %w", util.RedactURL(url), util.RedactError(err))
 	}
 
 	defer func() { _ = res.Body.Close() }()
 
+	types.ReportHTTPStatus(ctx, res.StatusCode)
+
 	return parseResponse(res, response)
 }
 
@@ -208,12 +316,13 @@ func parseResponse(res *http.Response, response any) error {
 			body = []byte{}
 		}
 
-		return Error{
-			StatusCode: res.StatusCode,
-			Body:       string(body),
-			err:        err,
-		}
+		return NewError(res, string(body), err)
 	}
 
 	return nil
 }
+
+// secondsToDuration converts a non-negative number of seconds to a time.Duration.
+func secondsToDuration(seconds float64) time.Duration {
+	return max(time.Duration(seconds*float64(time.Second)), 0)
+}
@@ -8,12 +8,15 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/nicholas-fedor/shoutrrr/pkg/types"
 	"github.com/nicholas-fedor/shoutrrr/pkg/util/jsonclient/mocks"
 )
 
@@ -1571,3 +1574,131 @@ func TestError_Unwrap(t *testing.T) {
 	assert.Equal(t, "unknown error (HTTP 500)", jsonErrNil.Error(), "Error() should return generic message")
 	assert.Equal(t, "unknown error (HTTP 500)", jsonErrNil.String(), "String() should return generic message")
 }
+
+// TestError_Unwrap_ErrorsIs tests that errors.Is reaches the wrapped error.
+func TestError_Unwrap_ErrorsIs(t *testing.T) {
+	t.Parallel()
+
+	jsonErr := Error{
+		StatusCode: http.StatusBadGateway,
+		err:        fmt.Errorf("%w: 502 Bad Gateway", ErrUnexpectedStatus),
+	}
+
+	require.ErrorIs(t, jsonErr, ErrUnexpectedStatus, "errors.Is should find the wrapped error")
+	assert.Equal(t, http.StatusBadGateway, jsonErr.HTTPStatus(), "HTTPStatus() mismatch")
+}
+
+// TestParseResponse_KeepsHeaders tests that rate limit headers are surfaced on errors.
+func TestParseResponse_KeepsHeaders(t *testing.T) {
+	t.Parallel()
+
+	res := &http.Response{
+		StatusCode: http.StatusTooManyRequests,
+		Status:     "429 Too Many Requests",
+		Header:     http.Header{"Retry-After": []string{"7"}},
+		Body:       io.NopCloser(bytes.NewBufferString(`{}`)),
+	}
+
+	err := parseResponse(res, &map[string]any{})
+
+	var jsonErr Error
+	require.ErrorAs(t, err, &jsonErr, "parseResponse() should return an Error")
+
+	delay, ok := jsonErr.RetryAfter()
+	assert.True(t, ok, "RetryAfter() should report a delay")
+	assert.Equal(t, 7*time.Second, delay, "RetryAfter() mismatch")
+}
+
+// TestParseRetryAfter tests parsing of the supported rate limit headers.
+func TestParseRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		header http.Header
+		want   time.Duration
+		wantOK bool
+	}{
+		{
+			name:   "nil header",
+			header: nil,
+		},
+		{
+			name:   "no rate limit headers",
+			header: http.Header{"Content-Type": []string{ContentType}},
+		},
+		{
+			name:   "retry-after seconds",
+			header: http.Header{"Retry-After": []string{"30"}},
+			want:   30 * time.Second,
+			wantOK: true,
+		},
+		{
+			name:   "retry-after http date",
+			header: http.Header{"Retry-After": []string{now.Add(2 * time.Minute).Format(http.TimeFormat)}},
+			want:   2 * time.Minute,
+			wantOK: true,
+		},
+		{
+			name:   "retry-after date in the past",
+			header: http.Header{"Retry-After": []string{now.Add(-time.Minute).Format(http.TimeFormat)}},
+			want:   0,
+			wantOK: true,
+		},
+		{
+			name:   "reset-after fractional seconds",
+			header: http.Header{"X-Ratelimit-Reset-After": []string{"1.5"}},
+			want:   1500 * time.Millisecond,
+			wantOK: true,
+		},
+		{
+			name:   "reset unix timestamp",
+			header: http.Header{"X-Ratelimit-Reset": []string{strconv.FormatInt(now.Add(10*time.Second).Unix(), 10)}},
+			want:   10 * time.Second,
+			wantOK: true,
+		},
+		{
+			name:   "reset relative seconds",
+			header: http.Header{"X-Ratelimit-Reset": []string{"4"}},
+			want:   4 * time.Second,
+			wantOK: true,
+		},
+		{
+			name:   "invalid value",
+			header: http.Header{"Retry-After": []string{"soon"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := ParseRetryAfter(tt.header, now)
+			assert.Equal(t, tt.wantOK, ok, "ParseRetryAfter() ok mismatch")
+			assert.Equal(t, tt.want, got, "ParseRetryAfter() delay mismatch")
+		})
+	}
+}
+
+func TestPostContext_ReportsHTTPStatus(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	receipt := &types.DeliveryReceipt{}
+	ctx := types.WithDeliveryReceipt(context.Background(), receipt)
+
+	contextClient, ok := NewClient().(ContextClient)
+	require.True(t, ok, "client should implement ContextClient")
+
+	var response map[string]any
+
+	require.NoError(t, contextClient.PostContext(ctx, server.URL, map[string]string{}, &response))
+	assert.Equal(t, http.StatusAccepted, receipt.HTTPStatus())
+}
@@ -1,7 +1,10 @@
 package util
 
 import (
+	"regexp"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/nicholas-fedor/shoutrrr/pkg/types"
 )
@@ -9,6 +12,25 @@ import (
 // ellipsis is the suffix appended to truncated strings.
 const ellipsis = " [...]"
 
+const (
+	// codeFence opens and closes a fenced code block.
+	codeFence = "```"
+	// closeFence is appended to a part ending inside a fenced code block.
+	closeFence = "\n" + codeFence
+	// maxEntityRunes is the length of the longest character reference kept whole.
+	maxEntityRunes = 10
+)
+
+// htmlTagRegex matches an HTML start or end tag, capturing the slash of an end
+// tag, the element name and the slash of a self-closing tag.
+var htmlTagRegex = regexp.MustCompile(`<(/?)([a-zA-Z][a-zA-Z0-9-]*)(?:\s[^<>]*?)?(/?)>`)
+
+// voidElements are the HTML elements that have no end tag.
+var voidElements = map[string]bool{
+	"area": true, "base": true, "br": true, "col": true, "embed": true, "hr": true, "img": true,
+	"input": true, "link": true, "meta": true, "source": true, "track": true, "wbr": true,
+}
+
 // PartitionMessage splits input into chunks that fit within the specified limits.
 //
 // The function attempts to split at word boundaries (spaces or newlines) to improve
@@ -150,3 +172,282 @@ func MessageItemsFromLines(plain string, limits types.MessageLimit) [][]types.Me
 
 	return batches
 }
+
+// SplitMessage splits text into parts of at most limit runes, breaking at a newline,
+// or else at a space, in the second half of each part where possible.
+//
+// The split is markup-aware: parts never end inside an HTML tag or character
+// reference, and HTML elements and fenced code blocks spanning parts are closed at
+// the end of one part and reopened at the start of the next, so that every part
+// renders on its own.
+//
+// Parameters:
+//   - text: The message to split.
+//   - limit: The maximum number of runes per part; 0 or less means no limit.
+//
+// Returns:
+//   - The parts of text, a single part if it fits within limit.
+func SplitMessage(text string, limit int) []string {
+	runes := []rune(text)
+	if limit <= 0 || len(runes) <= limit {
+		return []string{text}
+	}
+
+	parts := make([]string, 0, len(runes)/limit+1)
+	reopen := ""
+
+	for len(runes) > 0 {
+		var part, closing string
+
+		part, closing, runes, reopen = cutPart(runes, limit, reopen)
+		if part = strings.TrimRightFunc(part, unicode.IsSpace); part != "" {
+			parts = append(parts, part+closing)
+		}
+	}
+
+	return parts
+}
+
+// TruncateMessage shortens text to at most limit runes, appending an ellipsis if
+// truncated. Like SplitMessage, it never cuts inside an HTML tag or character
+// reference, and closes the HTML elements and fenced code block left open.
+//
+// Parameters:
+//   - text: The message to truncate.
+//   - limit: The maximum number of runes; 0 or less means no limit.
+//
+// Returns:
+//   - The original text if it fits within limit, otherwise the truncated text
+//     with " [...]" appended.
+func TruncateMessage(text string, limit int) string {
+	runes := []rune(text)
+	if limit <= 0 || len(runes) <= limit {
+		return text
+	}
+
+	if limit <= len(ellipsis) {
+		return string(runes[:limit])
+	}
+
+	part, closing, _, _ := cutPart(runes, limit-len(ellipsis), "")
+
+	return strings.TrimRightFunc(part, unicode.IsSpace) + ellipsis + closing
+}
+
+// cutPart cuts the next part of a message for SplitMessage.
+//
+// Parameters:
+//   - runes: The rest of the message.
+//   - limit: The maximum number of runes of the part, including its closing markup.
+//   - reopen: The markup reopening the elements and code block the previous part
+//     ended in.
+//
+// Returns:
+//   - The part, without its closing markup.
+//   - The markup closing the elements and code block left open by the part.
+//   - The runes left after the part.
+//   - The markup reopening them in the next part, if any.
+func cutPart(runes []rune, limit int, reopen string) (string, string, []rune, string) {
+	prefix := []rune(reopen)
+	if closing, _ := closeMarkup(reopen); len(prefix)+utf8.RuneCountInString(closing) >= limit {
+		// Too small to carry the markup over.
+		prefix = nil
+	}
+
+	if len(prefix)+len(runes) <= limit {
+		return string(prefix) + string(runes), "", nil, ""
+	}
+
+	for budget := limit - len(prefix); ; {
+		end, next := splitPoint(runes, budget)
+
+		fence, open := openFence(string(prefix) + string(runes[:end]))
+		if open && fence.start > len(prefix) {
+			// Leave a code block opened in this part to the next one.
+			end, next = fence.start-len(prefix), fence.start-len(prefix)
+		}
+
+		part := string(prefix) + string(runes[:end])
+		closing, carry := closeMarkup(part)
+
+		closingLen := utf8.RuneCountInString(closing)
+		if len(prefix)+end+closingLen <= limit {
+			return part, closing, runes[next:], carry
+		}
+
+		// Leave room for the closing markup, giving up on it if the part is too small.
+		budget = min(budget-1, limit-len(prefix)-closingLen)
+		if budget <= 0 {
+			end, next = splitPoint(runes, limit-len(prefix))
+
+			return string(prefix) + string(runes[:end]), "", runes[next:], ""
+		}
+	}
+}
+
+// closeMarkup returns the markup closing the HTML elements and fenced code block
+// left open at the end of text, and the markup reopening them.
+func closeMarkup(text string) (string, string) {
+	var closing, reopen strings.Builder
+
+	fence, fenced := openFence(text)
+	if fenced {
+		closing.WriteString(closeFence)
+	}
+
+	tags := openTags(text)
+	for i := len(tags) - 1; i >= 0; i-- {
+		closing.WriteString("</" + tags[i].name + ">")
+	}
+
+	for _, tag := range tags {
+		reopen.WriteString(tag.open)
+	}
+
+	if fenced {
+		reopen.WriteString(fence.line + "\n")
+	}
+
+	return closing.String(), reopen.String()
+}
+
+// splitPoint returns where to cut the first part of at most budget runes off
+// runes, which must be longer than budget. Markup longer than half the part may
+// still be cut.
+//
+// Returns:
+//   - The end of the part.
+//   - The start of the next part, after the newline or space cut at, if any.
+func splitPoint(runes []rune, budget int) (int, int) {
+	end, next := budget, budget
+
+	found := false
+	for _, sep := range []rune{'\n', ' '} {
+		for i := budget; i > budget/2 && !found; i-- {
+			if runes[i] == sep {
+				end, next, found = i, i+1, true
+			}
+		}
+	}
+
+	if start := openMarkup(runes[:end]); start > budget/2 {
+		end, next = start, start
+	}
+
+	return end, next
+}
+
+// openMarkup returns the index of the HTML tag or character reference left open at
+// the end of part, or -1 if there is none.
+func openMarkup(part []rune) int {
+	for i := len(part) - 1; i >= 0; i-- {
+		switch part[i] {
+		case '>':
+			return -1
+		case '<':
+			if i+1 < len(part) && (unicode.IsLetter(part[i+1]) || part[i+1] == '/' || part[i+1] == '!') {
+				return i
+			}
+		case '&':
+			if len(part)-i <= maxEntityRunes && isEntityName(part[i+1:]) {
+				return i
+			}
+		}
+	}
+
+	return -1
+}
+
+// isEntityName reports whether name can start the name of a character reference.
+func isEntityName(name []rune) bool {
+	for _, r := range name {
+		if r != '#' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// fenceLine is the opening line of a fenced code block.
+type fenceLine struct {
+	// line is the fence line, with its info string.
+	line string
+	// start is the index of the first rune of the line.
+	start int
+}
+
+// openFence returns the opening line of the fenced code block left open at the
+// end of text, if any.
+func openFence(text string) (fenceLine, bool) {
+	var fence fenceLine
+
+	start := 0
+
+	for line := range strings.SplitSeq(text, "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, codeFence) {
+			switch {
+			case fence.line == "":
+				fence = fenceLine{line: trimmed, start: start}
+			case strings.Trim(trimmed, "`") == "":
+				fence = fenceLine{}
+			}
+		}
+
+		start += utf8.RuneCountInString(line) + 1
+	}
+
+	return fence, fence.line != ""
+}
+
+// htmlTag is an HTML element left open.
+type htmlTag struct {
+	// name is the lowercase element name.
+	name string
+	// open is the start tag, with its attributes.
+	open string
+}
+
+// openTags returns the HTML elements left open at the end of text, outermost
+// first. Void and self-closed elements, and tags inside fenced code blocks, are
+// ignored.
+func openTags(text string) []htmlTag {
+	var (
+		tags   []htmlTag
+		fenced bool
+	)
+
+	for line := range strings.SplitSeq(text, "\n") {
+		if trimmed := strings.TrimSpace(line); strings.HasPrefix(trimmed, codeFence) {
+			fenced = !fenced || strings.Trim(trimmed, "`") != ""
+
+			continue
+		}
+
+		if fenced {
+			continue
+		}
+
+		for _, match := range htmlTagRegex.FindAllStringSubmatch(line, -1) {
+			name := strings.ToLower(match[2])
+
+			switch {
+			case match[1] == "/":
+				for i := len(tags) - 1; i >= 0; i-- {
+					if tags[i].name == name {
+						tags = tags[:i]
+
+						break
+					}
+				}
+			case match[3] == "/" || voidElements[name]:
+			default:
+				tags = append(tags, htmlTag{name: name, open: match[0]})
+			}
+		}
+	}
+
+	return tags
+}
@@ -276,3 +276,143 @@ func TestMessageItemsFromLines_Truncation(t *testing.T) {
 		assert.True(t, strings.HasSuffix(batches[0][0].Text, " [...]"), "Truncated text should end with ellipsis")
 	})
 }
+
+func TestSplitMessage(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		text  string
+		limit int
+		want  []string
+	}{
+		{
+			name:  "fits within limit",
+			text:  "Hello World",
+			limit: 20,
+			want:  []string{"Hello World"},
+		},
+		{
+			name:  "no limit",
+			text:  "Hello World",
+			limit: 0,
+			want:  []string{"Hello World"},
+		},
+		{
+			name:  "splits at spaces",
+			text:  "hello world this is a test message",
+			limit: 15,
+			want:  []string{"hello world", "this is a test", "message"},
+		},
+		{
+			name:  "prefers newlines",
+			text:  "first line\nsecond line",
+			limit: 16,
+			want:  []string{"first line", "second line"},
+		},
+		{
+			name:  "splits without whitespace",
+			text:  "ABCDEFGHIJ",
+			limit: 4,
+			want:  []string{"ABCD", "EFGH", "IJ"},
+		},
+		{
+			name:  "counts runes",
+			text:  "äöü äöü",
+			limit: 4,
+			want:  []string{"äöü", "äöü"},
+		},
+		{
+			name:  "never splits inside a tag",
+			text:  `see <a href="https://example.com/x">link</a> and <b>more</b> text`,
+			limit: 50,
+			want:  []string{`see <a href="https://example.com/x">link</a> and`, "<b>more</b> text"},
+		},
+		{
+			name:  "never splits inside a character reference",
+			text:  "fish and chips &amp; peas",
+			limit: 18,
+			want:  []string{"fish and chips", "&amp; peas"},
+		},
+		{
+			name:  "carries code blocks over",
+			text:  "intro\n```go\nfunc a() {}\nfunc b() {}\nfunc c() {}\n```\ndone",
+			limit: 24,
+			want: []string{
+				"intro",
+				"```go\nfunc a() {}\n```",
+				"```go\nfunc b() {}\n```",
+				"```go\nfunc c() {}\n```",
+				"done",
+			},
+		},
+		{
+			name:  "carries inline elements over",
+			text:  "<b>disk usage on <i>backup</i> is above 90 percent</b> now",
+			limit: 30,
+			want: []string{
+				"<b>disk usage on</b>",
+				"<b><i>backup</i> is above</b>",
+				"<b>90 percent</b> now",
+			},
+		},
+		{
+			name:  "reopens elements with their attributes",
+			text:  `<a href="https://example.com">one two three four</a>`,
+			limit: 44,
+			want: []string{
+				`<a href="https://example.com">one two</a>`,
+				`<a href="https://example.com">three four</a>`,
+			},
+		},
+		{
+			name:  "ignores void elements",
+			text:  "first line<br>second line<br/>third line",
+			limit: 26,
+			want:  []string{"first line<br>second", "line<br/>third line"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := SplitMessage(tt.text, tt.limit)
+			assert.Equal(t, tt.want, got)
+
+			for _, part := range got {
+				if tt.limit > 0 {
+					assert.LessOrEqual(t, len([]rune(part)), tt.limit, "part %q exceeds the limit", part)
+				}
+			}
+		})
+	}
+}
+
+func TestTruncateMessage(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		text  string
+		limit int
+		want  string
+	}{
+		{"fits within limit", "Hello World", 20, "Hello World"},
+		{"truncates at a space", "hello world this is a test message", 20, "hello world [...]"},
+		{"shorter than the ellipsis", "Hello World", 4, "Hell"},
+		{"never truncates inside a tag", "some text <b>bold</b>", 20, "some text [...]"},
+		{"closes inline elements", "<b>hello world this is a test</b>", 24, "<b>hello world [...]</b>"},
+		{"closes code blocks", "```\nline one\nline two\nline three\n```", 31, "```\nline one\nline two [...]\n```"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := TruncateMessage(tt.text, tt.limit)
+			assert.Equal(t, tt.want, got)
+			assert.LessOrEqual(t, len([]rune(got)), tt.limit)
+		})
+	}
+}
@@ -1,6 +1,10 @@
 package util
 
-import "net/url"
+import (
+	"errors"
+	"net/url"
+	"strings"
+)
 
 // URLUserPassword creates a url.Userinfo from user and password strings.
 //
@@ -24,3 +28,132 @@ func URLUserPassword(user, password string) *url.Userinfo {
 
 	return nil
 }
+
+// RedactedPlaceholder replaces secrets in redacted URLs.
+const RedactedPlaceholder = "REDACTED"
+
+// sensitiveKeys are query parameter and header name fragments whose values are redacted.
+var sensitiveKeys = []string{"token", "key", "secret", "pass", "auth", "sig", "credential"}
+
+// RedactURL returns the service URL with everything that may hold a credential redacted.
+//
+// Userinfo and path segments are always redacted, as services store tokens there.
+// The host is kept if it looks like a network address (contains a dot or a port,
+// or is "localhost"); otherwise it is treated as a token. Query values are
+// redacted if their name looks sensitive. Unparsable URLs are fully redacted.
+//
+// Parameters:
+//   - rawURL: the service URL to redact.
+//
+// Returns:
+//   - string: the redacted URL.
+func RedactURL(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return RedactedPlaceholder
+	}
+
+	if parsed.User != nil {
+		parsed.User = url.User(RedactedPlaceholder)
+	}
+
+	if host := parsed.Hostname(); host != "" && !strings.Contains(parsed.Host, ".") &&
+		parsed.Port() == "" && host != "localhost" {
+		parsed.Host = RedactedPlaceholder
+	}
+
+	if parsed.Path != "" && parsed.Path != "/" {
+		segments := strings.Split(parsed.Path, "/")
+		for i, segment := range segments {
+			if segment != "" {
+				segments[i] = RedactedPlaceholder
+			}
+		}
+
+		parsed.Path = strings.Join(segments, "/")
+		parsed.RawPath = ""
+	}
+
+	if parsed.RawQuery != "" {
+		query := parsed.Query()
+		for name := range query {
+			if IsSensitiveKey(name) {
+				query.Set(name, RedactedPlaceholder)
+			}
+		}
+
+		parsed.RawQuery = query.Encode()
+	}
+
+	parsed.Opaque = ""
+	parsed.Fragment = ""
+
+	redacted := parsed.String()
+
+	// url.URL drops the empty authority of URLs such as "logger://", keep it.
+	if parsed.Host == "" && parsed.User == nil && parsed.Path == "" &&
+		strings.HasPrefix(rawURL, parsed.Scheme+"://") {
+		redacted = parsed.Scheme + "://" + strings.TrimPrefix(redacted, parsed.Scheme+":")
+	}
+
+	return redacted
+}
+
+// RedactError returns err with the URL of the *url.Error in its chain redacted
+// from its message, as HTTP clients report the full request URL on failure.
+// The returned error unwraps to err.
+//
+// Parameters:
+//   - err: the error to redact.
+//
+// Returns:
+//   - error: the redacted error, or err itself if it holds no URL to redact.
+func RedactError(err error) error {
+	var urlErr *url.Error
+	if !errors.As(err, &urlErr) || urlErr.URL == "" {
+		return err
+	}
+
+	redacted := RedactURL(urlErr.URL)
+	if redacted == urlErr.URL {
+		return err
+	}
+
+	return &redactedError{msg: strings.ReplaceAll(err.Error(), urlErr.URL, redacted), err: err}
+}
+
+// redactedError is an error whose message has been redacted.
+type redactedError struct {
+	msg string
+	err error
+}
+
+// Error returns the redacted message.
+func (e *redactedError) Error() string {
+	return e.msg
+}
+
+// Unwrap returns the original error.
+func (e *redactedError) Unwrap() error {
+	return e.err
+}
+
+// IsSensitiveKey reports whether a query parameter, form field or header name looks
+// like it holds a secret, e.g. "token", "apikey" or "Authorization".
+//
+// Parameters:
+//   - name: the name to check.
+//
+// Returns:
+//   - bool: true if values of name should be redacted.
+func IsSensitiveKey(name string) bool {
+	name = strings.ToLower(name)
+
+	for _, fragment := range sensitiveKeys {
+		if strings.Contains(name, fragment) {
+			return true
+		}
+	}
+
+	return false
+}
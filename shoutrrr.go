@@ -19,11 +19,15 @@
 package shoutrrr
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/nicholas-fedor/shoutrrr/internal/meta"
+	"github.com/nicholas-fedor/shoutrrr/pkg/config"
+	"github.com/nicholas-fedor/shoutrrr/pkg/format"
 	"github.com/nicholas-fedor/shoutrrr/pkg/router"
 	"github.com/nicholas-fedor/shoutrrr/pkg/types"
+	"github.com/nicholas-fedor/shoutrrr/pkg/util"
 )
 
 // defaultRouter manages the creation and routing of notification services.
@@ -31,27 +35,70 @@ var defaultRouter = router.ServiceRouter{
 	Timeout: router.DefaultTimeout,
 }
 
-// Send delivers a notification message using the specified URL.
+// Send delivers a notification message using the specified URL. Router params
+// such as __timeout are rejected, as they are only applied by a ServiceRouter.
 func Send(rawURL, message string) error {
-	service, err := defaultRouter.Locate(rawURL)
+	service, err := locate(rawURL)
 	if err != nil {
-		return fmt.Errorf("locating service for URL %q: %w", rawURL, err)
+		return err
 	}
 
 	if err := service.Send(message, &types.Params{}); err != nil {
-		return fmt.Errorf("sending message via service at %q: %w", rawURL, err)
+		return fmt.Errorf("sending message via service at %q: %w", format.RedactServiceURL(service), err)
 	}
 
 	return nil
 }
 
+// SendContext delivers a notification message using the specified URL, aborting
+// the request when ctx is done. Services that do not implement types.ContextSender
+// are sent to without the context. Router params are rejected, as with Send.
+func SendContext(ctx context.Context, rawURL, message string) error {
+	service, err := locate(rawURL)
+	if err != nil {
+		return err
+	}
+
+	send := service.Send
+	if sender, ok := service.(types.ContextSender); ok {
+		send = func(message string, params *types.Params) error {
+			return sender.SendContext(ctx, message, params)
+		}
+	}
+
+	if err := send(message, &types.Params{}); err != nil {
+		return fmt.Errorf("sending message via service at %q: %w", format.RedactServiceURL(service), err)
+	}
+
+	return nil
+}
+
+// locate returns the service for the URL of Send and SendContext, which deliver
+// to it directly and therefore cannot apply router params.
+func locate(rawURL string) (types.Service, error) {
+	if router.HasURLParams(rawURL) {
+		return nil, fmt.Errorf(
+			"locating service for URL %q: %w: router params are only applied by a ServiceRouter, see CreateSenderWithOptions",
+			util.RedactURL(rawURL),
+			router.ErrInvalidRouterParam,
+		)
+	}
+
+	service, err := defaultRouter.Locate(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("locating service for URL %q: %w", util.RedactURL(rawURL), err)
+	}
+
+	return service, nil
+}
+
 // CreateSender constructs a new service router for the given URLs without a logger.
 //
 // Deprecated: Use CreateSenderWithOptions.
 func CreateSender(rawURLs ...string) (*router.ServiceRouter, error) {
 	serviceRouter, err := router.NewWithOptions(nil, types.SenderOptions{}, rawURLs...)
 	if err != nil {
-		return nil, fmt.Errorf("creating sender for URLs %v: %w", rawURLs, err)
+		return nil, fmt.Errorf("creating sender for URLs %v: %w", redactURLs(rawURLs), err)
 	}
 
 	return serviceRouter, nil
@@ -62,7 +109,7 @@ func CreateSender(rawURLs ...string) (*router.ServiceRouter, error) {
 func CreateSenderWithOptions(opts types.SenderOptions, rawURLs ...string) (*router.ServiceRouter, error) {
 	serviceRouter, err := router.NewWithOptions(nil, opts, rawURLs...)
 	if err != nil {
-		return nil, fmt.Errorf("creating sender with options for URLs %v: %w", rawURLs, err)
+		return nil, fmt.Errorf("creating sender with options for URLs %v: %w", redactURLs(rawURLs), err)
 	}
 
 	return serviceRouter, nil
@@ -74,7 +121,7 @@ func CreateSenderWithOptions(opts types.SenderOptions, rawURLs ...string) (*rout
 func NewSender(logger types.StdLogger, serviceURLs ...string) (*router.ServiceRouter, error) {
 	serviceRouter, err := router.NewWithOptions(logger, types.SenderOptions{}, serviceURLs...)
 	if err != nil {
-		return nil, fmt.Errorf("creating sender with logger for URLs %v: %w", serviceURLs, err)
+		return nil, fmt.Errorf("creating sender with logger for URLs %v: %w", redactURLs(serviceURLs), err)
 	}
 
 	return serviceRouter, nil
@@ -86,12 +133,45 @@ func NewSender(logger types.StdLogger, serviceURLs ...string) (*router.ServiceRo
 func NewSenderWithOptions(logger types.StdLogger, opts types.SenderOptions, serviceURLs ...string) (*router.ServiceRouter, error) {
 	serviceRouter, err := router.NewWithOptions(logger, opts, serviceURLs...)
 	if err != nil {
-		return nil, fmt.Errorf("creating sender with options for URLs %v: %w", serviceURLs, err)
+		return nil, fmt.Errorf("creating sender with options for URLs %v: %w", redactURLs(serviceURLs), err)
 	}
 
 	return serviceRouter, nil
 }
 
+// LoadConfig constructs a new service router from the targets, routes and
+// templates of a YAML or TOML config file, without a logger. Every target URL is
+// validated before any service is created. See package config for the file format.
+func LoadConfig(path string) (*router.ServiceRouter, error) {
+	return LoadConfigWithOptions(nil, types.SenderOptions{}, path)
+}
+
+// LoadConfigWithOptions constructs a new service router from a config file using
+// the given logger and SenderOptions.
+func LoadConfigWithOptions(logger types.StdLogger, opts types.SenderOptions, path string) (*router.ServiceRouter, error) {
+	cfg, err := config.Load(path)
+	if err != nil {
+		return nil, fmt.Errorf("loading config: %w", err)
+	}
+
+	serviceRouter, err := cfg.NewRouter(logger, opts)
+	if err != nil {
+		return nil, fmt.Errorf("creating sender from config %q: %w", path, err)
+	}
+
+	return serviceRouter, nil
+}
+
+// redactURLs returns the URLs with their credentials redacted, for use in errors.
+func redactURLs(rawURLs []string) []string {
+	redacted := make([]string, len(rawURLs))
+	for i, rawURL := range rawURLs {
+		redacted[i] = util.RedactURL(rawURL)
+	}
+
+	return redacted
+}
+
 // SetLogger configures the logger for all services in the default router.
 func SetLogger(logger types.StdLogger) {
 	defaultRouter.SetLogger(logger)
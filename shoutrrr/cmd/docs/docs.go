@@ -11,6 +11,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/nicholas-fedor/shoutrrr/pkg/color"
 	"github.com/nicholas-fedor/shoutrrr/pkg/format"
 	"github.com/nicholas-fedor/shoutrrr/pkg/router"
 	"github.com/nicholas-fedor/shoutrrr/shoutrrr/cmd"
@@ -19,8 +20,6 @@ import (
 var (
 	// serviceRouter manages the creation and initialization of notification services.
 	serviceRouter router.ServiceRouter
-	// services contains the list of available service schemes.
-	services = serviceRouter.ListServices()
 
 	// Cmd is the cobra command for generating service documentation.
 	// It displays configuration options and documentation for specified services.
@@ -29,17 +28,32 @@ var (
 		Short: "Print documentation for services",
 		Run:   Run,
 		Args: func(cmd *cobra.Command, args []string) error {
-			serviceList := strings.Join(services, ", ")
+			// Services registered at runtime are listed alongside the built-in ones.
+			serviceList := strings.Join(serviceRouter.ListServices(), ", ")
 			cmd.SetUsageTemplate(
 				cmd.UsageTemplate() + "\nAvailable services: \n  " + serviceList + "\n",
 			)
 
 			return cobra.MinimumNArgs(1)(cmd, args)
 		},
-		ValidArgs: services,
+		ValidArgsFunction: validArgs,
 	}
 )
 
+// validArgs completes the service schemes, including the ones registered at runtime.
+//
+// Parameters:
+//   - _: Unused cobra command.
+//   - _: Unused positional arguments already given.
+//   - _: Unused prefix of the argument to complete.
+//
+// Returns:
+//   - []string: The service schemes.
+//   - cobra.ShellCompDirective: The completion directive.
+func validArgs(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	return serviceRouter.ListServices(), cobra.ShellCompDirectiveNoFileComp
+}
+
 // init initializes the command flags for the docs command.
 func init() {
 	Cmd.Flags().StringP("format", "f", "console", "Output format (console or markdown)")
@@ -132,5 +146,49 @@ func printDocs(docFormat string, services []string) cmd.ExitError {
 		_, _ = fmt.Fprint(os.Stdout, renderer.RenderTree(configNode, scheme))
 	}
 
+	_, _ = fmt.Fprint(os.Stdout, renderURLParams(docFormat, router.URLParams()))
+
 	return cmd.ErrNil
 }
+
+// renderURLParams documents the router params accepted on every service URL.
+//
+// Parameters:
+//   - docFormat: The output format ("console" or "markdown").
+//   - params: The router params to document.
+//
+// Returns:
+//   - string: The rendered documentation.
+func renderURLParams(docFormat string, params []router.URLParam) string {
+	builder := strings.Builder{}
+
+	if docFormat == "markdown" {
+		builder.WriteString("\n### Router Params\n\n")
+		builder.WriteString("Router params can be added to the query of any service URL.\n")
+		builder.WriteString("They are applied by the router and removed before the service reads the URL.\n\n")
+
+		for _, param := range params {
+			fmt.Fprintf(&builder, "* `%s` - %s\n", param.Name, param.Description)
+		}
+
+		return builder.String()
+	}
+
+	nameWidth := 0
+	for _, param := range params {
+		nameWidth = max(nameWidth, len(param.Name))
+	}
+
+	builder.WriteString("\nRouter params, accepted on every service URL:\n")
+
+	for _, param := range params {
+		builder.WriteString(param.Name)
+		builder.WriteString(strings.Repeat(" ", nameWidth-len(param.Name)+1))
+		builder.WriteString(color.CyanString(param.Type))
+		builder.WriteString(strings.Repeat(" ", max(format.DefaultValueOffset-len(param.Type), 1)))
+		builder.WriteString(format.ColorizeDesc(param.Description))
+		builder.WriteString("\n")
+	}
+
+	return builder.String()
+}
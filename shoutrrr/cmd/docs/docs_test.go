@@ -5,7 +5,11 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"github.com/nicholas-fedor/shoutrrr/pkg/router"
+	"github.com/nicholas-fedor/shoutrrr/pkg/services/specialized/logger"
+	"github.com/nicholas-fedor/shoutrrr/pkg/types"
 	"github.com/nicholas-fedor/shoutrrr/shoutrrr/cmd"
 )
 
@@ -217,16 +221,20 @@ func Test_printDocs_EdgeCases(t *testing.T) {
 	}
 }
 
-// TestCmd_ValidArgs verifies that the Cmd has valid service arguments.
+// TestCmd_ValidArgs verifies that the Cmd completes the service arguments,
+// including services registered at runtime.
+//
+//nolint:paralleltest // Modifies the shared service registry.
 func TestCmd_ValidArgs(t *testing.T) {
-	t.Parallel()
+	require.NoError(t, router.RegisterService("mock-docs", func() types.Service { return &logger.Service{} }))
+	defer router.UnregisterService("mock-docs")
 
-	// Verify that services variable is populated
-	assert.NotEmpty(t, services, "services list should not be empty")
+	require.NotNil(t, Cmd.ValidArgsFunction, "Cmd.ValidArgsFunction should be set")
 
-	// Verify Cmd has ValidArgs set
-	assert.NotEmpty(t, Cmd.ValidArgs, "Cmd.ValidArgs should not be empty")
-	assert.Equal(t, services, Cmd.ValidArgs, "Cmd.ValidArgs should match services list")
+	completions, directive := Cmd.ValidArgsFunction(Cmd, nil, "")
+	assert.Contains(t, completions, "discord", "completions should list the built-in services")
+	assert.Contains(t, completions, "mock-docs", "completions should list the registered services")
+	assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
 }
 
 // TestCmd_Flags verifies that the Cmd has the expected flags.
@@ -239,3 +247,21 @@ func TestCmd_Flags(t *testing.T) {
 	assert.Equal(t, "console", formatFlag.DefValue, "format flag default should be 'console'")
 	assert.Equal(t, "f", formatFlag.Shorthand, "format flag shorthand should be 'f'")
 }
+
+// Test_renderURLParams tests that the router params are documented in both formats.
+func Test_renderURLParams(t *testing.T) {
+	t.Parallel()
+
+	params := router.URLParams()
+
+	markdown := renderURLParams("markdown", params)
+	console := renderURLParams("console", params)
+
+	assert.Contains(t, markdown, "### Router Params")
+
+	for _, param := range params {
+		assert.Contains(t, markdown, "* `"+param.Name+"` - "+param.Description)
+		assert.Contains(t, console, param.Name)
+		assert.Contains(t, console, param.Description)
+	}
+}
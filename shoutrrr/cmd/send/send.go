@@ -7,10 +7,12 @@ import (
 	"io"
 	"log"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/spf13/cobra"
 
+	"github.com/nicholas-fedor/shoutrrr"
 	"github.com/nicholas-fedor/shoutrrr/internal/dedupe"
 	internalUtil "github.com/nicholas-fedor/shoutrrr/internal/util"
 	"github.com/nicholas-fedor/shoutrrr/pkg/router"
@@ -25,6 +27,9 @@ const (
 	MaxMessageLength = 100
 )
 
+// cliGroupName is the name of the routing group formed from the --url values.
+const cliGroupName = "cli"
+
 // Cmd is the cobra command for sending notifications.
 // It sends messages to one or more notification services using configured URLs.
 var Cmd = &cobra.Command{
@@ -39,10 +44,11 @@ var Cmd = &cobra.Command{
 func init() {
 	Cmd.Flags().BoolP("verbose", "v", false, "Enable verbose output")
 	Cmd.Flags().StringArrayP("url", "u", []string{}, "The notification URL(s) to send to")
+	Cmd.Flags().StringP("config", "c", "", "A YAML or TOML config file defining the targets to send to")
+	Cmd.Flags().StringP("route", "r", "", "The route of the config file to send to (default: all targets)")
 
-	if err := Cmd.MarkFlagRequired("url"); err != nil {
-		fmt.Fprintf(os.Stderr, "Error marking URL flag as required: %v\n", err)
-	}
+	Cmd.MarkFlagsOneRequired("url", "config")
+	Cmd.MarkFlagsMutuallyExclusive("url", "config")
 
 	Cmd.Flags().
 		StringP("message", "m", "", "The message to send to the notification url, or - to read message from stdin")
@@ -52,6 +58,10 @@ func init() {
 	}
 
 	Cmd.Flags().StringP("title", "t", "", "The title used for services that support it")
+	Cmd.Flags().
+		StringP("group", "g", "", "Route the URLs as one group: failover, first-success or quorum (default: send to all)")
+	Cmd.Flags().Int("quorum", 0, "The number of URLs that must succeed in quorum mode (default: all)")
+	Cmd.Flags().StringArrayP("attach", "a", []string{}, "A file to attach for services that support it")
 }
 
 // Run executes the send command and handles its result.
@@ -124,6 +134,50 @@ func run(cmd *cobra.Command) error {
 		return fmt.Errorf("failed to get title flag: %w", err)
 	}
 
+	// Retrieve routing group flags.
+	groupMode, err := flags.GetString("group")
+	if err != nil {
+		return fmt.Errorf("failed to get group flag: %w", err)
+	}
+
+	quorum, err := flags.GetInt("quorum")
+	if err != nil {
+		return fmt.Errorf("failed to get quorum flag: %w", err)
+	}
+
+	// Retrieve config file flags.
+	configPath, err := flags.GetString("config")
+	if err != nil {
+		return fmt.Errorf("failed to get config flag: %w", err)
+	}
+
+	route, err := flags.GetString("route")
+	if err != nil {
+		return fmt.Errorf("failed to get route flag: %w", err)
+	}
+
+	// Retrieve attachment flag.
+	attachPaths, err := flags.GetStringArray("attach")
+	if err != nil {
+		return fmt.Errorf("failed to get attach flag: %w", err)
+	}
+
+	if route != "" && configPath == "" {
+		return cli.InvalidUsage("--route requires --config")
+	}
+
+	if groupMode != "" && configPath != "" {
+		return cli.InvalidUsage("--group cannot be used with --config; set the mode of a route in the config file")
+	}
+
+	if len(attachPaths) > 0 && (groupMode != "" || route != "") {
+		return cli.InvalidUsage("--attach cannot be used with --group or --route")
+	}
+
+	if flags.Changed("quorum") && groupMode != router.GroupQuorum.String() {
+		return cli.InvalidUsage("--quorum requires --group quorum")
+	}
+
 	// Read message from stdin if requested.
 	if message == "-" {
 		logf("Reading from STDIN...")
@@ -144,9 +198,17 @@ func run(cmd *cobra.Command) error {
 	var logger *log.Logger
 
 	if verbose {
+		if configPath != "" {
+			logf("Config: %s", configPath)
+		}
+
+		if route != "" {
+			logf("Route: %s", route)
+		}
+
 		urlsPrefix := "URLs:"
 		for i, url := range urls {
-			logf("%s %s", urlsPrefix, url)
+			logf("%s %s", urlsPrefix, util.RedactURL(url))
 
 			if i == 0 {
 				// Only display "URLs:" prefix for first line, replace with indentation for subsequent lines.
@@ -160,20 +222,55 @@ func run(cmd *cobra.Command) error {
 			logf("Title: %v", title)
 		}
 
+		for _, path := range attachPaths {
+			logf("Attachment: %s", path)
+		}
+
 		logger = log.New(os.Stderr, "SHOUTRRR ", log.LstdFlags)
 	} else {
 		logger = util.DiscardLogger
 	}
 
+	params := make(types.Params)
+	if title != "" {
+		params["title"] = title
+	}
+
+	if groupMode != "" {
+		return sendGroup(logger, groupMode, quorum, urls, message, params)
+	}
+
 	// Create service router and send notification.
-	serviceRouter, err := router.NewWithOptions(logger, types.SenderOptions{}, urls...)
+	var serviceRouter *router.ServiceRouter
+	if configPath != "" {
+		serviceRouter, err = shoutrrr.LoadConfigWithOptions(logger, types.SenderOptions{ExpandSecrets: true}, configPath)
+	} else {
+		serviceRouter, err = router.NewWithOptions(logger, types.SenderOptions{ExpandSecrets: true}, urls...)
+	}
+
 	if err != nil {
 		return cli.ConfigurationError(fmt.Sprintf("error invoking send: %s", err))
 	}
 
-	params := make(types.Params)
-	if title != "" {
-		params["title"] = title
+	if route != "" {
+		return reportGroup(serviceRouter.SendGroup(route, message, &params))
+	}
+
+	if len(attachPaths) > 0 {
+		items, err := attachmentItems(message, attachPaths)
+		if err != nil {
+			return err
+		}
+
+		for _, err := range serviceRouter.SendItems(items, params) {
+			if err != nil {
+				return cli.TaskUnavailable(err.Error())
+			}
+
+			logf("Notification sent")
+		}
+
+		return nil
 	}
 
 	errs := serviceRouter.SendAsync(message, &params)
@@ -187,3 +284,101 @@ func run(cmd *cobra.Command) error {
 
 	return nil
 }
+
+// attachmentItems reads the files to attach and returns the message items
+// carrying them. The first file is attached to the message item, each further
+// file gets an item of its own.
+//
+// Parameters:
+//   - message: The message to send.
+//   - paths: The paths of the files to attach.
+//
+// Returns:
+//   - []types.MessageItem: The message items to send.
+//   - error: An error if a file cannot be read.
+func attachmentItems(message string, paths []string) ([]types.MessageItem, error) {
+	items := []types.MessageItem{{Text: message}}
+
+	for i, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, cli.InvalidUsage(fmt.Sprintf("failed to read attachment: %s", err))
+		}
+
+		file := &types.File{Name: filepath.Base(path), Data: data}
+		if i == 0 {
+			items[0].File = file
+		} else {
+			items = append(items, types.MessageItem{File: file})
+		}
+	}
+
+	return items, nil
+}
+
+// sendGroup sends the message to the URLs as a single routing group and reports
+// which of them delivered it.
+//
+// Parameters:
+//   - logger: The logger passed to the services.
+//   - groupMode: The routing group mode name.
+//   - quorum: The number of URLs that must succeed in quorum mode.
+//   - urls: The member URLs, in failover order.
+//   - message: The message to send.
+//   - params: The parameters to apply.
+//
+// Returns:
+//   - error: An error if the group fails to deliver, or nil on success.
+func sendGroup(
+	logger *log.Logger,
+	groupMode string,
+	quorum int,
+	urls []string,
+	message string,
+	params types.Params,
+) error {
+	mode, err := router.ParseGroupMode(groupMode)
+	if err != nil {
+		return cli.InvalidUsage(err.Error())
+	}
+
+	serviceRouter, err := router.NewWithOptions(logger, types.SenderOptions{ExpandSecrets: true})
+	if err != nil {
+		return cli.ConfigurationError(fmt.Sprintf("error invoking send: %s", err))
+	}
+
+	if err := serviceRouter.AddGroup(cliGroupName, router.GroupOptions{Mode: mode, Quorum: quorum}, urls...); err != nil {
+		return cli.ConfigurationError(fmt.Sprintf("error invoking send: %s", err))
+	}
+
+	return reportGroup(serviceRouter.SendGroup(cliGroupName, message, &params))
+}
+
+// reportGroup reports which members of a routing group delivered the message.
+//
+// Parameters:
+//   - result: The outcome of sending to the group.
+//
+// Returns:
+//   - error: An error if the group fails to deliver, or nil on success.
+func reportGroup(result router.GroupResult) error {
+	if errors.Is(result.Err, router.ErrUnknownGroup) {
+		return cli.InvalidUsage(result.Err.Error())
+	}
+
+	if result.Skipped {
+		logf("Notification skipped by the level filter of %s", result.Group)
+
+		return nil
+	}
+
+	for _, delivery := range result.Delivered() {
+		logf("Notification sent by %s", delivery.URL)
+	}
+
+	if result.Err != nil {
+		return cli.TaskUnavailable(result.Err.Error())
+	}
+
+	return nil
+}
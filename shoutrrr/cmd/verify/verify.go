@@ -2,6 +2,7 @@
 package verify
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -72,6 +73,7 @@ func Run(cmd *cobra.Command, _ []string) {
 	serviceRouter = router.ServiceRouter{
 		Timeout: 0,
 	}
+	serviceRouter.SetExpandSecrets(true)
 
 	// Locate the service for the provided URL.
 	service, err := serviceRouter.Locate(URL)
@@ -82,8 +84,8 @@ func Run(cmd *cobra.Command, _ []string) {
 		os.Exit(1)
 	}
 
-	// Retrieve and display the service configuration.
-	config := format.GetServiceConfig(service)
+	// Retrieve and display the service configuration, with its credentials redacted.
+	config := format.RedactConfig(format.GetServiceConfig(service))
 	configNode := format.GetConfigFormat(config)
 
 	cfg := color.DefaultConfig()
@@ -102,6 +104,15 @@ func sanitizeError(err error) string {
 	errStr := err.Error()
 
 	// Check for common error patterns without exposing URL details.
+	if strings.Contains(errStr, "expanding secrets") {
+		return "unable to resolve secret reference"
+	}
+
+	// Router param errors name the param and its value, which are not credentials.
+	if errors.Is(err, router.ErrInvalidRouterParam) {
+		return errStr[strings.Index(errStr, router.ErrInvalidRouterParam.Error()):]
+	}
+
 	if strings.Contains(errStr, "unknown service") {
 		return "service not recognized"
 	}
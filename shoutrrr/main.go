@@ -7,6 +7,7 @@ import (
 	"github.com/spf13/viper"
 
 	"github.com/nicholas-fedor/shoutrrr/internal/meta"
+	"github.com/nicholas-fedor/shoutrrr/pkg/secrets"
 	"github.com/nicholas-fedor/shoutrrr/shoutrrr/cmd"
 	"github.com/nicholas-fedor/shoutrrr/shoutrrr/cmd/docs"
 	"github.com/nicholas-fedor/shoutrrr/shoutrrr/cmd/generate"
@@ -19,8 +20,20 @@ var cobraCmd = &cobra.Command{
 	Short: "Shoutrrr CLI",
 }
 
+// allowCmdSecrets enables ${cmd:...} secret references, which run arbitrary commands.
+var allowCmdSecrets bool
+
 func init() {
 	viper.AutomaticEnv()
+
+	cobraCmd.PersistentFlags().BoolVar(
+		&allowCmdSecrets,
+		"allow-cmd-secrets",
+		false,
+		"Allows ${cmd:...} secret references in URLs, which run the referenced command",
+	)
+	cobra.OnInitialize(registerSecretProviders)
+
 	cobraCmd.AddCommand(verify.Cmd)
 	cobraCmd.AddCommand(generate.Cmd)
 	cobraCmd.AddCommand(send.Cmd)
@@ -29,6 +42,15 @@ func init() {
 	cobraCmd.Version = meta.GetMetaStr()
 }
 
+// registerSecretProviders registers the cmd secret provider if the user opted in
+// with --allow-cmd-secrets, so URLs from scripts or config files cannot run
+// commands unless explicitly allowed.
+func registerSecretProviders() {
+	if allowCmdSecrets {
+		_ = secrets.Register("cmd", secrets.Command())
+	}
+}
+
 func main() {
 	if err := cobraCmd.Execute(); err != nil {
 		os.Exit(cmd.ExUsage)